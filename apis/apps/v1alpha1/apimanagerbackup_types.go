@@ -17,7 +17,25 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+const (
+	// APIManagerBackupVerifiedConditionType reports whether the stored backup
+	// data matches the checksums of the backup integrity manifest
+	APIManagerBackupVerifiedConditionType common.ConditionType = "Verified"
+
+	APIManagerBackupChecksumsVerifiedReason  common.ConditionReason = "ChecksumsVerified"
+	APIManagerBackupVerificationFailedReason common.ConditionReason = "VerificationFailed"
+
+	// APIManagerBackupFailedConditionType reports that a backup Job or the
+	// backup data verification failed and the backup will not complete
+	APIManagerBackupFailedConditionType common.ConditionType = "Failed"
+
+	APIManagerBackupJobFailedReason common.ConditionReason = "JobFailed"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -30,6 +48,22 @@ type APIManagerBackupSpec struct {
 
 	// Backup data destination configuration
 	BackupDestination APIManagerBackupDestination `json:"backupDestination"`
+
+	// Snapshot of the Redis databases managed by the operator
+	// +optional
+	Redis *APIManagerBackupRedis `json:"redis,omitempty"`
+}
+
+// APIManagerBackupRedis defines which Redis databases managed by the
+// operator are snapshotted into the backup data. The snapshot is an RDB file
+// generated with the Redis BGSAVE command
+type APIManagerBackupRedis struct {
+	// Snapshot the backend Redis database
+	// +optional
+	Backend *bool `json:"backend,omitempty"`
+	// Snapshot the system Redis database
+	// +optional
+	System *bool `json:"system,omitempty"`
 }
 
 // APIManagerBackupDestination defines the backup data destination
@@ -39,6 +73,9 @@ type APIManagerBackupDestination struct {
 	// PersistentVolumeClaim as backup data destination configuration
 	// +optional
 	PersistentVolumeClaim *PersistentVolumeClaimBackupDestination `json:"persistentVolumeClaim,omitempty"`
+	// S3 API-compatible object storage as backup data destination configuration
+	// +optional
+	S3 *S3BackupDestination `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimBackupDestination defines the configuration
@@ -62,6 +99,38 @@ type PersistentVolumeClaimBackupDestination struct {
 	StorageClass *string `json:"storageClass,omitempty"`
 }
 
+// S3BackupDestination defines the configuration of the S3 API-compatible
+// object storage bucket to be used as the backup data destination.
+// The backup data is stored under the <prefix>/<APIManagerBackup name> path
+// of the bucket
+type S3BackupDestination struct {
+	S3ObjectStorageSpec `json:",inline"`
+
+	// Path prefix inside the bucket where the backups are stored
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// S3ObjectStorageSpec defines the access configuration to a S3 API-compatible
+// object storage bucket
+type S3ObjectStorageSpec struct {
+	// Name of the bucket
+	Bucket string `json:"bucket"`
+
+	// Region of the bucket
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// Custom S3 API-compatible endpoint URL. Used to target object storage
+	// services other than AWS S3, like MinIO
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Secret containing the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// credentials to access the bucket
+	CredentialsSecretRef v1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
 // APIManagerBackupStatus defines the observed state of APIManagerBackup
 type APIManagerBackupStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -92,6 +161,19 @@ type APIManagerBackupStatus struct {
 	// PersistentVolumeClaim is used as the backup data destination
 	// +optional
 	BackupPersistentVolumeClaimName *string `json:"backupPersistentVolumeClaimName,omitempty"`
+
+	// Location of the backup data in the S3 API-compatible object storage,
+	// in s3://<bucket>/<path> form. Only set when S3 is used as the
+	// backup data destination
+	// +optional
+	BackupS3Location *string `json:"backupS3Location,omitempty"`
+
+	// Current state of the APIManagerBackup resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:object:root=true
@@ -116,6 +198,17 @@ func (a *APIManagerBackup) BackupCompleted() bool {
 	return a.Status.Completed != nil && *a.Status.Completed
 }
 
+// BackupFailed returns true when the backup will not complete, either
+// because a backup Job failed or because the backup data verification failed
+func (a *APIManagerBackup) BackupFailed() bool {
+	if a.Status.Conditions.IsTrueFor(APIManagerBackupFailedConditionType) {
+		return true
+	}
+
+	verifiedCondition := a.Status.Conditions.GetCondition(APIManagerBackupVerifiedConditionType)
+	return verifiedCondition != nil && !verifiedCondition.IsTrue()
+}
+
 func (a *APIManagerBackup) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
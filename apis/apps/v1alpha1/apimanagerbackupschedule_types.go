@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// APIManagerBackupScheduleLabelKey is the label set on the APIManagerBackup
+	// objects created by an APIManagerBackupSchedule. Its value is the name of
+	// the APIManagerBackupSchedule
+	APIManagerBackupScheduleLabelKey = "apps.3scale.net/apimanagerbackupschedule"
+)
+
+// APIManagerBackupScheduleSpec defines the desired state of APIManagerBackupSchedule
+type APIManagerBackupScheduleSpec struct {
+	// Schedule in Cron format. See https://en.wikipedia.org/wiki/Cron
+	Schedule string `json:"schedule"`
+
+	// Suspend subsequent backups. Already created backups are not affected
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// Template of the spec of the APIManagerBackup objects created by the schedule
+	BackupTemplate APIManagerBackupSpec `json:"backupTemplate"`
+
+	// Retention rules of the completed APIManagerBackup objects created by
+	// the schedule. When not set, all backups are kept
+	// +optional
+	Retention *APIManagerBackupScheduleRetention `json:"retention,omitempty"`
+}
+
+// APIManagerBackupScheduleRetention defines which of the completed backups
+// created by an APIManagerBackupSchedule are kept. A backup is kept when it
+// is matched by any of the set rules. The remaining completed backups are
+// deleted, together with their backup data PersistentVolumeClaim
+type APIManagerBackupScheduleRetention struct {
+	// Number of most recent completed backups to keep
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// Number of days completed backups are kept
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepDays *int32 `json:"keepDays,omitempty"`
+}
+
+// APIManagerBackupScheduleStatus defines the observed state of APIManagerBackupSchedule
+type APIManagerBackupScheduleStatus struct {
+	// Last time a backup was scheduled. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Next time a backup is scheduled. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Completion time of the last successful backup. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Name of the last successful APIManagerBackup
+	// +optional
+	LastSuccessfulBackupName *string `json:"lastSuccessfulBackupName,omitempty"`
+
+	// Reason of the last failure scheduling or pruning backups
+	// +optional
+	LastFailureReason *string `json:"lastFailureReason,omitempty"`
+
+	// Time of the last failure scheduling or pruning backups. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIManagerBackupSchedule represents a schedule of APIManager backups
+// +kubebuilder:resource:path=apimanagerbackupschedules,scope=Namespaced
+// +kubebuilder:printcolumn:JSONPath=".spec.schedule",name=Schedule,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.lastSuccessfulTime",name="Last Successful",type=date
+// +kubebuilder:printcolumn:JSONPath=".status.nextScheduleTime",name="Next Schedule",type=date
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIManagerBackupSchedule"
+type APIManagerBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIManagerBackupScheduleSpec   `json:"spec,omitempty"`
+	Status APIManagerBackupScheduleStatus `json:"status,omitempty"`
+}
+
+func (a *APIManagerBackupSchedule) SetDefaults() (bool, error) {
+	return false, nil
+}
+
+func (a *APIManagerBackupSchedule) IsSuspended() bool {
+	return a.Spec.Suspend != nil && *a.Spec.Suspend
+}
+
+// +kubebuilder:object:root=true
+
+// APIManagerBackupScheduleList contains a list of APIManagerBackupSchedule
+type APIManagerBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManagerBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManagerBackupSchedule{}, &APIManagerBackupScheduleList{})
+}
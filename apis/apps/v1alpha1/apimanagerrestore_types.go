@@ -19,6 +19,26 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+const (
+	// APIManagerRestoreVerifiedConditionType reports whether the backup data
+	// to be restored matches its backup integrity manifest
+	APIManagerRestoreVerifiedConditionType common.ConditionType = "Verified"
+
+	APIManagerRestoreManifestVerifiedReason common.ConditionReason = "ManifestVerified"
+	APIManagerRestoreManifestNotFoundReason common.ConditionReason = "ManifestNotFound"
+	APIManagerRestoreChecksumMismatchReason common.ConditionReason = "ChecksumMismatch"
+	APIManagerRestoreSpecHashMismatchReason common.ConditionReason = "SpecHashMismatch"
+
+	// APIManagerRestoreFailedConditionType reports that the backup data
+	// verification failed and the restore will not complete
+	APIManagerRestoreFailedConditionType common.ConditionType = "Failed"
+
+	APIManagerRestoreVerificationFailedReason common.ConditionReason = "VerificationFailed"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -30,6 +50,32 @@ type APIManagerRestoreSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	RestoreSource APIManagerRestoreSource `json:"restoreSource"`
+
+	// JSON merge patch (RFC 7386) applied to the spec of the backed up
+	// APIManager before it is restored. For example, to restore into a
+	// different wildcard domain: {"wildcardDomain": "staging.example.com"}
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	APIManagerOverrides *runtime.RawExtension `json:"apiManagerOverrides,omitempty"`
+
+	// Route host domains of the backed up 3scale tenants to be remapped.
+	// They are replaced in the restored system database before the routes
+	// are resynchronized
+	// +optional
+	RouteHostRemaps []RouteHostRemap `json:"routeHostRemaps,omitempty"`
+}
+
+// RouteHostRemap defines a domain of the backed up route hosts and the
+// domain that replaces it in the restored route hosts
+type RouteHostRemap struct {
+	// Domain of the backed up route hosts. For example prod.example.com
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`
+	From string `json:"from"`
+
+	// Domain replacing From in the restored route hosts. For example
+	// staging.example.com
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`
+	To string `json:"to"`
 }
 
 // APIManagerRestoreSource defines the backup data restore source
@@ -39,6 +85,9 @@ type APIManagerRestoreSource struct {
 	// +optional
 	// Restore data soure configuration
 	PersistentVolumeClaim *PersistentVolumeClaimRestoreSource `json:"persistentVolumeClaim,omitempty"`
+	// S3 API-compatible object storage restore data source configuration
+	// +optional
+	S3 *S3RestoreSource `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimRestoreSource defines the configuration
@@ -50,6 +99,17 @@ type PersistentVolumeClaimRestoreSource struct {
 	ClaimSource v1.PersistentVolumeClaimVolumeSource `json:"claimSource"`
 }
 
+// S3RestoreSource defines the configuration of the S3 API-compatible
+// object storage bucket to be used as the restore data source
+// for an APIManager restore
+type S3RestoreSource struct {
+	S3ObjectStorageSpec `json:",inline"`
+
+	// Path inside the bucket where the backup data is stored. It is the
+	// path part of the backupS3Location status field of the APIManagerBackup
+	Path string `json:"path"`
+}
+
 // APIManagerRestoreStatus defines the observed state of APIManagerRestore
 type APIManagerRestoreStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -75,6 +135,13 @@ type APIManagerRestoreStatus struct {
 	// Restore completion time. It is represented in RFC3339 form and is in UTC.
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Current state of the APIManagerRestore resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:object:root=true
@@ -99,6 +166,12 @@ func (a *APIManagerRestore) RestoreCompleted() bool {
 	return a.Status.Completed != nil && *a.Status.Completed
 }
 
+// RestoreFailed returns true when the restore will not complete because the
+// backup data verification failed
+func (a *APIManagerRestore) RestoreFailed() bool {
+	return a.Status.Conditions.IsTrueFor(APIManagerRestoreFailedConditionType)
+}
+
 func (a *APIManagerRestore) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
@@ -88,6 +88,11 @@ func (in *APIManagerBackupDestination) DeepCopyInto(out *APIManagerBackupDestina
 		*out = new(PersistentVolumeClaimBackupDestination)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupDestination)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupDestination.
@@ -132,10 +137,191 @@ func (in *APIManagerBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupRedis) DeepCopyInto(out *APIManagerBackupRedis) {
+	*out = *in
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.System != nil {
+		in, out := &in.System, &out.System
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupRedis.
+func (in *APIManagerBackupRedis) DeepCopy() *APIManagerBackupRedis {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupRedis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupSchedule) DeepCopyInto(out *APIManagerBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSchedule.
+func (in *APIManagerBackupSchedule) DeepCopy() *APIManagerBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleList) DeepCopyInto(out *APIManagerBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIManagerBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleList.
+func (in *APIManagerBackupScheduleList) DeepCopy() *APIManagerBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleRetention) DeepCopyInto(out *APIManagerBackupScheduleRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDays != nil {
+		in, out := &in.KeepDays, &out.KeepDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleRetention.
+func (in *APIManagerBackupScheduleRetention) DeepCopy() *APIManagerBackupScheduleRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleSpec) DeepCopyInto(out *APIManagerBackupScheduleSpec) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(APIManagerBackupScheduleRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleSpec.
+func (in *APIManagerBackupScheduleSpec) DeepCopy() *APIManagerBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleStatus) DeepCopyInto(out *APIManagerBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulBackupName != nil {
+		in, out := &in.LastSuccessfulBackupName, &out.LastSuccessfulBackupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastFailureReason != nil {
+		in, out := &in.LastFailureReason, &out.LastFailureReason
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleStatus.
+func (in *APIManagerBackupScheduleStatus) DeepCopy() *APIManagerBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerBackupSpec) DeepCopyInto(out *APIManagerBackupSpec) {
 	*out = *in
 	in.BackupDestination.DeepCopyInto(&out.BackupDestination)
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(APIManagerBackupRedis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSpec.
@@ -179,6 +365,18 @@ func (in *APIManagerBackupStatus) DeepCopyInto(out *APIManagerBackupStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BackupS3Location != nil {
+		in, out := &in.BackupS3Location, &out.BackupS3Location
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupStatus.
@@ -325,6 +523,11 @@ func (in *APIManagerRestoreSource) DeepCopyInto(out *APIManagerRestoreSource) {
 		*out = new(PersistentVolumeClaimRestoreSource)
 		**out = **in
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3RestoreSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSource.
@@ -341,6 +544,16 @@ func (in *APIManagerRestoreSource) DeepCopy() *APIManagerRestoreSource {
 func (in *APIManagerRestoreSpec) DeepCopyInto(out *APIManagerRestoreSpec) {
 	*out = *in
 	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
+	if in.APIManagerOverrides != nil {
+		in, out := &in.APIManagerOverrides, &out.APIManagerOverrides
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteHostRemaps != nil {
+		in, out := &in.RouteHostRemaps, &out.RouteHostRemaps
+		*out = make([]RouteHostRemap, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSpec.
@@ -379,6 +592,13 @@ func (in *APIManagerRestoreStatus) DeepCopyInto(out *APIManagerRestoreStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreStatus.
@@ -1444,6 +1664,84 @@ func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteHostRemap) DeepCopyInto(out *RouteHostRemap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteHostRemap.
+func (in *RouteHostRemap) DeepCopy() *RouteHostRemap {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteHostRemap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupDestination) DeepCopyInto(out *S3BackupDestination) {
+	*out = *in
+	in.S3ObjectStorageSpec.DeepCopyInto(&out.S3ObjectStorageSpec)
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupDestination.
+func (in *S3BackupDestination) DeepCopy() *S3BackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ObjectStorageSpec) DeepCopyInto(out *S3ObjectStorageSpec) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ObjectStorageSpec.
+func (in *S3ObjectStorageSpec) DeepCopy() *S3ObjectStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ObjectStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3RestoreSource) DeepCopyInto(out *S3RestoreSource) {
+	*out = *in
+	in.S3ObjectStorageSpec.DeepCopyInto(&out.S3ObjectStorageSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3RestoreSource.
+func (in *S3RestoreSource) DeepCopy() *S3RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(S3RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *STSSpec) DeepCopyInto(out *STSSpec) {
 	*out = *in
@@ -75,6 +75,10 @@ type TenantStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -172,6 +176,10 @@ func (b *TenantStatus) StatusEqual(other *TenantStatus, logger logr.Logger) bool
 
 	equal = conditionsEqual(TenantReadyConditionType, b.Conditions, other.Conditions)
 
+	if !b.LastSyncTime.Equal(other.LastSyncTime) {
+		equal = false
+	}
+
 	return equal
 }
 
@@ -71,6 +71,10 @@ type ActiveDocOpenAPIRefSpec struct {
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
 	// +optional
 	URL *string `json:"url,omitempty"`
+
+	// Git refers to the git repository file that contains the OpenAPI Document
+	// +optional
+	Git *GitSourceSpec `json:"git,omitempty"`
 }
 
 // ActiveDocSpec defines the desired state of ActiveDoc
@@ -127,6 +131,16 @@ type ActiveDocStatus struct {
 	// +optional
 	ProductResourceName *corev1.LocalObjectReference `json:"productResourceName,omitempty"`
 
+	// GitCommitSHA is the commit the OpenAPI document was read from, when
+	// read from a git repository
+	// +optional
+	GitCommitSHA string `json:"gitCommitSHA,omitempty"`
+
+	// OpenAPIDocumentHash is the hash of the OpenAPI document synchronized with 3scale,
+	// when the ActiveDoc is managed by an OpenAPI CR
+	// +optional
+	OpenAPIDocumentHash string `json:"openAPIDocumentHash,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Backend Spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -137,6 +151,10 @@ type ActiveDocStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (o *ActiveDocStatus) Equals(other *ActiveDocStatus, logger logr.Logger) bool {
@@ -158,6 +176,18 @@ func (o *ActiveDocStatus) Equals(other *ActiveDocStatus, logger logr.Logger) boo
 		return false
 	}
 
+	if o.GitCommitSHA != other.GitCommitSHA {
+		diff := cmp.Diff(o.GitCommitSHA, other.GitCommitSHA)
+		logger.V(1).Info("GitCommitSHA not equal", "difference", diff)
+		return false
+	}
+
+	if o.OpenAPIDocumentHash != other.OpenAPIDocumentHash {
+		diff := cmp.Diff(o.OpenAPIDocumentHash, other.OpenAPIDocumentHash)
+		logger.V(1).Info("OpenAPIDocumentHash not equal", "difference", diff)
+		return false
+	}
+
 	if o.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(o.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -173,6 +203,11 @@ func (o *ActiveDocStatus) Equals(other *ActiveDocStatus, logger logr.Logger) boo
 		return false
 	}
 
+	if !o.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", o.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
@@ -214,6 +249,22 @@ func (a *ActiveDoc) SetDefaults(logger logr.Logger) bool {
 
 func (a *ActiveDoc) Validate() field.ErrorList {
 	errors := field.ErrorList{}
+
+	openapiRefFldPath := field.NewPath("spec").Child("activeDocOpenAPIRef")
+	sources := 0
+	for _, isSet := range []bool{a.Spec.ActiveDocOpenAPIRef.SecretRef != nil, a.Spec.ActiveDocOpenAPIRef.URL != nil, a.Spec.ActiveDocOpenAPIRef.Git != nil} {
+		if isSet {
+			sources++
+		}
+	}
+	if sources > 1 {
+		errors = append(errors, field.Invalid(openapiRefFldPath, a.Spec.ActiveDocOpenAPIRef, "only one of secretRef, url or git is allowed"))
+	}
+
+	if a.Spec.ActiveDocOpenAPIRef.Git != nil {
+		errors = append(errors, a.Spec.ActiveDocOpenAPIRef.Git.Validate(openapiRefFldPath.Child("git"))...)
+	}
+
 	return errors
 }
 
@@ -85,6 +85,10 @@ type ApplicationStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (b *ApplicationStatus) Equals(annotationID string, other *ApplicationStatus, logger logr.Logger) bool {
@@ -130,6 +134,11 @@ func (b *ApplicationStatus) Equals(annotationID string, other *ApplicationStatus
 		return false
 	}
 
+	if !b.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", b.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
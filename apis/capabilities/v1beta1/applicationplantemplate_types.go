@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ApplicationPlanTemplateKind = "ApplicationPlanTemplate"
+)
+
+// ApplicationPlanTemplateSpec defines the desired state of ApplicationPlanTemplate
+type ApplicationPlanTemplateSpec struct {
+	// Application Plans added to the products referencing the template
+	// Map: system_name -> Application Plan Spec
+	ApplicationPlans map[string]ApplicationPlanSpec `json:"applicationPlans"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationPlanTemplate is the Schema for the applicationplantemplates API.
+// Products reference application plan templates to share application plans
+type ApplicationPlanTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ApplicationPlanTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationPlanTemplateList contains a list of ApplicationPlanTemplate
+type ApplicationPlanTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationPlanTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApplicationPlanTemplate{}, &ApplicationPlanTemplateList{})
+}
@@ -47,6 +47,14 @@ const (
 	// BackendFailedConditionType indicates that an error occurred during synchronization.
 	// The operator will retry.
 	BackendFailedConditionType common.ConditionType = "Failed"
+
+	// BackendDriftedConditionType indicates that the backend in 3scale differs from the BackendSpec.
+	// Only reported when the management policy is Observe
+	BackendDriftedConditionType common.ConditionType = "Drifted"
+
+	// BackendConflictConditionType indicates that a backend with the same system name exists in 3scale
+	// and it is not managed by this Backend. It must be adopted explicitly.
+	BackendConflictConditionType common.ConditionType = "Conflict"
 )
 
 var (
@@ -99,6 +107,13 @@ type BackendSpec struct {
 	// +optional
 	Methods map[string]MethodSpec `json:"methods,omitempty"`
 
+	// ManagementPolicy sets how the backend in 3scale is managed.
+	// Enforce (default) overwrites the backend in 3scale with the spec.
+	// Observe never changes the backend in 3scale, differences are reported in the Drifted condition
+	// +kubebuilder:validation:Enum=Enforce;Observe
+	// +optional
+	ManagementPolicy *string `json:"managementPolicy,omitempty"`
+
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
@@ -126,6 +141,10 @@ type BackendStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (b *BackendStatus) Equals(other *BackendStatus, logger logr.Logger) bool {
@@ -156,6 +175,11 @@ func (b *BackendStatus) Equals(other *BackendStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !b.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", b.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
@@ -263,6 +287,11 @@ func (backend *Backend) IsSynced() bool {
 	return backend.Status.Conditions.IsTrueFor(BackendSyncedConditionType)
 }
 
+// IsObserved returns true when the backend in 3scale is only observed, not changed
+func (backend *Backend) IsObserved() bool {
+	return backend.Spec.ManagementPolicy != nil && *backend.Spec.ManagementPolicy == ManagementPolicyObserve
+}
+
 func (backend *Backend) FindMetricOrMethod(ref string) bool {
 	if len(backend.Spec.Metrics) > 0 {
 		if _, ok := backend.Spec.Metrics[ref]; ok {
@@ -111,6 +111,10 @@ type CustomPolicyDefinitionStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (p *CustomPolicyDefinitionStatus) Equals(other *CustomPolicyDefinitionStatus, logger logr.Logger) bool {
@@ -141,6 +145,11 @@ func (p *CustomPolicyDefinitionStatus) Equals(other *CustomPolicyDefinitionStatu
 		return false
 	}
 
+	if !p.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", p.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
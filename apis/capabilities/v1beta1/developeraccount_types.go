@@ -69,6 +69,11 @@ type DeveloperAccountSpec struct {
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+
+	// AccountPlanRef references the AccountPlan resource the developer account is subscribed to.
+	// When not set, the developer account keeps the account plan assigned by 3scale
+	// +optional
+	AccountPlanRef *corev1.LocalObjectReference `json:"accountPlanRef,omitempty"`
 }
 
 // DeveloperAccountStatus defines the observed state of DeveloperAccount
@@ -96,6 +101,10 @@ type DeveloperAccountStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (a *DeveloperAccountStatus) Equals(other *DeveloperAccountStatus, logger logr.Logger) bool {
@@ -138,6 +147,11 @@ func (a *DeveloperAccountStatus) Equals(other *DeveloperAccountStatus, logger lo
 		return false
 	}
 
+	if !a.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", a.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
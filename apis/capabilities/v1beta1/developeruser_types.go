@@ -115,6 +115,10 @@ type DeveloperUserStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (a *DeveloperUserStatus) Equals(other *DeveloperUserStatus, logger logr.Logger) bool {
@@ -157,6 +161,11 @@ func (a *DeveloperUserStatus) Equals(other *DeveloperUserStatus, logger logr.Log
 		return false
 	}
 
+	if !a.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", a.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
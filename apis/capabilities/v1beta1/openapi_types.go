@@ -58,6 +58,44 @@ type OpenAPIRefSpec struct {
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
 	// +optional
 	URL *string `json:"url,omitempty"`
+
+	// ConfigMapRef refers to the configmap object that contains the OpenAPI Document
+	// +optional
+	ConfigMapRef *corev1.ObjectReference `json:"configMapRef,omitempty"`
+
+	// Key of the secret or configmap that contains the OpenAPI Document.
+	// Required when the secret or configmap contains more than one key.
+	// The other keys are the files the relative external references of the
+	// OpenAPI Document are resolved against, by path with `__` as directory separator
+	// +optional
+	Key *string `json:"key,omitempty"`
+
+	// Git refers to the git repository file that contains the OpenAPI Document
+	// +optional
+	Git *GitSourceSpec `json:"git,omitempty"`
+}
+
+// GitSourceSpec Reference to a file of a git repository
+type GitSourceSpec struct {
+	// URL of the git repository. http and https URLs are supported, as well
+	// as file URLs of repositories local to the operator
+	// +kubebuilder:validation:Pattern=`^(https?|file):\/\/.*$`
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit SHA to read the file from.
+	// Defaults to the default branch of the repository
+	// +optional
+	Ref *string `json:"ref,omitempty"`
+
+	// Path of the file within the repository. The relative external references
+	// of the OpenAPI Document are resolved against the repository tree
+	Path string `json:"path"`
+
+	// CredentialsSecretRef refers to the secret, in the same namespace, with the
+	// credentials of the git repository. The secret keys are "username" and
+	// "password", where the password can also be an access token
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // OpenAPISpec defines the desired state of OpenAPI
@@ -104,6 +142,22 @@ type OpenAPISpec struct {
 
 	// +optional
 	OIDC *OIDCSpec `json:"oidc,omitempty"`
+
+	// ActiveDoc enables the generation of an ActiveDoc from the OpenAPI document,
+	// bound to the generated product and kept updated when the document changes
+	// +optional
+	ActiveDoc *OpenAPIActiveDocSpec `json:"activeDoc,omitempty"`
+}
+
+// OpenAPIActiveDocSpec defines the desired state of the ActiveDoc generated from the OpenAPI document
+type OpenAPIActiveDocSpec struct {
+	// Published switch to publish the activedoc
+	// +optional
+	Published *bool `json:"published,omitempty"`
+
+	// SkipSwaggerValidations switch to skip OpenAPI validation
+	// +optional
+	SkipSwaggerValidations *bool `json:"skipSwaggerValidations,omitempty"`
 }
 
 // OpenAPIStatus defines the observed state of OpenAPI
@@ -123,6 +177,20 @@ type OpenAPIStatus struct {
 	// +optional
 	BackendResourceNames []corev1.LocalObjectReference `json:"backendResourceNames,omitempty"`
 
+	// ActiveDocResourceName references the managed ActiveDoc
+	// +optional
+	ActiveDocResourceName *corev1.LocalObjectReference `json:"activeDocResourceName,omitempty"`
+
+	// OpenAPIVersion is the detected version of the OpenAPI document, either
+	// Swagger 2.0, OpenAPI 3.0 or OpenAPI 3.1
+	// +optional
+	OpenAPIVersion string `json:"openapiVersion,omitempty"`
+
+	// GitCommitSHA is the commit the OpenAPI document was read from, when
+	// read from a git repository
+	// +optional
+	GitCommitSHA string `json:"gitCommitSHA,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Backend Spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -133,6 +201,10 @@ type OpenAPIStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (o *OpenAPIStatus) Equals(other *OpenAPIStatus, logger logr.Logger) bool {
@@ -154,6 +226,24 @@ func (o *OpenAPIStatus) Equals(other *OpenAPIStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(o.ActiveDocResourceName, other.ActiveDocResourceName) {
+		diff := cmp.Diff(o.ActiveDocResourceName, other.ActiveDocResourceName)
+		logger.V(1).Info("ActiveDocResourceName not equal", "difference", diff)
+		return false
+	}
+
+	if o.OpenAPIVersion != other.OpenAPIVersion {
+		diff := cmp.Diff(o.OpenAPIVersion, other.OpenAPIVersion)
+		logger.V(1).Info("OpenAPIVersion not equal", "difference", diff)
+		return false
+	}
+
+	if o.GitCommitSHA != other.GitCommitSHA {
+		diff := cmp.Diff(o.GitCommitSHA, other.GitCommitSHA)
+		logger.V(1).Info("GitCommitSHA not equal", "difference", diff)
+		return false
+	}
+
 	if o.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(o.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -169,6 +259,11 @@ func (o *OpenAPIStatus) Equals(other *OpenAPIStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !o.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", o.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
@@ -195,11 +290,51 @@ func (o *OpenAPI) SetDefaults(logger logr.Logger) bool {
 		updated = true
 	}
 
+	if o.Spec.OpenAPIRef.ConfigMapRef != nil && o.Spec.OpenAPIRef.ConfigMapRef.Namespace == "" {
+		o.Spec.OpenAPIRef.ConfigMapRef.Namespace = o.GetNamespace()
+		updated = true
+	}
+
 	return updated
 }
 
 func (o *OpenAPI) Validate() field.ErrorList {
 	errors := field.ErrorList{}
+
+	openapiRefFldPath := field.NewPath("spec").Child("openapiRef")
+	sources := 0
+	for _, isSet := range []bool{o.Spec.OpenAPIRef.SecretRef != nil, o.Spec.OpenAPIRef.ConfigMapRef != nil, o.Spec.OpenAPIRef.URL != nil, o.Spec.OpenAPIRef.Git != nil} {
+		if isSet {
+			sources++
+		}
+	}
+	if sources != 1 {
+		errors = append(errors, field.Invalid(openapiRefFldPath, o.Spec.OpenAPIRef, "exactly one of secretRef, configMapRef, url or git is required"))
+	}
+
+	if o.Spec.OpenAPIRef.Key != nil && (o.Spec.OpenAPIRef.URL != nil || o.Spec.OpenAPIRef.Git != nil) {
+		errors = append(errors, field.Invalid(openapiRefFldPath.Child("key"), o.Spec.OpenAPIRef.Key, "key is not supported for url and git"))
+	}
+
+	if o.Spec.OpenAPIRef.Git != nil {
+		errors = append(errors, o.Spec.OpenAPIRef.Git.Validate(openapiRefFldPath.Child("git"))...)
+	}
+
+	return errors
+}
+
+// Validate checks the git source fields that cannot be validated by the CRD schema
+func (g *GitSourceSpec) Validate(fldPath *field.Path) field.ErrorList {
+	errors := field.ErrorList{}
+
+	if g.URL == "" {
+		errors = append(errors, field.Required(fldPath.Child("url"), "git repository url is required"))
+	}
+
+	if g.Path == "" {
+		errors = append(errors, field.Required(fldPath.Child("path"), "file path is required"))
+	}
+
 	return errors
 }
 
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PolicyChainKind = "PolicyChain"
+)
+
+// PolicyChainSpec defines the desired state of PolicyChain
+type PolicyChainSpec struct {
+	// Policies holds the policies added to the policy chain of the products referencing the policy chain
+	Policies []PolicyConfig `json:"policies"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyChain is the Schema for the policychains API.
+// Products reference policy chains to share policies
+type PolicyChain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PolicyChainSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyChainList contains a list of PolicyChain
+type PolicyChainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyChain `json:"items"`
+}
+
+// IncludesApicastPolicy returns true when the policy chain includes the apicast policy
+func (p *PolicyChainSpec) IncludesApicastPolicy() bool {
+	return apicastPolicyIndex(p.Policies) >= 0
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyChain{}, &PolicyChainList{})
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
@@ -58,11 +59,37 @@ const (
 	// The operator will retry.
 	ProductFailedConditionType common.ConditionType = "Failed"
 
+	// ProductDriftedConditionType indicates that the product in 3scale differs from the ProductSpec.
+	// Only reported when the management policy is Observe
+	ProductDriftedConditionType common.ConditionType = "Drifted"
+
+	// ProductConflictConditionType indicates that a product with the same system name exists in 3scale
+	// and it is not managed by this Product. It must be adopted explicitly.
+	ProductConflictConditionType common.ConditionType = "Conflict"
+
+	// ManagementPolicyEnforce makes 3scale objects match the spec, overwriting any change made out of the operator
+	ManagementPolicyEnforce = "Enforce"
+
+	// ManagementPolicyObserve only reports the differences between 3scale objects and the spec
+	ManagementPolicyObserve = "Observe"
+
 	// ProductPolicyConfigurationPasswordSecretField indicates the secret field name with product policy configuration
 	ProductPolicyConfigurationPasswordSecretField = "configuration"
 
 	// ProductPolicyConfigurationDefault is the default for a product policy configuration
 	ProductPolicyConfigurationDefault = `{}`
+
+	// ApplicationPlanRetirementMigratingState indicates that applications are still subscribed to the retired plan
+	ApplicationPlanRetirementMigratingState = "Migrating"
+
+	// ApplicationPlanRetirementRetiredState indicates that the retired plan has been deleted
+	ApplicationPlanRetirementRetiredState = "Retired"
+
+	// FeatureScopeApplicationPlan is the scope of product features enabled on application plans
+	FeatureScopeApplicationPlan = "ApplicationPlan"
+
+	// FeatureScopeServicePlan is the scope of product features enabled on service plans
+	FeatureScopeServicePlan = "ServicePlan"
 )
 
 var (
@@ -172,13 +199,166 @@ type ApplicationPlanSpec struct {
 	// +optional
 	Published *bool `json:"published,omitempty"`
 
-	// TODO Features
+	// Features enabled on the application plan
+	// List of system names of product features with ApplicationPlan scope
+	// +optional
+	Features []string `json:"features,omitempty"`
+
+	// Retire deprecates the application plan.
+	// Subscribed applications are moved to the migrateTo plan, the plan is hidden
+	// and it is deleted once it has no subscribed applications
+	// +optional
+	Retire *ApplicationPlanRetireSpec `json:"retire,omitempty"`
+}
+
+// ApplicationPlanTemplateRefSpec references an ApplicationPlanTemplate
+type ApplicationPlanTemplateRefSpec struct {
+	// Name of the ApplicationPlanTemplate
+	Name string `json:"name"`
+
+	// Overrides of the template application plans for the product
+	// Map: system_name -> Application Plan Spec
+	// Only the fields set are overridden
+	// +optional
+	Overrides map[string]ApplicationPlanSpec `json:"overrides,omitempty"`
+}
+
+// ApplicationPlanRetireSpec defines the retirement of Product's Application Plan
+type ApplicationPlanRetireSpec struct {
+	// MigrateTo is the system name of the application plan subscribed applications are moved to
+	MigrateTo string `json:"migrateTo"`
 }
 
 func (a *ApplicationPlanSpec) IsPublished() bool {
 	return a.Published != nil && *a.Published
 }
 
+// Override returns a copy of the application plan with the fields set in the override.
+// Override limits replace the limits with the same period and metric or method reference, other limits are added.
+// Override pricing rules and features replace the ones of the application plan
+func (a *ApplicationPlanSpec) Override(override *ApplicationPlanSpec) ApplicationPlanSpec {
+	result := *a.DeepCopy()
+	override = override.DeepCopy()
+
+	if override.Name != nil {
+		result.Name = override.Name
+	}
+	if override.AppsRequireApproval != nil {
+		result.AppsRequireApproval = override.AppsRequireApproval
+	}
+	if override.TrialPeriod != nil {
+		result.TrialPeriod = override.TrialPeriod
+	}
+	if override.SetupFee != nil {
+		result.SetupFee = override.SetupFee
+	}
+	if override.CostMonth != nil {
+		result.CostMonth = override.CostMonth
+	}
+	if override.Published != nil {
+		result.Published = override.Published
+	}
+	if override.Retire != nil {
+		result.Retire = override.Retire
+	}
+	if override.PricingRules != nil {
+		result.PricingRules = override.PricingRules
+	}
+	if override.Features != nil {
+		result.Features = override.Features
+	}
+
+	for _, overrideLimit := range override.Limits {
+		found := false
+		for idx := range result.Limits {
+			if result.Limits[idx].Period == overrideLimit.Period && result.Limits[idx].MetricMethodRef.String() == overrideLimit.MetricMethodRef.String() {
+				result.Limits[idx] = overrideLimit
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.Limits = append(result.Limits, overrideLimit)
+		}
+	}
+
+	return result
+}
+
+// ServicePlanSpec defines the desired state of Product's Service Plan
+type ServicePlanSpec struct {
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Set whether or not developers subscribe to the product on demand
+	// or if approval is required from you before subscriptions are activated.
+	// +optional
+	ApprovalRequired *bool `json:"approvalRequired,omitempty"`
+
+	// Trial Period (days)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TrialPeriod *int `json:"trialPeriod,omitempty"`
+
+	// Setup fee (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	SetupFee *string `json:"setupFee,omitempty"`
+
+	// Cost per Month (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	CostMonth *string `json:"costMonth,omitempty"`
+
+	// Controls whether the service plan is published. If not specified it is
+	// hidden by default
+	// +optional
+	Published *bool `json:"published,omitempty"`
+
+	// Default sets the service plan as the default plan of new product subscriptions
+	// +optional
+	Default *bool `json:"default,omitempty"`
+
+	// Features enabled on the service plan
+	// List of system names of product features with ServicePlan scope
+	// +optional
+	Features []string `json:"features,omitempty"`
+}
+
+func (a *ApplicationPlanSpec) IsRetired() bool {
+	return a.Retire != nil
+}
+
+func (s *ServicePlanSpec) IsPublished() bool {
+	return s.Published != nil && *s.Published
+}
+
+func (s *ServicePlanSpec) IsDefault() bool {
+	return s.Default != nil && *s.Default
+}
+
+// FeatureSpec defines the desired state of Product's plan feature
+type FeatureSpec struct {
+	Name string `json:"name"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Scope sets the kind of plans the feature can be enabled on.
+	// If not specified, ApplicationPlan by default
+	// +kubebuilder:validation:Enum=ApplicationPlan;ServicePlan
+	// +optional
+	Scope *string `json:"scope,omitempty"`
+}
+
+func (f *FeatureSpec) ScopeOrDefault() string {
+	if f.Scope == nil {
+		return FeatureScopeApplicationPlan
+	}
+
+	return *f.Scope
+}
+
 // MethodSpec defines the desired state of Product's Method
 type MethodSpec struct {
 	Name string `json:"friendlyName"`
@@ -619,6 +799,64 @@ type ApicastSelfManagedSpec struct {
 	// +optional
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
 	ProductionPublicBaseURL *string `json:"productionPublicBaseURL,omitempty"`
+	// ProxyConfigPublication publishes the proxy configuration to a ConfigMap or Secret,
+	// for gateways loading the configuration from a file
+	// +optional
+	ProxyConfigPublication *ProxyConfigPublicationSpec `json:"proxyConfigPublication,omitempty"`
+}
+
+// ProxyConfigEnvironment is a proxy configuration environment
+// +kubebuilder:validation:Enum=staging;production
+type ProxyConfigEnvironment string
+
+const (
+	ProxyConfigEnvironmentStaging    ProxyConfigEnvironment = "staging"
+	ProxyConfigEnvironmentProduction ProxyConfigEnvironment = "production"
+
+	ProxyConfigPublicationConfigMapKind = "ConfigMap"
+	ProxyConfigPublicationSecretKind    = "Secret"
+)
+
+// ProxyConfigPublicationSpec defines the ConfigMap or Secret the proxy configuration is published to
+type ProxyConfigPublicationSpec struct {
+	// Kind of the object the proxy configuration is published to. Defaults to ConfigMap
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+
+	// Name of the ConfigMap or Secret
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap or Secret. Defaults to the namespace of the product.
+	// Other namespaces must allow the product with the capabilities.3scale.net/proxy-config-products annotation
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Environments published. Defaults to production
+	// +optional
+	Environments []ProxyConfigEnvironment `json:"environments,omitempty"`
+}
+
+func (p *ProxyConfigPublicationSpec) PublicationKind() string {
+	if p.Kind == nil {
+		return ProxyConfigPublicationConfigMapKind
+	}
+	return *p.Kind
+}
+
+// PublicationNamespace returns the namespace of the ConfigMap or Secret, the product namespace by default
+func (p *ProxyConfigPublicationSpec) PublicationNamespace(productNamespace string) string {
+	if p.Namespace == nil || *p.Namespace == "" {
+		return productNamespace
+	}
+	return *p.Namespace
+}
+
+func (p *ProxyConfigPublicationSpec) PublishedEnvironments() []ProxyConfigEnvironment {
+	if len(p.Environments) == 0 {
+		return []ProxyConfigEnvironment{ProxyConfigEnvironmentProduction}
+	}
+	return p.Environments
 }
 
 func (a *ApicastSelfManagedSpec) AuthenticationMode() *string {
@@ -699,6 +937,30 @@ type ProductDeploymentSpec struct {
 	ApicastHosted *ApicastHostedSpec `json:"apicastHosted,omitempty"`
 	// +optional
 	ApicastSelfManaged *ApicastSelfManagedSpec `json:"apicastSelfManaged,omitempty"`
+
+	// Promotion defines the automatic promotion of the proxy configuration after each successful synchronization
+	// +optional
+	Promotion *ProductPromotionSpec `json:"promotion,omitempty"`
+}
+
+// ProductPromotionSpec defines the automatic promotion of the Product proxy configuration
+type ProductPromotionSpec struct {
+	// Staging promotes the proxy configuration to the staging environment
+	// +optional
+	Staging *bool `json:"staging,omitempty"`
+
+	// Production promotes the latest staging proxy configuration to the production environment.
+	// The proxy configuration is promoted to the staging environment first
+	// +optional
+	Production *bool `json:"production,omitempty"`
+}
+
+func (p *ProductPromotionSpec) IsStagingEnabled() bool {
+	return (p.Staging != nil && *p.Staging) || p.IsProductionEnabled()
+}
+
+func (p *ProductPromotionSpec) IsProductionEnabled() bool {
+	return p.Production != nil && *p.Production
 }
 
 func (d *ProductDeploymentSpec) DeploymentOption() string {
@@ -865,6 +1127,36 @@ type PolicyConfig struct {
 	Enabled bool `json:"enabled"`
 }
 
+// PolicyChainPosition is the position of the referenced policy chain policies in the product policy chain
+// +kubebuilder:validation:Enum=prepend;append;replace
+type PolicyChainPosition string
+
+const (
+	PolicyChainPositionPrepend PolicyChainPosition = "prepend"
+	PolicyChainPositionAppend  PolicyChainPosition = "append"
+	PolicyChainPositionReplace PolicyChainPosition = "replace"
+)
+
+// PolicyChainRefSpec references a PolicyChain
+type PolicyChainRefSpec struct {
+	// Name of the PolicyChain
+	Name string `json:"name"`
+
+	// Position of the PolicyChain policies. Defaults to append.
+	// prepend adds the policies before the policy chain, append adds the policies after the policy chain
+	// and before the apicast policy, replace replaces the policy chain by the policies.
+	// Only replacing policy chains can include the apicast policy
+	// +optional
+	Position *PolicyChainPosition `json:"position,omitempty"`
+}
+
+func (p *PolicyChainRefSpec) ChainPosition() PolicyChainPosition {
+	if p.Position == nil {
+		return PolicyChainPositionAppend
+	}
+	return *p.Position
+}
+
 func (d *ProductDeploymentSpec) OIDCSpec() *OIDCSpec {
 	// spec.deployment is oneOf by CRD openapiV3 validation
 	if d.ApicastHosted != nil {
@@ -929,6 +1221,31 @@ type ProductSpec struct {
 	// +optional
 	ApplicationPlans map[string]ApplicationPlanSpec `json:"applicationPlans,omitempty"`
 
+	// ApplicationPlanTemplateRefs references ApplicationPlanTemplate resources of the product namespace.
+	// The application plans of the templates are added in order,
+	// application plans of the product take precedence over template plans with the same system name
+	// +optional
+	ApplicationPlanTemplateRefs []ApplicationPlanTemplateRefSpec `json:"applicationPlanTemplateRefs,omitempty"`
+
+	// Service Plans
+	// Map: system_name -> Service Plan Spec
+	// When not set, the service plans of the product are not managed
+	// +optional
+	ServicePlans map[string]ServicePlanSpec `json:"servicePlans,omitempty"`
+
+	// Features
+	// Map: system_name -> Feature Spec
+	// When not set, the features of the product and the features enabled on its plans are not managed
+	// +optional
+	Features map[string]FeatureSpec `json:"features,omitempty"`
+
+	// ManagementPolicy sets how the product in 3scale is managed.
+	// Enforce (default) overwrites the product in 3scale with the spec.
+	// Observe never changes the product in 3scale, differences are reported in the Drifted condition
+	// +kubebuilder:validation:Enum=Enforce;Observe
+	// +optional
+	ManagementPolicy *string `json:"managementPolicy,omitempty"`
+
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
@@ -936,6 +1253,11 @@ type ProductSpec struct {
 	// Policies holds the product's policy chain
 	// +optional
 	Policies []PolicyConfig `json:"policies,omitempty"`
+
+	// PolicyChainRefs references PolicyChain resources of the product namespace.
+	// The policies of the referenced policy chains are combined with the product policies in order
+	// +optional
+	PolicyChainRefs []PolicyChainRefSpec `json:"policyChainRefs,omitempty"`
 }
 
 func (s *ProductSpec) DeploymentOption() *string {
@@ -1074,6 +1396,22 @@ type GatewayResponseSpec struct {
 	ErrorLimitsExceeded *string `json:"errorLimitsExceeded,omitempty"`
 }
 
+// ApplicationPlanRetirementStatus defines the observed state of the retirement of an application plan
+type ApplicationPlanRetirementStatus struct {
+	// SystemName of the retired application plan
+	SystemName string `json:"systemName"`
+
+	// MigrateTo is the system name of the application plan subscribed applications are moved to
+	MigrateTo string `json:"migrateTo"`
+
+	// State of the retirement: Migrating or Retired
+	State string `json:"state"`
+
+	// PendingApplications is the number of applications still subscribed to the retired plan
+	// +optional
+	PendingApplications int `json:"pendingApplications,omitempty"`
+}
+
 // ProductStatus defines the observed state of Product
 type ProductStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -1092,12 +1430,33 @@ type ProductStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// RetiredApplicationPlans reports the progress of the retirement of application plans
+	// +optional
+	RetiredApplicationPlans []ApplicationPlanRetirementStatus `json:"retiredApplicationPlans,omitempty"`
+
+	// KeptServicePlans are the system names of the service plans not in the spec that are not deleted
+	// because developer accounts are still subscribed to them
+	// +optional
+	KeptServicePlans []string `json:"keptServicePlans,omitempty"`
+
+	// The latest proxy configuration version in staging, when the proxy configuration is promoted automatically
+	// +optional
+	LatestStagingVersion int `json:"latestStagingVersion,omitempty"`
+
+	// The latest proxy configuration version in production, when the proxy configuration is promoted automatically
+	// +optional
+	LatestProductionVersion int `json:"latestProductionVersion,omitempty"`
+
 	// Current state of the 3scale product.
 	// Conditions represent the latest available observations of an object's state
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// LastSyncTime is the time of the last successful synchronization with 3scale
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 func (p *ProductStatus) Equals(other *ProductStatus, logger logr.Logger) bool {
@@ -1125,6 +1484,30 @@ func (p *ProductStatus) Equals(other *ProductStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(p.RetiredApplicationPlans, other.RetiredApplicationPlans) {
+		diff := cmp.Diff(p.RetiredApplicationPlans, other.RetiredApplicationPlans)
+		logger.V(1).Info("RetiredApplicationPlans not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(p.KeptServicePlans, other.KeptServicePlans) {
+		diff := cmp.Diff(p.KeptServicePlans, other.KeptServicePlans)
+		logger.V(1).Info("KeptServicePlans not equal", "difference", diff)
+		return false
+	}
+
+	if p.LatestStagingVersion != other.LatestStagingVersion {
+		diff := cmp.Diff(p.LatestStagingVersion, other.LatestStagingVersion)
+		logger.V(1).Info("LatestStagingVersion not equal", "difference", diff)
+		return false
+	}
+
+	if p.LatestProductionVersion != other.LatestProductionVersion {
+		diff := cmp.Diff(p.LatestProductionVersion, other.LatestProductionVersion)
+		logger.V(1).Info("LatestProductionVersion not equal", "difference", diff)
+		return false
+	}
+
 	// Marshalling sorts by condition type
 	currentMarshaledJSON, _ := p.Conditions.MarshalJSON()
 	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
@@ -1134,6 +1517,11 @@ func (p *ProductStatus) Equals(other *ProductStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !p.LastSyncTime.Equal(other.LastSyncTime) {
+		logger.V(1).Info("LastSyncTime not equal", "current", p.LastSyncTime, "new", other.LastSyncTime)
+		return false
+	}
+
 	return true
 }
 
@@ -1385,6 +1773,140 @@ func (product *Product) Validate() field.ErrorList {
 		}
 	}
 
+	// Check at most one service plan is the default one
+	defaultServicePlans := []string{}
+	for planSystemName, planSpec := range product.Spec.ServicePlans {
+		if planSpec.IsDefault() {
+			defaultServicePlans = append(defaultServicePlans, planSystemName)
+		}
+	}
+	if len(defaultServicePlans) > 1 {
+		sort.Strings(defaultServicePlans)
+		errors = append(errors, field.Invalid(specFldPath.Child("servicePlans"), defaultServicePlans, "only one service plan can be the default one."))
+	}
+
+	// Check plan features reference existing product features with the plan scope
+	for planSystemName, planSpec := range product.Spec.ApplicationPlans {
+		featuresFldPath := specFldPath.Child("applicationPlans").Key(planSystemName).Child("features")
+		errors = append(errors, product.validatePlanFeatures(featuresFldPath, planSpec.Features, FeatureScopeApplicationPlan)...)
+	}
+	for planSystemName, planSpec := range product.Spec.ServicePlans {
+		featuresFldPath := specFldPath.Child("servicePlans").Key(planSystemName).Child("features")
+		errors = append(errors, product.validatePlanFeatures(featuresFldPath, planSpec.Features, FeatureScopeServicePlan)...)
+	}
+
+	// Check retired application plans migrate to existing and not retired application plans
+	for planSystemName, planSpec := range product.Spec.ApplicationPlans {
+		if !planSpec.IsRetired() {
+			continue
+		}
+
+		targetSpec, ok := product.Spec.ApplicationPlans[planSpec.Retire.MigrateTo]
+		if !ok || targetSpec.IsRetired() {
+			migrateToFldPath := specFldPath.Child("applicationPlans").Key(planSystemName).Child("retire", "migrateTo")
+			errors = append(errors, field.Invalid(migrateToFldPath, planSpec.Retire.MigrateTo, "retired application plan does not migrate to a valid application plan."))
+		}
+	}
+
+	// Check production promotion is not set without staging promotion
+	if product.Spec.Deployment != nil && product.Spec.Deployment.Promotion != nil {
+		promotion := product.Spec.Deployment.Promotion
+		if promotion.IsProductionEnabled() && promotion.Staging != nil && !*promotion.Staging {
+			promotionFldPath := specFldPath.Child("deployment", "promotion")
+			errors = append(errors, field.Invalid(promotionFldPath.Child("staging"), *promotion.Staging, "production promotion requires staging promotion."))
+		}
+	}
+
+	return errors
+}
+
+// ProxyConfigPublication returns the proxy configuration publication of self managed products, nil when not published
+func (product *Product) ProxyConfigPublication() *ProxyConfigPublicationSpec {
+	if product.Spec.Deployment == nil || product.Spec.Deployment.ApicastSelfManaged == nil {
+		return nil
+	}
+	return product.Spec.Deployment.ApicastSelfManaged.ProxyConfigPublication
+}
+
+// PolicyChain returns the product policy chain, the product policies combined with the referenced policy chains in order.
+// policyChains holds the referenced policy chains by name, references not found are skipped.
+// Appended policy chains are added before the apicast policy, policies like CORS or headers must run before it.
+// The apicast policy is added when replacing policy chains do not include it
+func (product *Product) PolicyChain(policyChains map[string]*PolicyChain) []PolicyConfig {
+	policies := product.Spec.Policies
+	replaced := false
+
+	for _, ref := range product.Spec.PolicyChainRefs {
+		policyChain, ok := policyChains[ref.Name]
+		if !ok {
+			continue
+		}
+
+		switch ref.ChainPosition() {
+		case PolicyChainPositionPrepend:
+			policies = append(append([]PolicyConfig{}, policyChain.Spec.Policies...), policies...)
+		case PolicyChainPositionReplace:
+			policies = append([]PolicyConfig{}, policyChain.Spec.Policies...)
+			replaced = true
+		default:
+			apicastIdx := apicastPolicyIndex(policies)
+			if apicastIdx < 0 {
+				apicastIdx = len(policies)
+			}
+			appended := append(append([]PolicyConfig{}, policies[:apicastIdx]...), policyChain.Spec.Policies...)
+			policies = append(appended, policies[apicastIdx:]...)
+		}
+	}
+
+	// Apicast Policy must exist
+	if !replaced || apicastPolicyIndex(policies) >= 0 {
+		return policies
+	}
+
+	// Add to the end of the slice as the one with the lowest priority
+	return append(policies, apicastPolicy)
+}
+
+// apicastPolicyIndex returns the index of the apicast policy, -1 when not found
+func apicastPolicyIndex(policies []PolicyConfig) int {
+	for idx := range policies {
+		if policies[idx].Name == apicastPolicy.Name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// IsPromotionEnabled returns true when the proxy configuration is promoted automatically after each successful synchronization
+func (product *Product) IsPromotionEnabled() bool {
+	return product.Spec.Deployment != nil && product.Spec.Deployment.Promotion != nil && product.Spec.Deployment.Promotion.IsStagingEnabled()
+}
+
+// ApplicationPlanTarget returns the system name of the application plan applications referencing
+// the given plan are subscribed to. Retired plans resolve to their migrateTo plan
+func (product *Product) ApplicationPlanTarget(systemName string) string {
+	if planSpec, ok := product.Spec.ApplicationPlans[systemName]; ok && planSpec.IsRetired() {
+		return planSpec.Retire.MigrateTo
+	}
+
+	return systemName
+}
+
+func (product *Product) validatePlanFeatures(fldPath *field.Path, features []string, scope string) field.ErrorList {
+	errors := field.ErrorList{}
+
+	for idx, featureSystemName := range features {
+		featureSpec, ok := product.Spec.Features[featureSystemName]
+		if !ok {
+			errors = append(errors, field.Invalid(fldPath.Index(idx), featureSystemName, "plan feature does not have valid product feature reference."))
+			continue
+		}
+
+		if featureSpec.ScopeOrDefault() != scope {
+			errors = append(errors, field.Invalid(fldPath.Index(idx), featureSystemName, fmt.Sprintf("product feature scope is not %s.", scope)))
+		}
+	}
+
 	return errors
 }
 
@@ -1392,6 +1914,11 @@ func (product *Product) IsSynced() bool {
 	return product.Status.Conditions.IsTrueFor(ProductSyncedConditionType)
 }
 
+// IsObserved returns true when the product in 3scale is only observed, not changed
+func (product *Product) IsObserved() bool {
+	return product.Spec.ManagementPolicy != nil && *product.Spec.ManagementPolicy == ManagementPolicyObserve
+}
+
 func (product *Product) FindMetricOrMethod(ref string) bool {
 	if len(product.Spec.Metrics) > 0 {
 		if _, ok := product.Spec.Metrics[ref]; ok {
@@ -1,6 +1,7 @@
 package v1beta1
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -207,6 +208,212 @@ func TestValidateProductPlanPricingRuleOverlappingRange(t *testing.T) {
 	}
 }
 
+func TestValidateProductMultipleDefaultServicePlans(t *testing.T) {
+	product := defaultTestingProduct()
+
+	trueValue := true
+	product.Spec.ServicePlans = map[string]ServicePlanSpec{
+		"basic":   ServicePlanSpec{Default: &trueValue},
+		"premium": ServicePlanSpec{Default: &trueValue},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "only one service plan can be the default one.") {
+		t.Error("valition passes and more than one service plan is the default one.")
+	}
+
+	product.Spec.ServicePlans["premium"] = ServicePlanSpec{}
+	errors = product.Validate()
+	if len(errors) > 0 {
+		t.Errorf("product validation fails: %s", errors.ToAggregate().Error())
+	}
+}
+
+func TestValidateProductPlanFeatures(t *testing.T) {
+	product := defaultTestingProduct()
+
+	servicePlanScope := FeatureScopeServicePlan
+	product.Spec.Features = map[string]FeatureSpec{
+		"unlimited_greetings": FeatureSpec{Name: "Unlimited Greetings"},
+		"24_7_support":        FeatureSpec{Name: "24/7 Support", Scope: &servicePlanScope},
+	}
+	product.Spec.ApplicationPlans = map[string]ApplicationPlanSpec{
+		"plan01": ApplicationPlanSpec{Features: []string{"unknown"}},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "plan feature does not have valid product feature reference.") {
+		t.Error("valition passes and application plan references unknown feature.")
+	}
+
+	product.Spec.ApplicationPlans["plan01"] = ApplicationPlanSpec{Features: []string{"24_7_support"}}
+	errors = product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "product feature scope is not ApplicationPlan.") {
+		t.Error("valition passes and application plan references service plan feature.")
+	}
+
+	product.Spec.ApplicationPlans["plan01"] = ApplicationPlanSpec{Features: []string{"unlimited_greetings"}}
+	product.Spec.ServicePlans = map[string]ServicePlanSpec{
+		"basic": ServicePlanSpec{Features: []string{"24_7_support"}},
+	}
+	errors = product.Validate()
+	if len(errors) > 0 {
+		t.Errorf("product validation fails: %s", errors.ToAggregate().Error())
+	}
+}
+
+func TestValidateProductRetiredApplicationPlans(t *testing.T) {
+	product := defaultTestingProduct()
+
+	product.Spec.ApplicationPlans = map[string]ApplicationPlanSpec{
+		"old": ApplicationPlanSpec{Retire: &ApplicationPlanRetireSpec{MigrateTo: "unknown"}},
+		"new": ApplicationPlanSpec{},
+	}
+
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "retired application plan does not migrate to a valid application plan.") {
+		t.Error("valition passes and retired plan migrates to unknown plan.")
+	}
+
+	product.Spec.ApplicationPlans["old"] = ApplicationPlanSpec{Retire: &ApplicationPlanRetireSpec{MigrateTo: "old"}}
+	errors = product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "retired application plan does not migrate to a valid application plan.") {
+		t.Error("valition passes and retired plan migrates to retired plan.")
+	}
+
+	product.Spec.ApplicationPlans["old"] = ApplicationPlanSpec{Retire: &ApplicationPlanRetireSpec{MigrateTo: "new"}}
+	errors = product.Validate()
+	if len(errors) > 0 {
+		t.Errorf("product validation fails: %s", errors.ToAggregate().Error())
+	}
+
+	if product.ApplicationPlanTarget("old") != "new" || product.ApplicationPlanTarget("new") != "new" {
+		t.Error("retired plan does not resolve to migrateTo plan")
+	}
+}
+
+func TestValidateProductPromotion(t *testing.T) {
+	product := defaultTestingProduct()
+	falseValue := false
+	trueValue := true
+	product.Spec.Deployment = &ProductDeploymentSpec{ApicastHosted: &ApicastHostedSpec{}}
+
+	product.Spec.Deployment.Promotion = &ProductPromotionSpec{Staging: &falseValue, Production: &trueValue}
+	errors := product.Validate()
+	if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), "production promotion requires staging promotion.") {
+		t.Error("valition passes and production promotion is set without staging promotion.")
+	}
+
+	product.Spec.Deployment.Promotion = &ProductPromotionSpec{Production: &trueValue}
+	errors = product.Validate()
+	if len(errors) > 0 {
+		t.Errorf("product validation fails: %s", errors.ToAggregate().Error())
+	}
+
+	if !product.IsPromotionEnabled() || !product.Spec.Deployment.Promotion.IsStagingEnabled() {
+		t.Error("production promotion does not promote to staging")
+	}
+
+	product.Spec.Deployment.Promotion = &ProductPromotionSpec{}
+	if product.IsPromotionEnabled() {
+		t.Error("empty promotion is enabled")
+	}
+}
+
+func TestProductPolicyChain(t *testing.T) {
+	product := defaultTestingProduct()
+	product.Spec.Policies = append([]PolicyConfig{{Name: "echo", Version: "builtin"}}, product.Spec.Policies...)
+
+	policyChains := map[string]*PolicyChain{
+		"cors":    {Spec: PolicyChainSpec{Policies: []PolicyConfig{{Name: "cors", Version: "builtin"}}}},
+		"logging": {Spec: PolicyChainSpec{Policies: []PolicyConfig{{Name: "logging", Version: "builtin"}}}},
+	}
+
+	policyNames := func(policies []PolicyConfig) string {
+		names := []string{}
+		for _, policy := range policies {
+			names = append(names, policy.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	prepend := PolicyChainPositionPrepend
+	replace := PolicyChainPositionReplace
+
+	cases := []struct {
+		name     string
+		refs     []PolicyChainRefSpec
+		expected string
+	}{
+		{"no references", nil, "echo,apicast"},
+		{"append before apicast by default", []PolicyChainRefSpec{{Name: "logging"}}, "echo,logging,apicast"},
+		{"prepend", []PolicyChainRefSpec{{Name: "cors", Position: &prepend}}, "cors,echo,apicast"},
+		{"in order", []PolicyChainRefSpec{{Name: "cors", Position: &prepend}, {Name: "logging"}}, "cors,echo,logging,apicast"},
+		{"replace adds apicast", []PolicyChainRefSpec{{Name: "cors", Position: &replace}}, "cors,apicast"},
+		{"append after replace", []PolicyChainRefSpec{{Name: "cors", Position: &replace}, {Name: "logging"}}, "cors,logging,apicast"},
+		{"missing references skipped", []PolicyChainRefSpec{{Name: "unknown", Position: &replace}}, "echo,apicast"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			product.Spec.PolicyChainRefs = tc.refs
+			policies := product.PolicyChain(policyChains)
+			if policyNames(policies) != tc.expected {
+				subT.Errorf("policy chain: %s, expected: %s", policyNames(policies), tc.expected)
+			}
+		})
+	}
+
+	if policyNames(product.Spec.Policies) != "echo,apicast" {
+		t.Errorf("product policies changed: %s", policyNames(product.Spec.Policies))
+	}
+}
+
+func TestApplicationPlanOverride(t *testing.T) {
+	basic := "Basic"
+	gold := "Gold"
+	cost := "10.00"
+	backend := "backendA"
+
+	planSpec := ApplicationPlanSpec{
+		Name:     &basic,
+		Features: []string{"support"},
+		Limits: []LimitSpec{
+			{Period: "month", Value: 1000, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits"}},
+			{Period: "month", Value: 100, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits", BackendSystemName: &backend}},
+		},
+	}
+
+	overridden := planSpec.Override(&ApplicationPlanSpec{
+		Name:      &gold,
+		CostMonth: &cost,
+		Limits: []LimitSpec{
+			{Period: "month", Value: 500, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits", BackendSystemName: &backend}},
+			{Period: "day", Value: 50, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits"}},
+		},
+	})
+
+	if *overridden.Name != gold || overridden.CostMonth == nil || *overridden.CostMonth != cost {
+		t.Errorf("plan fields not overridden: %v", overridden)
+	}
+	if !reflect.DeepEqual(overridden.Features, []string{"support"}) {
+		t.Errorf("features not overridden changed: %v", overridden.Features)
+	}
+
+	expectedLimits := []LimitSpec{
+		{Period: "month", Value: 1000, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits"}},
+		{Period: "month", Value: 500, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits", BackendSystemName: &backend}},
+		{Period: "day", Value: 50, MetricMethodRef: MetricMethodRefSpec{SystemName: "hits"}},
+	}
+	if !reflect.DeepEqual(overridden.Limits, expectedLimits) {
+		t.Errorf("limits: %v, expected: %v", overridden.Limits, expectedLimits)
+	}
+
+	if *planSpec.Name != basic || planSpec.Limits[1].Value != 100 || len(planSpec.Limits) != 2 {
+		t.Errorf("overridden application plan changed: %v", planSpec)
+	}
+}
+
 func TestValidateProductHappyPath(t *testing.T) {
 	product := defaultTestingProduct()
 
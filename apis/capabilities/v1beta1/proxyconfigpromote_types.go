@@ -32,6 +32,9 @@ const (
 	ProxyPromoteConfigReadyConditionType      common.ConditionType = "Ready"
 	ProxyPromoteConfigFailedConditionType     common.ConditionType = "Failed"
 	ProxyPromoteConfigInProgressConditionType common.ConditionType = "In-progress"
+
+	// ProxyConfigPromoteRollbackToPrevious promotes the production version preceding the current one
+	ProxyConfigPromoteRollbackToPrevious = "previous"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -52,6 +55,23 @@ type ProxyConfigPromoteSpec struct {
 	// deleteCR  deletes this CR when it has successfully completed the promotion
 	// +optional
 	DeleteCR *bool `json:"deleteCR,omitempty"`
+
+	// TargetVersion promotes the given staging version to production instead of the latest one,
+	// for instance to roll back a bad production deploy. Staging is not promoted
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetVersion *int `json:"targetVersion,omitempty"`
+
+	// RollbackTo promotes the production version preceding the current one to production.
+	// Staging is not promoted
+	// +kubebuilder:validation:Enum=previous
+	// +optional
+	RollbackTo *string `json:"rollbackTo,omitempty"`
+}
+
+// IsVersionPromotion returns true when a specific version is promoted to production
+func (s *ProxyConfigPromoteSpec) IsVersionPromotion() bool {
+	return s.TargetVersion != nil || s.RollbackTo != nil
 }
 
 // ProxyConfigPromoteStatus defines the observed state of ProxyConfigPromote
@@ -70,6 +90,10 @@ type ProxyConfigPromoteStatus struct {
 	//+optional
 	LatestStagingVersion int `json:"latestStagingVersion,omitempty"`
 
+	// The Version in production before promoting a specific version
+	//+optional
+	PreviousProductionVersion int `json:"previousProductionVersion,omitempty"`
+
 	// Current state of the ProxyConfigPromote resource.
 	// Conditions represent the latest available observations of an object's state
 	// +optional
@@ -122,6 +146,12 @@ func (o *ProxyConfigPromoteStatus) Equals(other *ProxyConfigPromoteStatus, logge
 		return false
 	}
 
+	if o.PreviousProductionVersion != other.PreviousProductionVersion {
+		diff := cmp.Diff(o.PreviousProductionVersion, other.PreviousProductionVersion)
+		logger.V(1).Info("PreviousProductionVersion not equal", "difference", diff)
+		return false
+	}
+
 	// Marshalling sorts by condition type
 	currentMarshaledJSON, _ := o.Conditions.MarshalJSON()
 	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
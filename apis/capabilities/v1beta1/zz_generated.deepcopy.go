@@ -26,6 +26,173 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountFeatureSpec) DeepCopyInto(out *AccountFeatureSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountFeatureSpec.
+func (in *AccountFeatureSpec) DeepCopy() *AccountFeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountFeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlan) DeepCopyInto(out *AccountPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlan.
+func (in *AccountPlan) DeepCopy() *AccountPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanList) DeepCopyInto(out *AccountPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccountPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanList.
+func (in *AccountPlanList) DeepCopy() *AccountPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanSpec) DeepCopyInto(out *AccountPlanSpec) {
+	*out = *in
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SystemName != nil {
+		in, out := &in.SystemName, &out.SystemName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApprovalRequired != nil {
+		in, out := &in.ApprovalRequired, &out.ApprovalRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrialPeriod != nil {
+		in, out := &in.TrialPeriod, &out.TrialPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SetupFee != nil {
+		in, out := &in.SetupFee, &out.SetupFee
+		*out = new(string)
+		**out = **in
+	}
+	if in.CostMonth != nil {
+		in, out := &in.CostMonth, &out.CostMonth
+		*out = new(string)
+		**out = **in
+	}
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]AccountFeatureSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanSpec.
+func (in *AccountPlanSpec) DeepCopy() *AccountPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanStatus) DeepCopyInto(out *AccountPlanStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanStatus.
+func (in *AccountPlanStatus) DeepCopy() *AccountPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveDoc) DeepCopyInto(out *ActiveDoc) {
 	*out = *in
@@ -98,6 +265,11 @@ func (in *ActiveDocOpenAPIRefSpec) DeepCopyInto(out *ActiveDocOpenAPIRefSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveDocOpenAPIRefSpec.
@@ -176,6 +348,10 @@ func (in *ActiveDocStatus) DeepCopyInto(out *ActiveDocStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveDocStatus.
@@ -226,6 +402,11 @@ func (in *ApicastSelfManagedSpec) DeepCopyInto(out *ApicastSelfManagedSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ProxyConfigPublication != nil {
+		in, out := &in.ProxyConfigPublication, &out.ProxyConfigPublication
+		*out = new(ProxyConfigPublicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastSelfManagedSpec.
@@ -448,6 +629,36 @@ func (in *ApplicationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanRetireSpec) DeepCopyInto(out *ApplicationPlanRetireSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanRetireSpec.
+func (in *ApplicationPlanRetireSpec) DeepCopy() *ApplicationPlanRetireSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanRetireSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanRetirementStatus) DeepCopyInto(out *ApplicationPlanRetirementStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanRetirementStatus.
+func (in *ApplicationPlanRetirementStatus) DeepCopy() *ApplicationPlanRetirementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanRetirementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationPlanSpec) DeepCopyInto(out *ApplicationPlanSpec) {
 	*out = *in
@@ -495,6 +706,16 @@ func (in *ApplicationPlanSpec) DeepCopyInto(out *ApplicationPlanSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retire != nil {
+		in, out := &in.Retire, &out.Retire
+		*out = new(ApplicationPlanRetireSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanSpec.
@@ -507,6 +728,108 @@ func (in *ApplicationPlanSpec) DeepCopy() *ApplicationPlanSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanTemplate) DeepCopyInto(out *ApplicationPlanTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanTemplate.
+func (in *ApplicationPlanTemplate) DeepCopy() *ApplicationPlanTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationPlanTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanTemplateList) DeepCopyInto(out *ApplicationPlanTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationPlanTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanTemplateList.
+func (in *ApplicationPlanTemplateList) DeepCopy() *ApplicationPlanTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationPlanTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanTemplateRefSpec) DeepCopyInto(out *ApplicationPlanTemplateRefSpec) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make(map[string]ApplicationPlanSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanTemplateRefSpec.
+func (in *ApplicationPlanTemplateRefSpec) DeepCopy() *ApplicationPlanTemplateRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanTemplateRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanTemplateSpec) DeepCopyInto(out *ApplicationPlanTemplateSpec) {
+	*out = *in
+	if in.ApplicationPlans != nil {
+		in, out := &in.ApplicationPlans, &out.ApplicationPlans
+		*out = make(map[string]ApplicationPlanSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanTemplateSpec.
+func (in *ApplicationPlanTemplateSpec) DeepCopy() *ApplicationPlanTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 	*out = *in
@@ -547,6 +870,10 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationStatus.
@@ -672,6 +999,11 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ManagementPolicy != nil {
+		in, out := &in.ManagementPolicy, &out.ManagementPolicy
+		*out = new(string)
+		**out = **in
+	}
 	if in.ProviderAccountRef != nil {
 		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
 		*out = new(v1.LocalObjectReference)
@@ -704,6 +1036,10 @@ func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendStatus.
@@ -826,6 +1162,10 @@ func (in *CustomPolicyDefinitionStatus) DeepCopyInto(out *CustomPolicyDefinition
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPolicyDefinitionStatus.
@@ -940,6 +1280,11 @@ func (in *DeveloperAccountSpec) DeepCopyInto(out *DeveloperAccountSpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.AccountPlanRef != nil {
+		in, out := &in.AccountPlanRef, &out.AccountPlanRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSpec.
@@ -977,6 +1322,10 @@ func (in *DeveloperAccountStatus) DeepCopyInto(out *DeveloperAccountStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountStatus.
@@ -1100,6 +1449,10 @@ func (in *DeveloperUserStatus) DeepCopyInto(out *DeveloperUserStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperUserStatus.
@@ -1112,6 +1465,26 @@ func (in *DeveloperUserStatus) DeepCopy() *DeveloperUserStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
+	*out = *in
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
+func (in *FeatureSpec) DeepCopy() *FeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayResponseSpec) DeepCopyInto(out *GatewayResponseSpec) {
 	*out = *in
@@ -1187,6 +1560,31 @@ func (in *GatewayResponseSpec) DeepCopy() *GatewayResponseSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSourceSpec) DeepCopyInto(out *GitSourceSpec) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSourceSpec.
+func (in *GitSourceSpec) DeepCopy() *GitSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LimitSpec) DeepCopyInto(out *LimitSpec) {
 	*out = *in
@@ -1365,6 +1763,31 @@ func (in *OpenAPI) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIActiveDocSpec) DeepCopyInto(out *OpenAPIActiveDocSpec) {
+	*out = *in
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipSwaggerValidations != nil {
+		in, out := &in.SkipSwaggerValidations, &out.SkipSwaggerValidations
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIActiveDocSpec.
+func (in *OpenAPIActiveDocSpec) DeepCopy() *OpenAPIActiveDocSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIActiveDocSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenAPIList) DeepCopyInto(out *OpenAPIList) {
 	*out = *in
@@ -1410,6 +1833,21 @@ func (in *OpenAPIRefSpec) DeepCopyInto(out *OpenAPIRefSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIRefSpec.
@@ -1471,6 +1909,11 @@ func (in *OpenAPISpec) DeepCopyInto(out *OpenAPISpec) {
 		*out = new(OIDCSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ActiveDoc != nil {
+		in, out := &in.ActiveDoc, &out.ActiveDoc
+		*out = new(OpenAPIActiveDocSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPISpec.
@@ -1496,6 +1939,11 @@ func (in *OpenAPIStatus) DeepCopyInto(out *OpenAPIStatus) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ActiveDocResourceName != nil {
+		in, out := &in.ActiveDocResourceName, &out.ActiveDocResourceName
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(common.Conditions, len(*in))
@@ -1503,6 +1951,10 @@ func (in *OpenAPIStatus) DeepCopyInto(out *OpenAPIStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIStatus.
@@ -1515,6 +1967,106 @@ func (in *OpenAPIStatus) DeepCopy() *OpenAPIStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyChain) DeepCopyInto(out *PolicyChain) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyChain.
+func (in *PolicyChain) DeepCopy() *PolicyChain {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyChain) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyChainList) DeepCopyInto(out *PolicyChainList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyChain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyChainList.
+func (in *PolicyChainList) DeepCopy() *PolicyChainList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyChainList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyChainList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyChainRefSpec) DeepCopyInto(out *PolicyChainRefSpec) {
+	*out = *in
+	if in.Position != nil {
+		in, out := &in.Position, &out.Position
+		*out = new(PolicyChainPosition)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyChainRefSpec.
+func (in *PolicyChainRefSpec) DeepCopy() *PolicyChainRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyChainRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyChainSpec) DeepCopyInto(out *PolicyChainSpec) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyChainSpec.
+func (in *PolicyChainSpec) DeepCopy() *PolicyChainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyChainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyConfig) DeepCopyInto(out *PolicyConfig) {
 	*out = *in
@@ -1588,6 +2140,11 @@ func (in *ProductDeploymentSpec) DeepCopyInto(out *ProductDeploymentSpec) {
 		*out = new(ApicastSelfManagedSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Promotion != nil {
+		in, out := &in.Promotion, &out.Promotion
+		*out = new(ProductPromotionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductDeploymentSpec.
@@ -1632,6 +2189,31 @@ func (in *ProductList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProductPromotionSpec) DeepCopyInto(out *ProductPromotionSpec) {
+	*out = *in
+	if in.Staging != nil {
+		in, out := &in.Staging, &out.Staging
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Production != nil {
+		in, out := &in.Production, &out.Production
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductPromotionSpec.
+func (in *ProductPromotionSpec) DeepCopy() *ProductPromotionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProductPromotionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProductSpec) DeepCopyInto(out *ProductSpec) {
 	*out = *in
@@ -1675,6 +2257,32 @@ func (in *ProductSpec) DeepCopyInto(out *ProductSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ApplicationPlanTemplateRefs != nil {
+		in, out := &in.ApplicationPlanTemplateRefs, &out.ApplicationPlanTemplateRefs
+		*out = make([]ApplicationPlanTemplateRefSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServicePlans != nil {
+		in, out := &in.ServicePlans, &out.ServicePlans
+		*out = make(map[string]ServicePlanSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]FeatureSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ManagementPolicy != nil {
+		in, out := &in.ManagementPolicy, &out.ManagementPolicy
+		*out = new(string)
+		**out = **in
+	}
 	if in.ProviderAccountRef != nil {
 		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
 		*out = new(v1.LocalObjectReference)
@@ -1687,6 +2295,13 @@ func (in *ProductSpec) DeepCopyInto(out *ProductSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PolicyChainRefs != nil {
+		in, out := &in.PolicyChainRefs, &out.PolicyChainRefs
+		*out = make([]PolicyChainRefSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductSpec.
@@ -1712,6 +2327,16 @@ func (in *ProductStatus) DeepCopyInto(out *ProductStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RetiredApplicationPlans != nil {
+		in, out := &in.RetiredApplicationPlans, &out.RetiredApplicationPlans
+		*out = make([]ApplicationPlanRetirementStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeptServicePlans != nil {
+		in, out := &in.KeptServicePlans, &out.KeptServicePlans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(common.Conditions, len(*in))
@@ -1719,6 +2344,10 @@ func (in *ProductStatus) DeepCopyInto(out *ProductStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductStatus.
@@ -1803,6 +2432,16 @@ func (in *ProxyConfigPromoteSpec) DeepCopyInto(out *ProxyConfigPromoteSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TargetVersion != nil {
+		in, out := &in.TargetVersion, &out.TargetVersion
+		*out = new(int)
+		**out = **in
+	}
+	if in.RollbackTo != nil {
+		in, out := &in.RollbackTo, &out.RollbackTo
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPromoteSpec.
@@ -1837,6 +2476,36 @@ func (in *ProxyConfigPromoteStatus) DeepCopy() *ProxyConfigPromoteStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfigPublicationSpec) DeepCopyInto(out *ProxyConfigPublicationSpec) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]ProxyConfigEnvironment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigPublicationSpec.
+func (in *ProxyConfigPublicationSpec) DeepCopy() *ProxyConfigPublicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfigPublicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 	*out = *in
@@ -1862,6 +2531,61 @@ func (in *SecuritySpec) DeepCopy() *SecuritySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanSpec) DeepCopyInto(out *ServicePlanSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApprovalRequired != nil {
+		in, out := &in.ApprovalRequired, &out.ApprovalRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrialPeriod != nil {
+		in, out := &in.TrialPeriod, &out.TrialPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SetupFee != nil {
+		in, out := &in.SetupFee, &out.SetupFee
+		*out = new(string)
+		**out = **in
+	}
+	if in.CostMonth != nil {
+		in, out := &in.CostMonth, &out.CostMonth
+		*out = new(string)
+		**out = **in
+	}
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanSpec.
+func (in *ServicePlanSpec) DeepCopy() *ServicePlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserKeyAuthenticationSpec) DeepCopyInto(out *UserKeyAuthenticationSpec) {
 	*out = *in
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -15,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	apispkgcommon "github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
@@ -36,7 +38,7 @@ func NewAPIManagerBackupLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv
 		cr:             cr,
 	}
 
-	if cr.BackupCompleted() {
+	if cr.BackupCompleted() || cr.BackupFailed() {
 		return res, nil
 	}
 
@@ -70,6 +72,11 @@ func (r *APIManagerBackupLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.BackupFailed() {
+		r.Logger().Info("Backup failed. End of reconciliation")
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling backup steps")
 		result, err := r.reconcileMainSteps()
@@ -104,6 +111,11 @@ func (r *APIManagerBackupLogicReconciler) reconcileMainSteps() (reconcile.Result
 		return result, err
 	}
 
+	result, err = r.reconcileBackupInS3Destination()
+	if result.Requeue || err != nil {
+		return result, err
+	}
+
 	result, err = r.reconcileSetMainStepsCompleted()
 	if result.Requeue || err != nil {
 		return result, err
@@ -141,6 +153,10 @@ func (r *APIManagerBackupLogicReconciler) reconcileSetMainStepsCompleted() (reco
 }
 
 func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (reconcile.Result, error) {
+	if r.cr.Spec.BackupDestination.PersistentVolumeClaim == nil {
+		return reconcile.Result{}, nil
+	}
+
 	var res reconcile.Result
 	var err error
 
@@ -174,6 +190,89 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (rec
 		return res, err
 	}
 
+	res, err = r.reconcileBackupSystemDatabaseToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupZyncDatabaseToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupBackendRedisToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupSystemRedisToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupManifestToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	return res, err
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupInS3Destination() (reconcile.Result, error) {
+	if r.cr.Spec.BackupDestination.S3 == nil {
+		return reconcile.Result{}, nil
+	}
+
+	res, err := r.reconcileBackupDestinationS3Status()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupJobsPermissions()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupSecretsAndConfigMapsToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileAPIManagerCustomResourceBackupToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupSystemFileStoragePVCToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupSystemDatabaseToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupZyncDatabaseToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupBackendRedisToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupSystemRedisToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupManifestToS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	return res, err
 }
 
@@ -223,6 +322,18 @@ func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job) (re
 	// Jobs ownerReference or labels nor annotations not reconciled
 	// Jobs are one-shot so there's not much point on making updates to them
 
+	// Failed Jobs are not retried, the backup will not complete
+	if jobFailed(existing) {
+		r.cr.Status.Conditions.SetCondition(apispkgcommon.Condition{
+			Type:    appsv1alpha1.APIManagerBackupFailedConditionType,
+			Status:  v1.ConditionTrue,
+			Reason:  appsv1alpha1.APIManagerBackupJobFailedReason,
+			Message: fmt.Sprintf("Job '%s' failed. See the logs of the Job", desired.Name),
+		})
+		err = r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
+
 	if existing.Status.Succeeded != *desired.Spec.Completions {
 		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
@@ -259,6 +370,189 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToP
 	return r.reconcileJob(desired)
 }
 
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemDatabaseToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemDatabaseToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupZyncDatabaseToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupZyncDatabaseToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupBackendRedisToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupBackendRedisToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemRedisToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemRedisToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSecretsAndConfigMapsToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSecretsAndConfigMapsToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerCustomResourceBackupToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupAPIManagerCustomResourceToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemFileStoragePVCToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemDatabaseToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemDatabaseToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupZyncDatabaseToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupZyncDatabaseToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupBackendRedisToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupBackendRedisToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemRedisToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemRedisToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupManifestToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupManifestToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileBackupManifestJob(desired)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupManifestToS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupManifestToS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileBackupManifestJob(desired)
+}
+
+// reconcileBackupManifestJob runs the Job that verifies the backup data
+// against the checksums written by the backup Jobs and writes the backup
+// integrity manifest. The result is surfaced in the Verified condition
+func (r *APIManagerBackupLogicReconciler) reconcileBackupManifestJob(desired *batchv1.Job) (reconcile.Result, error) {
+	verifiedCondition := r.cr.Status.Conditions.GetCondition(appsv1alpha1.APIManagerBackupVerifiedConditionType)
+	if verifiedCondition != nil {
+		if !verifiedCondition.IsTrue() {
+			return r.reconcileVerificationFailed(verifiedCondition.Message)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	existing := &batchv1.Job{}
+	err := r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	if err == nil && jobFailed(existing) {
+		return r.reconcileVerificationFailed(fmt.Sprintf("Backup data does not match the checksums written by the backup Jobs. See the logs of Job '%s'", desired.Name))
+	}
+
+	res, err := r.reconcileJob(desired)
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	r.cr.Status.Conditions.SetCondition(apispkgcommon.Condition{
+		Type:    appsv1alpha1.APIManagerBackupVerifiedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.APIManagerBackupChecksumsVerifiedReason,
+		Message: "Backup data matches the checksums of its integrity manifest",
+	})
+	err = r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
+// reconcileVerificationFailed reports the failed backup data verification in
+// the Verified and Failed conditions. The verification is final, the backup
+// will not complete
+func (r *APIManagerBackupLogicReconciler) reconcileVerificationFailed(message string) (reconcile.Result, error) {
+	r.cr.Status.Conditions.SetCondition(apispkgcommon.Condition{
+		Type:    appsv1alpha1.APIManagerBackupVerifiedConditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  appsv1alpha1.APIManagerBackupVerificationFailedReason,
+		Message: message,
+	})
+	r.cr.Status.Conditions.SetCondition(apispkgcommon.Condition{
+		Type:    appsv1alpha1.APIManagerBackupFailedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.APIManagerBackupVerificationFailedReason,
+		Message: message,
+	})
+	err := r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
+func jobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileBackupCompletion() (reconcile.Result, error) {
 	if !r.cr.BackupCompleted() {
 		// TODO make this more robust only setting it in case all substeps have been completed?
@@ -322,6 +616,16 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationPVCStatus()
 	return reconcile.Result{}, nil
 }
 
+func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationS3Status() (reconcile.Result, error) {
+	if r.cr.Status.BackupS3Location == nil {
+		location := r.apiManagerBackup.BackupS3Location()
+		r.cr.Status.BackupS3Location = &location
+		err := r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
+	return reconcile.Result{}, nil
+}
+
 // Delete all K8s jobs created during the backup. The reason for this is that
 // some PVCs are referenced in the K8s Jobs and those PVCs cannot be deleted
 // while some pods reference them, even if in state Completed. By deleting the
@@ -331,10 +635,26 @@ func (r *APIManagerBackupLogicReconciler) reconcileJobsCleanup() (reconcile.Resu
 		r.apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob(),
 		r.apiManagerBackup.BackupAPIManagerCustomResourceToPVCJob(),
 		r.apiManagerBackup.BackupSystemFileStoragePVCToPVCJob(),
+		r.apiManagerBackup.BackupSecretsAndConfigMapsToS3Job(),
+		r.apiManagerBackup.BackupAPIManagerCustomResourceToS3Job(),
+		r.apiManagerBackup.BackupSystemFileStoragePVCToS3Job(),
+		r.apiManagerBackup.BackupSystemDatabaseToPVCJob(),
+		r.apiManagerBackup.BackupZyncDatabaseToPVCJob(),
+		r.apiManagerBackup.BackupSystemDatabaseToS3Job(),
+		r.apiManagerBackup.BackupZyncDatabaseToS3Job(),
+		r.apiManagerBackup.BackupBackendRedisToPVCJob(),
+		r.apiManagerBackup.BackupSystemRedisToPVCJob(),
+		r.apiManagerBackup.BackupBackendRedisToS3Job(),
+		r.apiManagerBackup.BackupSystemRedisToS3Job(),
+		r.apiManagerBackup.BackupManifestToPVCJob(),
+		r.apiManagerBackup.BackupManifestToS3Job(),
 	}
 
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -386,7 +706,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupJobsServiceAccount() (r
 }
 
 func (r *APIManagerBackupLogicReconciler) reconcileBackupJobsRole() (reconcile.Result, error) {
-	err := r.ReconcileResource(&rbacv1.Role{}, r.apiManagerBackup.Role(), reconcilers.CreateOnlyMutator)
+	err := r.ReconcileResource(&rbacv1.Role{}, r.apiManagerBackup.Role(), reconcilers.RoleRuleMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
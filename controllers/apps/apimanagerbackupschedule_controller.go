@@ -0,0 +1,103 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// APIManagerBackupScheduleReconciler reconciles a APIManagerBackupSchedule object
+type APIManagerBackupScheduleReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that APIManagerBackupScheduleReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &APIManagerBackupScheduleReconciler{}
+
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackups,verbs=get;list;watch;create;update;patch;delete
+
+func (r *APIManagerBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("apimanagerbackupschedule", req.NamespacedName)
+	logger.Info("Reconciling APIManagerBackupSchedule")
+
+	// Fetch the APIManagerBackupSchedule instance
+	instance, err := r.getAPIManagerBackupScheduleCR(req)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("APIManagerBackupSchedule not found")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error getting APIManagerBackupSchedule")
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.setAPIManagerBackupScheduleDefaults(instance)
+	if err != nil {
+		logger.Error(err, "Error")
+		return ctrl.Result{}, err
+	}
+	if res.Requeue {
+		logger.Info("Defaults set for APIManagerBackupSchedule resource")
+		return res, nil
+	}
+
+	logicReconciler := NewAPIManagerBackupScheduleLogicReconciler(r.BaseReconciler, instance)
+	res, err = logicReconciler.Reconcile()
+	if err != nil {
+		logger.Error(err, "Error during reconciliation")
+		return res, err
+	}
+
+	logger.Info("Reconciliation finished", "requeueAfter", res.RequeueAfter)
+	return res, nil
+}
+
+func (r *APIManagerBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.APIManagerBackupSchedule{}).
+		Owns(&appsv1alpha1.APIManagerBackup{}).
+		Complete(r)
+}
+
+func (r *APIManagerBackupScheduleReconciler) getAPIManagerBackupScheduleCR(request reconcile.Request) (*appsv1alpha1.APIManagerBackupSchedule, error) {
+	instance := appsv1alpha1.APIManagerBackupSchedule{}
+	err := r.Client().Get(context.TODO(), request.NamespacedName, &instance)
+	return &instance, err
+}
+
+func (r *APIManagerBackupScheduleReconciler) setAPIManagerBackupScheduleDefaults(cr *appsv1alpha1.APIManagerBackupSchedule) (reconcile.Result, error) {
+	changed, err := cr.SetDefaults()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if changed {
+		err = r.Client().Update(context.TODO(), cr)
+	}
+
+	return reconcile.Result{Requeue: changed}, err
+}
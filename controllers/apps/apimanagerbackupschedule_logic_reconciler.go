@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+type APIManagerBackupScheduleLogicReconciler struct {
+	*reconcilers.BaseReconciler
+	logger logr.Logger
+	cr     *appsv1alpha1.APIManagerBackupSchedule
+}
+
+func NewAPIManagerBackupScheduleLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv1alpha1.APIManagerBackupSchedule) *APIManagerBackupScheduleLogicReconciler {
+	return &APIManagerBackupScheduleLogicReconciler{
+		BaseReconciler: b,
+		logger:         b.Logger().WithValues("APIManagerBackupSchedule Controller", cr.Name),
+		cr:             cr,
+	}
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) Logger() logr.Logger {
+	return r.logger
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) Reconcile() (reconcile.Result, error) {
+	now := apimanagerbackupClock.Now().UTC()
+	newStatus := r.cr.Status.DeepCopy()
+
+	sched, err := backup.ParseBackupSchedule(r.cr.Spec.Schedule)
+	if err != nil {
+		// Nothing to do until the schedule is fixed. The spec change triggers
+		// a new reconciliation
+		r.setFailure(newStatus, err.Error(), now)
+		newStatus.NextScheduleTime = nil
+		return reconcile.Result{}, r.reconcileStatus(newStatus)
+	}
+
+	backups, err := r.scheduledBackups()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.reconcileLastSuccessfulStatus(newStatus, backups)
+
+	err = r.reconcileScheduledBackup(sched, backups, newStatus, now)
+	if err == nil {
+		err = r.reconcilePrunedBackups(backups, newStatus, now)
+	}
+
+	res := reconcile.Result{}
+	newStatus.NextScheduleTime = nil
+	if !r.cr.IsSuspended() {
+		next := sched.Next(now)
+		newStatus.NextScheduleTime = &metav1.Time{Time: next}
+		res.RequeueAfter = next.Sub(now)
+	}
+
+	statusErr := r.reconcileStatus(newStatus)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return res, statusErr
+}
+
+// scheduledBackups returns the APIManagerBackup objects created by the schedule
+func (r *APIManagerBackupScheduleLogicReconciler) scheduledBackups() ([]appsv1alpha1.APIManagerBackup, error) {
+	backupList := &appsv1alpha1.APIManagerBackupList{}
+	opts := []client.ListOption{
+		client.InNamespace(r.cr.Namespace),
+		client.MatchingLabels{appsv1alpha1.APIManagerBackupScheduleLabelKey: r.cr.Name},
+	}
+	err := r.Client().List(r.Context(), backupList, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []appsv1alpha1.APIManagerBackup{}
+	for idx := range backupList.Items {
+		if metav1.IsControlledBy(&backupList.Items[idx], r.cr) {
+			res = append(res, backupList.Items[idx])
+		}
+	}
+	return res, nil
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileLastSuccessfulStatus(newStatus *appsv1alpha1.APIManagerBackupScheduleStatus, backups []appsv1alpha1.APIManagerBackup) {
+	for idx := range backups {
+		completionTime := backups[idx].Status.CompletionTime
+		if !backups[idx].BackupCompleted() || completionTime == nil {
+			continue
+		}
+
+		if newStatus.LastSuccessfulTime == nil || newStatus.LastSuccessfulTime.Before(completionTime) {
+			newStatus.LastSuccessfulTime = completionTime.DeepCopy()
+			newStatus.LastSuccessfulBackupName = &backups[idx].Name
+		}
+	}
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileScheduledBackup(sched cron.Schedule, backups []appsv1alpha1.APIManagerBackup, newStatus *appsv1alpha1.APIManagerBackupScheduleStatus, now time.Time) error {
+	if r.cr.IsSuspended() {
+		return nil
+	}
+
+	earliestTime := r.cr.CreationTimestamp.Time
+	if newStatus.LastScheduleTime != nil {
+		earliestTime = newStatus.LastScheduleTime.Time
+	}
+
+	// Only the most recent missed schedule time is run. Schedule times are
+	// evaluated in UTC
+	scheduledTime, err := backup.MostRecentScheduleTime(sched, earliestTime.UTC(), now)
+	if err == backup.ErrTooManyMissedScheduleTimes {
+		// Missed schedule times are skipped, the next schedule time is run
+		newStatus.LastScheduleTime = &metav1.Time{Time: now}
+		r.setFailure(newStatus, fmt.Sprintf("backups scheduled since %s skipped: %s", earliestTime.UTC().Format(time.RFC3339), err), now)
+		return nil
+	}
+	if scheduledTime == nil {
+		return nil
+	}
+
+	lastScheduleTime := &metav1.Time{Time: scheduledTime.UTC()}
+
+	err = r.validateBackupTemplate()
+	if err != nil {
+		// The schedule time is skipped
+		newStatus.LastScheduleTime = lastScheduleTime
+		r.setFailure(newStatus, err.Error(), now)
+		return nil
+	}
+
+	// Concurrent backups are not allowed. Failed backups will never complete
+	for idx := range backups {
+		if !backups[idx].BackupCompleted() && !backups[idx].BackupFailed() {
+			newStatus.LastScheduleTime = lastScheduleTime
+			r.setFailure(newStatus, fmt.Sprintf("backup scheduled at %s skipped: APIManagerBackup %s has not completed", lastScheduleTime.Format(time.RFC3339), backups[idx].Name), now)
+			return nil
+		}
+	}
+
+	desired := r.scheduledBackup(*scheduledTime)
+	err = r.SetControllerOwnerReference(r.cr, desired)
+	if err != nil {
+		return err
+	}
+
+	// The schedule time is run again when the backup cannot be created
+	err = r.CreateResource(desired)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		r.setFailure(newStatus, fmt.Sprintf("creating APIManagerBackup %s: %s", desired.Name, err), now)
+		return err
+	}
+
+	newStatus.LastScheduleTime = lastScheduleTime
+	r.Logger().Info("Scheduled backup created", "APIManagerBackup", desired.Name)
+	return nil
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) validateBackupTemplate() error {
+	pvcDestination := r.cr.Spec.BackupTemplate.BackupDestination.PersistentVolumeClaim
+	if pvcDestination != nil && pvcDestination.VolumeName != nil {
+		// A PersistentVolume can only be bound to a single PersistentVolumeClaim
+		return fmt.Errorf("backupTemplate persistentVolumeClaim volumeName cannot be set in a schedule")
+	}
+	return nil
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) scheduledBackup(scheduledTime time.Time) *appsv1alpha1.APIManagerBackup {
+	return &appsv1alpha1.APIManagerBackup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "APIManagerBackup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.ScheduledBackupName(r.cr.Name, scheduledTime),
+			Namespace: r.cr.Namespace,
+			Labels: map[string]string{
+				appsv1alpha1.APIManagerBackupScheduleLabelKey: r.cr.Name,
+			},
+		},
+		Spec: *r.cr.Spec.BackupTemplate.DeepCopy(),
+	}
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) reconcilePrunedBackups(backups []appsv1alpha1.APIManagerBackup, newStatus *appsv1alpha1.APIManagerBackupScheduleStatus, now time.Time) error {
+	for _, pruned := range backup.BackupsToPrune(backups, r.cr.Spec.Retention, now) {
+		err := r.pruneBackup(&pruned)
+		if err != nil {
+			r.setFailure(newStatus, fmt.Sprintf("pruning APIManagerBackup %s: %s", pruned.Name, err), now)
+			return err
+		}
+		r.Logger().Info("Backup pruned", "APIManagerBackup", pruned.Name)
+	}
+	return nil
+}
+
+// pruneBackup deletes the backup and its backup data PersistentVolumeClaim.
+// Backup data stored in S3 is not deleted
+func (r *APIManagerBackupScheduleLogicReconciler) pruneBackup(apimanagerBackup *appsv1alpha1.APIManagerBackup) error {
+	if apimanagerBackup.Status.BackupPersistentVolumeClaimName != nil {
+		pvc := &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      *apimanagerBackup.Status.BackupPersistentVolumeClaimName,
+				Namespace: apimanagerBackup.Namespace,
+			},
+		}
+		err := r.DeleteResource(pvc)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	err := r.DeleteResource(apimanagerBackup)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) setFailure(newStatus *appsv1alpha1.APIManagerBackupScheduleStatus, reason string, now time.Time) {
+	r.Logger().Info("Backup schedule failure", "reason", reason)
+	newStatus.LastFailureReason = &reason
+	newStatus.LastFailureTime = &metav1.Time{Time: now}
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileStatus(newStatus *appsv1alpha1.APIManagerBackupScheduleStatus) error {
+	if equality.Semantic.DeepEqual(&r.cr.Status, newStatus) {
+		return nil
+	}
+
+	r.cr.Status = *newStatus
+	return r.UpdateResourceStatus(r.cr)
+}
@@ -17,6 +17,7 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	apispkgcommon "github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -50,6 +51,11 @@ func (r *APIManagerRestoreLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.RestoreFailed() {
+		r.Logger().Info("Restore failed. End of reconciliation")
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling restore steps")
 		result, err := r.reconcileMainSteps()
@@ -75,7 +81,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileMainSteps() (reconcile.Resul
 		return result, err
 	}
 
-	result, err = r.reconcileRestoreFromPVCSource()
+	result, err = r.reconcileRestoreFromSource()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -126,7 +132,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileStartTimeField() (reconcile.
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reconcile.Result, error) {
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromSource() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
@@ -135,7 +141,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
-	res, err = r.reconcileRestoreSecretsAndConfigMapsFromPVCJob()
+	res, err = r.reconcileVerifyBackup()
 	if res.Requeue || err != nil {
 		return res, err
 	}
@@ -145,16 +151,51 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
+	res, err = r.reconcileVerifyBackedUpAPIManager()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileRestoreSecretsAndConfigMapsFromPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileRestoreSecretsAndConfigMapsFromS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileSystemEnvironmentWildcardDomain()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileRestoreSystemFileStoragePVCFromPVCJob()
 	if res.Requeue || err != nil {
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreSystemFileStoragePVCFromS3Job()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileRestoreRedis()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileRestoreAPIManager()
 	if res.Requeue || err != nil {
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreDatabases()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileResynchronizeZyncDomains()
 	if res.Requeue || err != nil {
 		return res, err
@@ -170,6 +211,11 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
+	res, err = r.reconcileBackupVerificationConfigMapCleanup()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	return res, err
 }
 
@@ -225,6 +271,51 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSecretsAndConfigMapsF
 	return r.reconcileJob(desired)
 }
 
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSecretsAndConfigMapsFromS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerRestore.RestoreSecretsAndConfigMapsFromS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+// reconcileSystemEnvironmentWildcardDomain updates the 3scale superdomain of
+// the restored system-environment ConfigMap when the wildcard domain of the
+// APIManager to be restored has been overridden. The ConfigMap is not
+// updated by the APIManager once it exists
+func (r *APIManagerRestoreLogicReconciler) reconcileSystemEnvironmentWildcardDomain() (reconcile.Result, error) {
+	if r.cr.Spec.APIManagerOverrides == nil {
+		return reconcile.Result{}, nil
+	}
+
+	apimanager, err := r.apiManagerFromSharedBackupSecret()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	systemEnvironment := &v1.ConfigMap{}
+	err = r.GetResource(types.NamespacedName{Name: "system-environment", Namespace: r.cr.Namespace}, systemEnvironment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if systemEnvironment.Data["THREESCALE_SUPERDOMAIN"] == apimanager.Spec.WildcardDomain {
+		return reconcile.Result{}, nil
+	}
+
+	r.Logger().Info("Updating 3scale superdomain of the restored system-environment ConfigMap", "WildcardDomain", apimanager.Spec.WildcardDomain)
+	if systemEnvironment.Data == nil {
+		systemEnvironment.Data = map[string]string{}
+	}
+	systemEnvironment.Data["THREESCALE_SUPERDOMAIN"] = apimanager.Spec.WildcardDomain
+	err = r.UpdateResource(systemEnvironment)
+	return reconcile.Result{Requeue: true}, err
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileSystemStoragePVC() (reconcile.Result, error) {
 	// TODO is it enough with just calling ReconcileResource???
 	exists, err := r.systemStoragePVCExists()
@@ -255,8 +346,28 @@ func (r *APIManagerRestoreLogicReconciler) runtimeRestoreInfoFromAPIManager(apim
 	if apimanager.Spec.System != nil && apimanager.Spec.System.FileStorageSpec != nil && apimanager.Spec.System.FileStorageSpec.PVC != nil {
 		storageClass = apimanager.Spec.System.FileStorageSpec.PVC.StorageClassName
 	}
+	systemDatabaseDumpOptions, err := backup.SystemDatabaseDumpOptions(apimanager)
+	if err != nil {
+		return nil, err
+	}
+	zyncDatabaseDumpOptions, err := backup.ZyncDatabaseDumpOptions(apimanager)
+	if err != nil {
+		return nil, err
+	}
+	backendRedisSnapshotOptions, err := backup.BackendRedisSnapshotOptions(apimanager)
+	if err != nil {
+		return nil, err
+	}
+	systemRedisSnapshotOptions, err := backup.SystemRedisSnapshotOptions(apimanager)
+	if err != nil {
+		return nil, err
+	}
 	restoreInfo := &restore.RuntimeAPIManagerRestoreInfo{
-		PVCStorageClass: storageClass,
+		PVCStorageClass:             storageClass,
+		SystemDatabaseDumpOptions:   systemDatabaseDumpOptions,
+		ZyncDatabaseDumpOptions:     zyncDatabaseDumpOptions,
+		BackendRedisSnapshotOptions: backendRedisSnapshotOptions,
+		SystemRedisSnapshotOptions:  systemRedisSnapshotOptions,
 	}
 	return restoreInfo, nil
 }
@@ -275,6 +386,20 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSystemFileStoragePVCF
 	return r.reconcileJob(desired)
 }
 
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSystemFileStoragePVCFromS3Job() (reconcile.Result, error) {
+	desired := r.apiManagerRestore.RestoreSystemFileStoragePVCFromS3Job()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	res, err := r.reconcileSystemStoragePVC()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	return r.reconcileJob(desired)
+}
+
 func (r *APIManagerRestoreLogicReconciler) systemStoragePVCExists() (bool, error) {
 	pvc := &v1.PersistentVolumeClaim{}
 	err := r.GetResource(types.NamespacedName{Name: component.SystemFileStoragePVCName, Namespace: r.cr.Namespace}, pvc)
@@ -359,7 +484,23 @@ func (r *APIManagerRestoreLogicReconciler) sharedBackupSecret() (*v1.Secret, err
 	return secret, nil
 }
 
+// apiManagerFromSharedBackupSecret returns the APIManager to be restored:
+// the backed up APIManager with the APIManager overrides applied
 func (r *APIManagerRestoreLogicReconciler) apiManagerFromSharedBackupSecret() (*appsv1alpha1.APIManager, error) {
+	apimanager, err := r.backedUpAPIManagerFromSharedBackupSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = restore.ApplyAPIManagerOverrides(apimanager, r.cr.Spec.APIManagerOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return apimanager, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) backedUpAPIManagerFromSharedBackupSecret() (*appsv1alpha1.APIManager, error) {
 	secret, err := r.sharedBackupSecret()
 	if err != nil {
 		return nil, err
@@ -419,6 +560,137 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManager() (reconci
 	return reconcile.Result{}, err
 }
 
+// reconcileRestoreRedis loads the Redis snapshots of the backup data into the
+// PersistentVolumeClaims of the Redis databases managed by the operator. It
+// has to be done before the APIManager is restored, while Redis is not running
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreRedis() (reconcile.Result, error) {
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, &appsv1alpha1.APIManager{})
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil {
+		r.Logger().Info("APIManager already exists. Skipping restore of the Redis snapshots", "APIManager", r.cr.Status.APIManagerToRestoreRef.Name)
+		return reconcile.Result{}, nil
+	}
+
+	apimanager, err := r.apiManagerFromSharedBackupSecret()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	restoreInfo, err := r.runtimeRestoreInfoFromAPIManager(apimanager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for _, snapshotOptions := range []*backup.RedisSnapshotOptions{restoreInfo.BackendRedisSnapshotOptions, restoreInfo.SystemRedisSnapshotOptions} {
+		if snapshotOptions == nil {
+			continue
+		}
+
+		err = r.ReconcileResource(&v1.PersistentVolumeClaim{}, r.apiManagerRestore.RedisPVC(snapshotOptions), reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		for _, desired := range []*batchv1.Job{
+			r.apiManagerRestore.RestoreRedisFromPVCJob(snapshotOptions),
+			r.apiManagerRestore.RestoreRedisFromS3Job(snapshotOptions),
+		} {
+			if desired == nil {
+				continue
+			}
+
+			res, err := r.reconcileJob(desired)
+			if res.Requeue || err != nil {
+				return res, err
+			}
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileRestoreDatabases loads the database dumps of the backup data into
+// the databases managed by the operator of the restored APIManager. Each
+// database is restored once its Deployment is ready
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreDatabases() (reconcile.Result, error) {
+	existingAPIManager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, existingAPIManager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Logger().Info("APIManager not found. Waiting until it exists", "APIManager", r.cr.Status.APIManagerToRestoreRef.Name)
+			return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	restoreInfo, err := r.runtimeRestoreInfoFromAPIManager(existingAPIManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for _, dumpOptions := range []*backup.DatabaseDumpOptions{restoreInfo.SystemDatabaseDumpOptions, restoreInfo.ZyncDatabaseDumpOptions} {
+		if dumpOptions == nil {
+			continue
+		}
+
+		if !helper.ArrayContains(existingAPIManager.Status.Deployments.Ready, dumpOptions.DeploymentName) {
+			r.Logger().Info("Database deployment not ready. Waiting", "APIManager", existingAPIManager.Name, "Deployment", dumpOptions.DeploymentName)
+			return reconcile.Result{RequeueAfter: 5 * time.Second, Requeue: true}, nil
+		}
+
+		for _, desired := range []*batchv1.Job{
+			r.apiManagerRestore.RestoreDatabaseFromPVCJob(dumpOptions),
+			r.apiManagerRestore.RestoreDatabaseFromS3Job(dumpOptions),
+		} {
+			if desired == nil {
+				continue
+			}
+
+			res, err := r.reconcileJob(desired)
+			if res.Requeue || err != nil {
+				return res, err
+			}
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// apiManagerRestoreJobs returns the database and Redis restore Jobs of the
+// restored APIManager. They cannot be known until the APIManager has been
+// restored
+func (r *APIManagerRestoreLogicReconciler) apiManagerRestoreJobs() ([]*batchv1.Job, error) {
+	if r.cr.Status.APIManagerToRestoreRef == nil {
+		return nil, nil
+	}
+
+	existingAPIManager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, existingAPIManager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	restoreInfo, err := r.runtimeRestoreInfoFromAPIManager(existingAPIManager)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*batchv1.Job{
+		r.apiManagerRestore.RestoreDatabaseFromPVCJob(restoreInfo.SystemDatabaseDumpOptions),
+		r.apiManagerRestore.RestoreDatabaseFromPVCJob(restoreInfo.ZyncDatabaseDumpOptions),
+		r.apiManagerRestore.RestoreDatabaseFromS3Job(restoreInfo.SystemDatabaseDumpOptions),
+		r.apiManagerRestore.RestoreDatabaseFromS3Job(restoreInfo.ZyncDatabaseDumpOptions),
+		r.apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo.BackendRedisSnapshotOptions),
+		r.apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo.SystemRedisSnapshotOptions),
+		r.apiManagerRestore.RestoreRedisFromS3Job(restoreInfo.BackendRedisSnapshotOptions),
+		r.apiManagerRestore.RestoreRedisFromS3Job(restoreInfo.SystemRedisSnapshotOptions),
+	}, nil
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileAPIManagerBackupSharedInSecretCleanup() (reconcile.Result, error) {
 	desiredSecret, err := r.sharedBackupSecret()
 	existingSecret := &v1.Secret{}
@@ -446,6 +718,170 @@ func (r *APIManagerRestoreLogicReconciler) reconcileAPIManagerBackupSharedInSecr
 	return reconcile.Result{}, nil
 }
 
+// reconcileVerifyBackup checks the backup data to be restored against its
+// backup integrity manifest before anything is restored, including the
+// backed up APIManager shared in a secret. The result is surfaced in the
+// Verified condition. Backups without manifest, taken by previous versions of
+// the operator, are restored without verification
+func (r *APIManagerRestoreLogicReconciler) reconcileVerifyBackup() (reconcile.Result, error) {
+	verifiedCondition := r.cr.Status.Conditions.GetCondition(appsv1alpha1.APIManagerRestoreVerifiedConditionType)
+	if verifiedCondition != nil {
+		return r.verifiedConditionResult(verifiedCondition)
+	}
+
+	for _, desired := range []*batchv1.Job{
+		r.apiManagerRestore.VerifyBackupFromPVCJob(),
+		r.apiManagerRestore.VerifyBackupFromS3Job(),
+	} {
+		if desired == nil {
+			continue
+		}
+
+		res, err := r.reconcileJob(desired)
+		if res.Requeue || err != nil {
+			return res, err
+		}
+	}
+
+	verification, err := r.backupVerification()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The backed up APIManager is checked against the manifest once shared
+	if verification[restore.BackupVerificationResultKey] == restore.BackupVerificationResultVerified {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileVerifiedCondition(verification)
+}
+
+// reconcileVerifyBackedUpAPIManager checks the backed up APIManager shared
+// in a secret against the spec hash of the backup integrity manifest, once
+// the backup data has been verified
+func (r *APIManagerRestoreLogicReconciler) reconcileVerifyBackedUpAPIManager() (reconcile.Result, error) {
+	verifiedCondition := r.cr.Status.Conditions.GetCondition(appsv1alpha1.APIManagerRestoreVerifiedConditionType)
+	if verifiedCondition != nil {
+		return r.verifiedConditionResult(verifiedCondition)
+	}
+
+	verification, err := r.backupVerification()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.reconcileVerifiedCondition(verification)
+}
+
+// verifiedConditionResult lets the restore proceed unless the backup data
+// verification failed. A failed verification is final, it is reported in the
+// Failed condition and the restore does not complete
+func (r *APIManagerRestoreLogicReconciler) verifiedConditionResult(verifiedCondition *apispkgcommon.Condition) (reconcile.Result, error) {
+	if !backupVerificationFailed(verifiedCondition) {
+		return reconcile.Result{}, nil
+	}
+
+	r.cr.Status.Conditions.SetCondition(restoreFailedCondition(verifiedCondition))
+	err := r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
+func (r *APIManagerRestoreLogicReconciler) reconcileVerifiedCondition(verification map[string]string) (reconcile.Result, error) {
+	condition, err := r.backupVerifiedCondition(verification)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.cr.Status.Conditions.SetCondition(condition)
+	if backupVerificationFailed(&condition) {
+		r.cr.Status.Conditions.SetCondition(restoreFailedCondition(&condition))
+	}
+	err = r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
+// backupVerificationFailed returns true when the Verified condition does not
+// let the restore proceed. Backup data without manifest is restored
+func backupVerificationFailed(verifiedCondition *apispkgcommon.Condition) bool {
+	return !verifiedCondition.IsTrue() && verifiedCondition.Reason != appsv1alpha1.APIManagerRestoreManifestNotFoundReason
+}
+
+func restoreFailedCondition(verifiedCondition *apispkgcommon.Condition) apispkgcommon.Condition {
+	return apispkgcommon.Condition{
+		Type:    appsv1alpha1.APIManagerRestoreFailedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.APIManagerRestoreVerificationFailedReason,
+		Message: fmt.Sprintf("Backup data verification failed: %s", verifiedCondition.Message),
+	}
+}
+
+// backupVerification returns the result written by the backup verification Job
+func (r *APIManagerRestoreLogicReconciler) backupVerification() (map[string]string, error) {
+	verificationConfigMap := &v1.ConfigMap{}
+	err := r.GetResource(types.NamespacedName{Name: r.apiManagerRestore.BackupVerificationConfigMapName(), Namespace: r.cr.Namespace}, verificationConfigMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return verificationConfigMap.Data, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) backupVerifiedCondition(verification map[string]string) (apispkgcommon.Condition, error) {
+	condition := apispkgcommon.Condition{
+		Type:   appsv1alpha1.APIManagerRestoreVerifiedConditionType,
+		Status: v1.ConditionFalse,
+	}
+
+	switch verification[restore.BackupVerificationResultKey] {
+	case restore.BackupVerificationResultManifestNotFound:
+		condition.Reason = appsv1alpha1.APIManagerRestoreManifestNotFoundReason
+		condition.Message = "Backup data has no integrity manifest. It is restored without verification"
+	case restore.BackupVerificationResultChecksumMismatch:
+		condition.Reason = appsv1alpha1.APIManagerRestoreChecksumMismatchReason
+		condition.Message = fmt.Sprintf("Backup data does not match the checksums of its integrity manifest: %s", verification[restore.BackupVerificationFailuresKey])
+	case restore.BackupVerificationResultVerified:
+		apimanager, err := r.backedUpAPIManagerFromSharedBackupSecret()
+		if err != nil {
+			return condition, err
+		}
+		specHash, err := backup.APIManagerSpecHash(apimanager)
+		if err != nil {
+			return condition, err
+		}
+		if specHash != verification[restore.BackupVerificationAPIManagerSpecHashKey] {
+			condition.Reason = appsv1alpha1.APIManagerRestoreSpecHashMismatchReason
+			condition.Message = fmt.Sprintf("Backed up APIManager '%s' does not match the spec hash of the integrity manifest", apimanager.Name)
+			break
+		}
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.APIManagerRestoreManifestVerifiedReason
+		condition.Message = fmt.Sprintf("Backup data matches its integrity manifest, written by operator version %s", verification[restore.BackupVerificationOperatorVersionKey])
+	default:
+		return condition, fmt.Errorf("Unknown backup verification result '%s' in ConfigMap '%s'", verification[restore.BackupVerificationResultKey], r.apiManagerRestore.BackupVerificationConfigMapName())
+	}
+
+	return condition, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) reconcileBackupVerificationConfigMapCleanup() (reconcile.Result, error) {
+	existing := &v1.ConfigMap{}
+	err := r.GetResource(types.NamespacedName{Name: r.apiManagerRestore.BackupVerificationConfigMapName(), Namespace: r.cr.Namespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	common.TagObjectToDelete(existing)
+	err = r.ReconcileResource(&v1.ConfigMap{}, existing, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileWaitForAPIManagerReady() (reconcile.Result, error) {
 	existingAPIManager := &appsv1alpha1.APIManager{}
 	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, existingAPIManager)
@@ -472,6 +908,15 @@ func (r *APIManagerRestoreLogicReconciler) reconcileResynchronizeZyncDomains() (
 		return res, err
 	}
 
+	// route hosts have to be remapped before zync creates the routes
+	remapJob := r.apiManagerRestore.RemapRouteHostsJob()
+	if remapJob != nil {
+		res, err = r.reconcileJob(remapJob)
+		if res.Requeue || err != nil {
+			return res, err
+		}
+	}
+
 	desired := r.apiManagerRestore.ZyncResyncDomainsJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
@@ -513,11 +958,25 @@ func (r *APIManagerRestoreLogicReconciler) reconcileJobsCleanup() (reconcile.Res
 		r.apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob(),
 		r.apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob(),
 		r.apiManagerRestore.CreateAPIManagerSharedSecretJob(),
+		r.apiManagerRestore.RemapRouteHostsJob(),
 		r.apiManagerRestore.ZyncResyncDomainsJob(),
+		r.apiManagerRestore.RestoreSecretsAndConfigMapsFromS3Job(),
+		r.apiManagerRestore.RestoreSystemFileStoragePVCFromS3Job(),
+		r.apiManagerRestore.VerifyBackupFromPVCJob(),
+		r.apiManagerRestore.VerifyBackupFromS3Job(),
 	}
 
+	apiManagerRestoreJobs, err := r.apiManagerRestoreJobs()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	jobsToDelete = append(jobsToDelete, apiManagerRestoreJobs...)
+
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -0,0 +1,267 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+	"github.com/go-logr/logr"
+)
+
+const accountPlanFinalizer = "accountplan.capabilities.3scale.net/finalizer"
+
+// accountPlanSubscribedRequeueTime is the time to retry deleting an account plan with developer accounts subscribed
+const accountPlanSubscribedRequeueTime = 5 * time.Minute
+
+// AccountPlanReconciler reconciles a AccountPlan object
+type AccountPlanReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that AccountPlanReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &AccountPlanReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=accountplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=accountplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=accountplans/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *AccountPlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("accountplan", req.NamespacedName)
+	reqLogger.Info("Reconcile AccountPlan", "Operator version", version.Version)
+
+	// Fetch the instance
+	accountPlanCR := &capabilitiesv1beta1.AccountPlan{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, accountPlanCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(accountPlanCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// AccountPlan has been marked for deletion
+	if accountPlanCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(accountPlanCR, accountPlanFinalizer) {
+		err = r.removeAccountPlanFrom3scale(accountPlanCR)
+		if helper.IsWaitError(err) {
+			// Report it and retry, the finalizer is kept until the account plan is deleted from 3scale
+			reqLogger.Info("ERROR", "wait error", err)
+			r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "Waiting to delete account plan", "%v", err)
+			statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, accountPlanCR.Status.ProviderAccountHost, nil, err)
+			_, statusUpdateErr := statusReconciler.Reconcile()
+			if statusUpdateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("Failed to update accountplan status: %w", statusUpdateErr)
+			}
+
+			return ctrl.Result{RequeueAfter: accountPlanSubscribedRequeueTime}, nil
+		}
+		if err != nil {
+			r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "Failed to delete account plan", "%v", err)
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(accountPlanCR, accountPlanFinalizer)
+		err = r.UpdateResource(accountPlanCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Ignore deleted resource, this can happen when foregroundDeletion is enabled
+	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+	if accountPlanCR.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(accountPlanCR, accountPlanFinalizer) {
+		controllerutil.AddFinalizer(accountPlanCR, accountPlanFinalizer)
+		err = r.UpdateResource(accountPlanCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// No need requeue because the reconcile will trigger automatically since updating the AccountPlan CR
+		return ctrl.Result{}, nil
+	}
+
+	if accountPlanCR.SetDefaults(reqLogger) {
+		err := r.Client().Update(r.Context(), accountPlanCR)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed setting accountplan defaults: %w", err)
+		}
+
+		reqLogger.Info("resource defaults updated. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcileSpec(accountPlanCR, reqLogger)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to reconcile accountplan: %v. Failed to update accountplan status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update accountplan status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			// On Validation error, no need to retry as spec is not valid and needs to be changed
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "Invalid AccountPlan Spec", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return controllerhelper.WithResync(ctrl.Result{}, accountPlanCR.GetAnnotations()), nil
+}
+
+func (r *AccountPlanReconciler) reconcileSpec(accountPlanCR *capabilitiesv1beta1.AccountPlan, logger logr.Logger) (*AccountPlanStatusReconciler, error) {
+	err := r.validateSpec(accountPlanCR)
+	if err != nil {
+		statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), accountPlanCR.Namespace, accountPlanCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, "", nil, err)
+		return statusReconciler, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(accountPlanCR.GetAnnotations())
+	plansAPIClient, err := controllerhelper.NewPlansAPIClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewAccountPlanThreescaleReconciler(r.BaseReconciler, accountPlanCR, plansAPIClient, providerAccount.AdminURLStr, logger)
+	accountPlanObj, err := reconciler.Reconcile()
+
+	statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, providerAccount.AdminURLStr, accountPlanObj, err)
+	return statusReconciler, err
+}
+
+func (r *AccountPlanReconciler) validateSpec(resource *capabilitiesv1beta1.AccountPlan) error {
+	errors := field.ErrorList{}
+	errors = append(errors, resource.Validate()...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+func (r *AccountPlanReconciler) removeAccountPlanFrom3scale(accountPlanCR *capabilitiesv1beta1.AccountPlan) error {
+	logger := r.Logger().WithValues("accountplan", client.ObjectKey{Name: accountPlanCR.Name, Namespace: accountPlanCR.Namespace})
+
+	if controllerhelper.IsOrphanDeletionPolicy(accountPlanCR.GetAnnotations()) {
+		logger.Info("account plan not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
+	// Attempt to remove account plan only if accountPlanCR.Status.ID is present
+	if accountPlanCR.Status.ID == nil {
+		logger.Info("could not remove account plan because ID is missing in status")
+		return nil
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), accountPlanCR.Namespace, accountPlanCR.Spec.ProviderAccountRef, r.Logger())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("account plan not deleted from 3scale, provider account not found")
+			return nil
+		}
+		return err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(accountPlanCR.GetAnnotations())
+	plansAPIClient, err := controllerhelper.NewPlansAPIClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	// Deleting the account plan would leave the subscribed developer accounts without account plan.
+	// It is deleted once the developer accounts are moved to another account plan
+	subscribed, err := plansAPIClient.AccountPlanSubscribed(*accountPlanCR.Status.ID)
+	if err != nil {
+		return err
+	}
+
+	if subscribed {
+		return &helper.WaitError{
+			Err: fmt.Errorf("account plan with ID %d not deleted from 3scale, developer accounts are still subscribed to it", *accountPlanCR.Status.ID),
+		}
+	}
+
+	err = plansAPIClient.DeleteAccountPlan(*accountPlanCR.Status.ID)
+	if err != nil && !controllerhelper.IsPlansAPINotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AccountPlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.AccountPlan{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
+		Complete(r)
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+type AccountPlanThreescaleReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.AccountPlan
+	plansAPIClient      *controllerhelper.PlansAPIClient
+	providerAccountHost string
+	logger              logr.Logger
+}
+
+func NewAccountPlanThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.AccountPlan, plansAPIClient *controllerhelper.PlansAPIClient, providerAccountHost string, logger logr.Logger) *AccountPlanThreescaleReconciler {
+	return &AccountPlanThreescaleReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		plansAPIClient:      plansAPIClient,
+		providerAccountHost: providerAccountHost,
+		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
+	}
+}
+
+func (s *AccountPlanThreescaleReconciler) Reconcile() (*controllerhelper.AccountPlan, error) {
+	s.logger.V(1).Info("START")
+
+	// SetDefaults ensures the system name is set
+	systemName := *s.resource.Spec.SystemName
+
+	remotePlans, err := s.plansAPIClient.ListAccountPlans()
+	if err != nil {
+		return nil, fmt.Errorf("account plan [%s]: %w", systemName, err)
+	}
+
+	var remotePlan *controllerhelper.AccountPlan
+	for idx := range remotePlans.Plans {
+		// Look for ID. If it does not exist, look for system name
+		foundByID := s.resource.Status.ID != nil && remotePlans.Plans[idx].Element.ID == *s.resource.Status.ID
+		foundBySystemName := remotePlans.Plans[idx].Element.SystemName == systemName
+		if foundByID || foundBySystemName {
+			// found
+			remotePlan = &remotePlans.Plans[idx]
+			break
+		}
+	}
+
+	if remotePlan == nil {
+		// Create account plan using system_name.
+		// it cannot be modified later
+		params := threescaleapi.Params{"system_name": systemName, "name": s.resource.Spec.Name}
+		remotePlan, err = s.plansAPIClient.CreateAccountPlan(params)
+		if err != nil {
+			return nil, fmt.Errorf("account plan [%s]: %w", systemName, err)
+		}
+	}
+
+	params := planUpdateParams(planSpec{
+		Name:             &s.resource.Spec.Name,
+		ApprovalRequired: s.resource.Spec.ApprovalRequired,
+		TrialPeriod:      s.resource.Spec.TrialPeriod,
+		SetupFee:         s.resource.Spec.SetupFee,
+		CostMonth:        s.resource.Spec.CostMonth,
+		Published:        s.resource.IsPublished(),
+	}, remotePlan.Element)
+	if len(params) > 0 {
+		s.logger.V(1).Info("Desired account plan needs sync", "params", params)
+		remotePlan, err = s.plansAPIClient.UpdateAccountPlan(remotePlan.Element.ID, params)
+		if err != nil {
+			return nil, fmt.Errorf("account plan [%s]: %w", systemName, err)
+		}
+	}
+
+	if s.resource.IsDefault() && !remotePlan.Element.Default {
+		s.logger.V(1).Info("Set default account plan")
+		remotePlan, err = s.plansAPIClient.SetDefaultAccountPlan(remotePlan.Element.ID)
+		if err != nil {
+			return nil, fmt.Errorf("account plan [%s]: %w", systemName, err)
+		}
+	}
+
+	err = s.syncFeatures(remotePlan.Element.ID)
+	if err != nil {
+		return nil, fmt.Errorf("account plan [%s] features: %w", systemName, err)
+	}
+
+	return remotePlan, nil
+}
+
+// syncFeatures ensures the desired account features exist and they are the only ones enabled on the account plan.
+// Account features are shared by all the account plans of the tenant, hence never deleted
+func (s *AccountPlanThreescaleReconciler) syncFeatures(planID int64) error {
+	if s.resource.Spec.Features == nil {
+		return nil
+	}
+
+	existingList, err := s.plansAPIClient.ListAccountFeatures()
+	if err != nil {
+		return err
+	}
+
+	existingMap := map[string]controllerhelper.FeatureItem{}
+	for _, existing := range existingList.Features {
+		existingMap[existing.Element.SystemName] = existing.Element
+	}
+
+	featureIDs := map[string]int64{}
+	desiredKeys := make([]string, 0, len(s.resource.Spec.Features))
+	for systemName, desired := range s.resource.Spec.Features {
+		desiredKeys = append(desiredKeys, systemName)
+
+		existing, ok := existingMap[systemName]
+		if !ok {
+			params := threescaleapi.Params{
+				"system_name": systemName,
+				"name":        desired.Name,
+				"description": desired.Description,
+			}
+			created, err := s.plansAPIClient.CreateAccountFeature(params)
+			if err != nil {
+				return err
+			}
+			featureIDs[systemName] = created.Element.ID
+			continue
+		}
+
+		params := threescaleapi.Params{}
+		if desired.Name != existing.Name {
+			params["name"] = desired.Name
+		}
+
+		if desired.Description != existing.Description {
+			params["description"] = desired.Description
+		}
+
+		if len(params) > 0 {
+			s.logger.V(1).Info("Desired account feature needs sync", "systemName", systemName, "params", params)
+			_, err := s.plansAPIClient.UpdateAccountFeature(existing.ID, params)
+			if err != nil {
+				return err
+			}
+		}
+		featureIDs[systemName] = existing.ID
+	}
+
+	return syncEnabledFeatures(desiredKeys, featureIDs,
+		func() (*controllerhelper.FeatureList, error) { return s.plansAPIClient.ListAccountPlanFeatures(planID) },
+		func(featureID int64) error { return s.plansAPIClient.EnableAccountPlanFeature(planID, featureID) },
+		func(featureID int64) error { return s.plansAPIClient.DisableAccountPlanFeature(planID, featureID) },
+	)
+}
@@ -20,12 +20,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
@@ -33,8 +38,11 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 )
 
+const activeDocFinalizer = "activedoc.capabilities.3scale.net/finalizer"
+
 // ActiveDocReconciler reconciles a ActiveDoc object
 type ActiveDocReconciler struct {
 	*reconcilers.BaseReconciler
@@ -45,6 +53,7 @@ var _ reconcile.Reconciler = &ActiveDocReconciler{}
 
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=activedocs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=activedocs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=activedocs/finalizers,verbs=get;list;watch;create;update;patch;delete
 
 func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := r.Logger().WithValues("activedoc", req.NamespacedName)
@@ -73,12 +82,40 @@ func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		reqLogger.V(1).Info(string(jsonData))
 	}
 
+	// ActiveDoc has been marked for deletion
+	if activeDocCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(activeDocCR, activeDocFinalizer) {
+		err = r.removeActiveDocFrom3scale(activeDocCR)
+		if err != nil {
+			r.EventRecorder().Eventf(activeDocCR, corev1.EventTypeWarning, "Failed to delete activedoc", "%v", err)
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(activeDocCR, activeDocFinalizer)
+		err = r.UpdateResource(activeDocCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
 	// Ignore deleted resource, this can happen when foregroundDeletion is enabled
 	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if activeDocCR.DeletionTimestamp != nil {
 		return ctrl.Result{}, nil
 	}
 
+	if !controllerutil.ContainsFinalizer(activeDocCR, activeDocFinalizer) {
+		controllerutil.AddFinalizer(activeDocCR, activeDocFinalizer)
+		err = r.UpdateResource(activeDocCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// No need requeue because the reconcile will trigger automatically since updating the ActiveDoc CR
+		return ctrl.Result{}, nil
+	}
+
 	if activeDocCR.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), activeDocCR)
 		if err != nil {
@@ -122,39 +159,45 @@ func (r *ActiveDocReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, reconcileErr
 	}
 
-	return ctrl.Result{}, nil
+	// The git repository cannot be watched. Requeue to pick up new commits of the ref,
+	// the repository is only fetched again when the ref resolves to a new commit
+	if activeDocCR.Spec.ActiveDocOpenAPIRef.Git != nil {
+		return controllerhelper.WithResync(ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Minute}, activeDocCR.GetAnnotations()), nil
+	}
+
+	return controllerhelper.WithResync(ctrl.Result{}, activeDocCR.GetAnnotations()), nil
 }
 
 func (r *ActiveDocReconciler) reconcileSpec(activeDocCR *capabilitiesv1beta1.ActiveDoc, logger logr.Logger) (*ActiveDocStatusReconciler, error) {
 	err := r.validateSpec(activeDocCR)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", activeDocCR.Status.GitCommitSHA, nil, err)
 		return statusReconciler, err
 	}
 
 	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), activeDocCR.Namespace, activeDocCR.Spec.ProviderAccountRef, logger)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, "", activeDocCR.Status.GitCommitSHA, nil, err)
 		return statusReconciler, err
 	}
 
 	err = r.checkExternalRefs(activeDocCR, providerAccount.AdminURLStr, logger)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, activeDocCR.Status.GitCommitSHA, nil, err)
 		return statusReconciler, err
 	}
 
 	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(activeDocCR.GetAnnotations())
 	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
 	if err != nil {
-		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, nil, err)
+		statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, activeDocCR.Status.GitCommitSHA, nil, err)
 		return statusReconciler, err
 	}
 
 	reconciler := NewActiveDocThreescaleReconciler(r.BaseReconciler, activeDocCR, threescaleAPIClient, providerAccount.AdminURLStr, logger)
 	activeDocObj, err := reconciler.Reconcile()
 
-	statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, activeDocObj, err)
+	statusReconciler := NewActiveDocStatusReconciler(r.BaseReconciler, activeDocCR, providerAccount.AdminURLStr, reconciler.GitCommitSHA(), activeDocObj, err)
 	return statusReconciler, err
 }
 
@@ -200,8 +243,57 @@ func (r *ActiveDocReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Ac
 	}
 }
 
+func (r *ActiveDocReconciler) removeActiveDocFrom3scale(activeDocCR *capabilitiesv1beta1.ActiveDoc) error {
+	logger := r.Logger().WithValues("activedoc", client.ObjectKey{Name: activeDocCR.Name, Namespace: activeDocCR.Namespace})
+
+	if controllerhelper.IsOrphanDeletionPolicy(activeDocCR.GetAnnotations()) {
+		logger.Info("activedoc not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
+	// Attempt to remove activedoc only if activeDocCR.Status.ID is present
+	if activeDocCR.Status.ID == nil {
+		logger.Info("could not remove activedoc because ID is missing in status")
+		return nil
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), activeDocCR.Namespace, activeDocCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("activedoc not deleted from 3scale, provider account not found")
+			return nil
+		}
+		return err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(activeDocCR.GetAnnotations())
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	err = threescaleAPIClient.DeleteActiveDoc(*activeDocCR.Status.ID)
+	if err != nil && !threescaleapi.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 func (r *ActiveDocReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	gitCredentialsSecretToActiveDocEventMapper := &GitCredentialsSecretToActiveDocEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("gitCredentialsSecretToActiveDocEventMapper"),
+	}
+
+	err := mgr.GetFieldIndexer().IndexField(r.Context(), &capabilitiesv1beta1.ActiveDoc{}, gitCredentialsSecretIndexField, activeDocGitCredentialsSecretIndexer)
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.ActiveDoc{}).
+		For(&capabilitiesv1beta1.ActiveDoc{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(gitCredentialsSecretToActiveDocEventMapper.Map)).
 		Complete(r)
 }
@@ -20,16 +20,18 @@ type ActiveDocStatusReconciler struct {
 	*reconcilers.BaseReconciler
 	resource            *capabilitiesv1beta1.ActiveDoc
 	providerAccountHost string
+	gitCommitSHA        string
 	activeDoc           *threescaleapi.ActiveDoc
 	reconcileError      error
 	logger              logr.Logger
 }
 
-func NewActiveDocStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ActiveDoc, providerAccountHost string, activeDoc *threescaleapi.ActiveDoc, reconcileError error) *ActiveDocStatusReconciler {
+func NewActiveDocStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ActiveDoc, providerAccountHost, gitCommitSHA string, activeDoc *threescaleapi.ActiveDoc, reconcileError error) *ActiveDocStatusReconciler {
 	return &ActiveDocStatusReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		providerAccountHost: providerAccountHost,
+		gitCommitSHA:        gitCommitSHA,
 		activeDoc:           activeDoc,
 		reconcileError:      reconcileError,
 		logger:              b.Logger().WithValues("Status Reconciler", resource.Name),
@@ -84,6 +86,14 @@ func (s *ActiveDocStatusReconciler) calculateStatus() (*capabilitiesv1beta1.Acti
 
 	newStatus.ProviderAccountHost = s.providerAccountHost
 
+	newStatus.GitCommitSHA = s.gitCommitSHA
+
+	// The document hash annotation is set by the OpenAPI controller on managed activedocs
+	newStatus.OpenAPIDocumentHash = s.resource.Status.OpenAPIDocumentHash
+	if s.reconcileError == nil {
+		newStatus.OpenAPIDocumentHash = s.resource.GetAnnotations()[openAPIDocumentHashAnnotation]
+	}
+
 	productResourceName, err := s.getReferencedProduct()
 	if err != nil {
 		return nil, err
@@ -98,6 +108,8 @@ func (s *ActiveDocStatusReconciler) calculateStatus() (*capabilitiesv1beta1.Acti
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.resource.Status.LastSyncTime, s.reconcileError, s.resource.GetAnnotations())
+
 	return newStatus, nil
 }
 
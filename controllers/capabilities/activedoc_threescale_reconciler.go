@@ -26,6 +26,7 @@ type ActiveDocThreescaleReconciler struct {
 	resource            *capabilitiesv1beta1.ActiveDoc
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
 	providerAccountHost string
+	gitCommitSHA        string
 	logger              logr.Logger
 }
 
@@ -39,6 +40,11 @@ func NewActiveDocThreescaleReconciler(b *reconcilers.BaseReconciler, resource *c
 	}
 }
 
+// GitCommitSHA returns the commit the OpenAPI document was read from, when read from a git repository
+func (s *ActiveDocThreescaleReconciler) GitCommitSHA() string {
+	return s.gitCommitSHA
+}
+
 func (s *ActiveDocThreescaleReconciler) Reconcile() (*threescaleapi.ActiveDoc, error) {
 	s.logger.V(1).Info("START")
 
@@ -202,6 +208,16 @@ func (s *ActiveDocThreescaleReconciler) getDesiredActiveDocBody() (*openapi3.T,
 		return s.readOpenAPISecret()
 	}
 
+	if s.resource.Spec.ActiveDocOpenAPIRef.Git != nil {
+		gitFldPath := field.NewPath("spec").Child("activeDocOpenAPIRef", "git")
+		openapiObj, _, gitCommitSHA, err := readOpenAPIFromGit(s.Context(), s.Client(), s.resource.Namespace, s.resource.Spec.ActiveDocOpenAPIRef.Git, gitFldPath)
+		if err != nil {
+			return nil, err
+		}
+		s.gitCommitSHA = gitCommitSHA
+		return openapiObj, nil
+	}
+
 	// Must be URL
 	return s.readOpenAPIFromURL()
 }
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkRemoteOwnership protects 3scale objects created out of the operator from being taken over by custom resources
+// matching them by system name.
+// Returns true when the custom resource adopts the 3scale object.
+// Returns ConflictError when the 3scale object is not owned and the adopt annotation is missing
+func checkRemoteOwnership(object metav1.Object, statusID *int64, remoteID int64, kind, systemName string) (bool, error) {
+	if controllerhelper.IsRemoteObjectOwned(statusID, remoteID) {
+		return false, nil
+	}
+
+	if controllerhelper.IsAdoptAnnotationTrue(object.GetAnnotations()) {
+		return true, nil
+	}
+
+	return false, &helper.ConflictError{
+		Err: fmt.Errorf("%s [%s] with ID %d already exists in 3scale and is not managed by %s/%s. Set the %s annotation to \"true\" to adopt it",
+			kind, systemName, remoteID, object.GetNamespace(), object.GetName(), controllerhelper.AdoptAnnotation),
+	}
+}
+
+// saveRemoteOwnership records the ID of the 3scale object just created for the custom resource in its status.
+// The ID is the ownership marker of the 3scale object. It is saved right away, retrying on conflicts,
+// otherwise a conflicting status update at the end of the reconciliation would lose it and
+// the next reconciliation would find a 3scale object not owned by the custom resource
+func saveRemoteOwnership(ctx context.Context, k8sClient client.Client, object client.Object, setID func(client.Object)) error {
+	latest := object.DeepCopyObject().(client.Object)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(object), latest)
+		if err != nil {
+			return err
+		}
+
+		setID(latest)
+		return k8sClient.Status().Update(ctx, latest)
+	})
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckRemoteOwnership(t *testing.T) {
+	ownedID := int64(3)
+	otherID := int64(4)
+
+	cases := []struct {
+		testName      string
+		annotations   map[string]string
+		statusID      *int64
+		expectedAdopt bool
+		expectedError bool
+	}{
+		{"owned", nil, &ownedID, false, false},
+		{"owned with adopt annotation", map[string]string{controllerhelper.AdoptAnnotation: "true"}, &ownedID, false, false},
+		{"status without ID", nil, nil, false, true},
+		{"status with another ID", nil, &otherID, false, true},
+		{"adopt annotation false", map[string]string{controllerhelper.AdoptAnnotation: "false"}, nil, false, true},
+		{"adopt", map[string]string{controllerhelper.AdoptAnnotation: "true"}, nil, true, false},
+		{"adopt uppercase", map[string]string{controllerhelper.AdoptAnnotation: "True"}, &otherID, true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			product := &capabilitiesv1beta1.Product{
+				ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "test", Annotations: tc.annotations},
+			}
+
+			adopt, err := checkRemoteOwnership(product, tc.statusID, ownedID, "product", "api01")
+			if adopt != tc.expectedAdopt {
+				subT.Errorf("adopt = %t, want %t", adopt, tc.expectedAdopt)
+			}
+
+			if tc.expectedError != helper.IsConflictError(err) {
+				subT.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSaveRemoteOwnership(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := capabilitiesv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	product := &capabilitiesv1beta1.Product{
+		ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "test"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(product).WithStatusSubresource(product).Build()
+
+	// Stale copy, the custom resource is updated concurrently during the reconciliation
+	stale := &capabilitiesv1beta1.Product{}
+	if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(product), stale); err != nil {
+		t.Fatal(err)
+	}
+	updated := stale.DeepCopy()
+	updated.Annotations = map[string]string{"updated": "true"}
+	if err := k8sClient.Update(context.TODO(), updated); err != nil {
+		t.Fatal(err)
+	}
+
+	productID := int64(3)
+	err := saveRemoteOwnership(context.TODO(), k8sClient, stale, func(obj client.Object) {
+		obj.(*capabilitiesv1beta1.Product).Status.ID = &productID
+	})
+	if err != nil {
+		t.Fatalf("saveRemoteOwnership() error = %v", err)
+	}
+
+	saved := &capabilitiesv1beta1.Product{}
+	if err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(product), saved); err != nil {
+		t.Fatal(err)
+	}
+	if !controllerhelper.IsRemoteObjectOwned(saved.Status.ID, productID) {
+		t.Errorf("status ID = %v, want %d", saved.Status.ID, productID)
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -45,7 +46,7 @@ type ApplicationReconciler struct {
 
 const (
 	// applicationIdAnnotation matches the application.statu.ID
-	applicationIdAnnotation = "applicationID"
+	applicationIdAnnotation = controllerhelper.ApplicationIDAnnotation
 
 	applicationFinalizer = "application.capabilities.3scale.net/finalizer"
 )
@@ -184,7 +185,7 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	reqLogger.Info("END", "error", reconcileErr)
 
-	return ctrl.Result{}, nil
+	return controllerhelper.WithResync(ctrl.Result{}, application.GetAnnotations()), nil
 }
 
 func (r *ApplicationReconciler) reconcileMetadata(application *capabilitiesv1beta1.Application) bool {
@@ -235,6 +236,13 @@ func (r *ApplicationReconciler) applicationReconciler(applicationResource *capab
 		return statusReconciler, err
 	}
 
+	// Applications of retired template plans are subscribed to the plan they migrate to
+	productResource, err = controllerhelper.ExpandApplicationPlanTemplates(productResource, controllerhelper.NewApplicationPlanTemplateGetter(r.Context(), r.Client(), productResource.Namespace))
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, nil, "", err)
+		return statusReconciler, err
+	}
+
 	reconciler := NewApplicationReconciler(r.BaseReconciler, applicationResource, accountResource, productResource, threescaleAPIClient)
 	ApplicationEntity, err := reconciler.Reconcile()
 	if err != nil {
@@ -248,6 +256,11 @@ func (r *ApplicationReconciler) applicationReconciler(applicationResource *capab
 func (r *ApplicationReconciler) removeApplicationFrom3scale(application *capabilitiesv1beta1.Application, req ctrl.Request, threescaleAPIClient threescaleapi.ThreeScaleClient) error {
 	logger := r.Logger().WithValues("application", client.ObjectKey{Name: application.Name, Namespace: application.Namespace})
 
+	if controllerhelper.IsOrphanDeletionPolicy(application.GetAnnotations()) {
+		logger.Info("application not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
 	// get Account
 	account := &capabilitiesv1beta1.DeveloperAccount{}
 	projectMeta := types.NamespacedName{
@@ -312,6 +325,6 @@ func (r *ApplicationReconciler) checkExternalResources(applicationResource *capa
 
 func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.Application{}).
+		For(&capabilitiesv1beta1.Application{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Complete(r)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-porta-go-client/client"
 	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"reflect"
@@ -133,6 +134,42 @@ func getApplicationJson(state string) *client.Application {
 	return applicationJson
 }
 
+// getRetiredTemplatePlanApplicationCR returns an application subscribed to the retired "old" template plan
+func getRetiredTemplatePlanApplicationCR() *capabilitiesv1beta1.Application {
+	application := getApplicationCR()
+	application.Spec.ApplicationPlanName = "old"
+	return application
+}
+
+// getTemplateProduct returns a product retiring the "old" template plan, migrating to the "test" plan
+func getTemplateProduct() *capabilitiesv1beta1.Product {
+	product := getProductList().Items[0].DeepCopy()
+	product.Spec.ApplicationPlanTemplateRefs = []capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{
+		{
+			Name: "tiers",
+			Overrides: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"old": {Retire: &capabilitiesv1beta1.ApplicationPlanRetireSpec{MigrateTo: "test"}},
+			},
+		},
+	}
+	return product
+}
+
+func getApplicationPlanTemplate() *capabilitiesv1beta1.ApplicationPlanTemplate {
+	return &capabilitiesv1beta1.ApplicationPlanTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tiers",
+			Namespace: "test",
+		},
+		Spec: capabilitiesv1beta1.ApplicationPlanTemplateSpec{
+			ApplicationPlans: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"old":  {},
+				"test": {},
+			},
+		},
+	}
+}
+
 func TestApplicationReconciler_applicationReconciler(t *testing.T) {
 
 	//admin portal
@@ -179,6 +216,25 @@ func TestApplicationReconciler_applicationReconciler(t *testing.T) {
 				nil),
 			wantErr: false,
 		},
+		{
+			name: "Create application subscribed to a retired template plan",
+			fields: fields{
+				BaseReconciler: getBaseReconciler(getRetiredTemplatePlanApplicationCR(), getTemplateProduct(), getApplicationPlanTemplate()),
+			},
+			args: args{
+				applicationResource: getRetiredTemplatePlanApplicationCR(),
+				req: controllerruntime.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      "test",
+						Namespace: "test",
+					},
+				},
+				threescaleApiClient:     client.NewThreeScale(ap, "test", mockHttpClientApplication(getApplicationPlanListByProductJson(), getApplicationJson("live"))),
+				providerAccountAdminURL: "https://3scale-admin.test.3scale.net",
+				accountResource:         getApplicationDeveloperAccount(),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Attempt to create application with unknown Product and Account CR",
 			fields: fields{
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func (t *ProductThreescaleReconciler) retireApplicationPlans(_ interface{}) error {
+	retiredKeys := make([]string, 0)
+	for systemName, planSpec := range t.resource.Spec.ApplicationPlans {
+		if planSpec.IsRetired() {
+			retiredKeys = append(retiredKeys, systemName)
+		}
+	}
+	// Sorted for a stable status
+	sort.Strings(retiredKeys)
+
+	existingList, err := t.productEntity.ApplicationPlans()
+	if err != nil {
+		return fmt.Errorf("Error retire product [%s] plans: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingMap := map[string]threescaleapi.ApplicationPlanItem{}
+	for _, existing := range existingList.Plans {
+		existingMap[existing.Element.SystemName] = existing.Element
+	}
+
+	retirements := make([]capabilitiesv1beta1.ApplicationPlanRetirementStatus, 0, len(retiredKeys))
+	// Set even when failing, to report the progress of the plans already processed
+	defer func() { t.planRetirements = retirements }()
+
+	for _, systemName := range retiredKeys {
+		migrateTo := t.resource.Spec.ApplicationPlans[systemName].Retire.MigrateTo
+		retirement := capabilitiesv1beta1.ApplicationPlanRetirementStatus{
+			SystemName: systemName,
+			MigrateTo:  migrateTo,
+			State:      capabilitiesv1beta1.ApplicationPlanRetirementRetiredState,
+		}
+
+		existing, ok := existingMap[systemName]
+		if !ok {
+			// Already deleted
+			retirements = append(retirements, retirement)
+			continue
+		}
+
+		// spec validation ensures migrateTo references a desired plan, created by the application plans sync
+		target, ok := existingMap[migrateTo]
+		if !ok {
+			return fmt.Errorf("Error retire product [%s] plan [%s]: plan [%s] not found", t.resource.Spec.SystemName, systemName, migrateTo)
+		}
+
+		pending, err := t.migrateApplications(existing.ID, target.ID)
+		if err != nil {
+			return fmt.Errorf("Error retire product [%s] plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+
+		if pending > 0 {
+			retirement.State = capabilitiesv1beta1.ApplicationPlanRetirementMigratingState
+			retirement.PendingApplications = pending
+			retirements = append(retirements, retirement)
+			continue
+		}
+
+		t.logger.V(1).Info("retireApplicationPlans", "deleted plan", systemName)
+		err = t.productEntity.DeleteApplicationPlan(existing.ID)
+		if err != nil {
+			return fmt.Errorf("Error retire product [%s] plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+		retirements = append(retirements, retirement)
+	}
+
+	return nil
+}
+
+// planRetirementsPendingError returns a wait error when retired plans still have applications pending migration.
+// The other tasks do not depend on the migration, so it is reported once the product is synchronized
+func (t *ProductThreescaleReconciler) planRetirementsPendingError() error {
+	pendingKeys := []string{}
+	pendingApplications := 0
+	for _, retirement := range t.planRetirements {
+		if retirement.State == capabilitiesv1beta1.ApplicationPlanRetirementMigratingState {
+			pendingKeys = append(pendingKeys, retirement.SystemName)
+			pendingApplications += retirement.PendingApplications
+		}
+	}
+
+	if len(pendingKeys) == 0 {
+		return nil
+	}
+
+	return &helper.WaitError{
+		Err: fmt.Errorf("product [%s] plans %v: %d applications pending migration", t.resource.Spec.SystemName, pendingKeys, pendingApplications),
+	}
+}
+
+// migrateApplications moves the applications subscribed to the plan, including the ones not managed by Application resources,
+// to the target plan. Returns the number of applications still subscribed to the plan
+func (t *ProductThreescaleReconciler) migrateApplications(planID, targetPlanID int64) (int, error) {
+	migrated := map[int64]bool{}
+	for {
+		subscribed := 0
+		pending := 0
+		// Migrated applications leave the list and the next pages shift,
+		// the list is read again until no application is migrated
+		for page := 1; ; page++ {
+			list, err := t.plansAPIClient.ListApplicationsByPlan(planID, page)
+			if err != nil {
+				return 0, err
+			}
+
+			for _, item := range list.Applications {
+				application := item.Application
+				// Never trust the plan filter, other plans applications must not be moved
+				if application.PlanID != planID {
+					continue
+				}
+
+				subscribed++
+				if migrated[application.ID] {
+					// Already migrated, but still subscribed
+					pending++
+					continue
+				}
+
+				accountID, err := strconv.ParseInt(application.UserAccountID, 10, 64)
+				if err != nil {
+					return 0, fmt.Errorf("application [%d] account ID: %w", application.ID, err)
+				}
+
+				t.logger.V(1).Info("migrateApplications", "application", application.ID, "plan", targetPlanID)
+				_, err = t.threescaleAPIClient.ChangeApplicationPlan(accountID, application.ID, targetPlanID)
+				if err != nil {
+					return 0, fmt.Errorf("application [%d]: %w", application.ID, err)
+				}
+				migrated[application.ID] = true
+			}
+
+			if len(list.Applications) < controllerhelper.ApplicationListPerPage {
+				break
+			}
+		}
+
+		if pending == subscribed {
+			return pending, nil
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProductThreescaleReconciler_retireApplicationPlans(t *testing.T) {
+	tests := []struct {
+		name                string
+		changePlanEffective bool
+		wantWaitErr         bool
+		wantRequests        []string
+		wantRetirements     []capabilitiesv1beta1.ApplicationPlanRetirementStatus
+	}{
+		{
+			name:                "applications migrated and plan deleted",
+			changePlanEffective: true,
+			wantWaitErr:         false,
+			wantRequests: []string{
+				"GET /admin/api/services/10/application_plans.json",
+				"GET /admin/api/applications.json",
+				"PUT /admin/api/accounts/3/applications/100/change_plan.json plan_id=2",
+				"PUT /admin/api/accounts/4/applications/101/change_plan.json plan_id=2",
+				"GET /admin/api/applications.json",
+				"DELETE /admin/api/services/10/application_plans/1.json",
+			},
+			wantRetirements: []capabilitiesv1beta1.ApplicationPlanRetirementStatus{
+				{SystemName: "old", MigrateTo: "new", State: capabilitiesv1beta1.ApplicationPlanRetirementRetiredState},
+			},
+		},
+		{
+			name:                "applications pending migration",
+			changePlanEffective: false,
+			wantWaitErr:         true,
+			wantRequests: []string{
+				"GET /admin/api/services/10/application_plans.json",
+				"GET /admin/api/applications.json",
+				"PUT /admin/api/accounts/3/applications/100/change_plan.json plan_id=2",
+				"PUT /admin/api/accounts/4/applications/101/change_plan.json plan_id=2",
+				"GET /admin/api/applications.json",
+			},
+			wantRetirements: []capabilitiesv1beta1.ApplicationPlanRetirementStatus{
+				{SystemName: "old", MigrateTo: "new", State: capabilitiesv1beta1.ApplicationPlanRetirementMigratingState, PendingApplications: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			requests := []string{}
+			subscribed := map[string]string{"100": "3", "101": "4"}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					subT.Fatal(err)
+				}
+				request := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+				if len(r.PostForm) > 0 {
+					request = fmt.Sprintf("%s %s", request, r.PostForm.Encode())
+				}
+				requests = append(requests, request)
+
+				switch {
+				case r.URL.Path == "/admin/api/services/10/application_plans.json":
+					fmt.Fprint(w, `{"plans":[`+
+						`{"application_plan":{"id":1,"name":"Old","system_name":"old","state":"hidden"}},`+
+						`{"application_plan":{"id":2,"name":"New","system_name":"new","state":"published"}}]}`)
+				case r.URL.Path == "/admin/api/applications.json":
+					if r.URL.Query().Get("plan_id") != "1" {
+						subT.Errorf("unexpected plan_id filter: %s", r.URL.RawQuery)
+					}
+					// Applications of other plans, when the filter is not applied, are never moved
+					applications := []string{`{"application":{"id":200,"user_account_id":"5","plan_id":7}}`}
+					for _, id := range []string{"100", "101"} {
+						if accountID, ok := subscribed[id]; ok {
+							applications = append(applications, fmt.Sprintf(`{"application":{"id":%s,"user_account_id":"%s","plan_id":1}}`, id, accountID))
+						}
+					}
+					fmt.Fprintf(w, `{"applications":[%s]}`, strings.Join(applications, ","))
+				case strings.HasSuffix(r.URL.Path, "/change_plan.json"):
+					if tt.changePlanEffective {
+						delete(subscribed, strings.Split(r.URL.Path, "/")[6])
+					}
+					fmt.Fprint(w, `{"application":{"id":100,"plan_id":2}}`)
+				default:
+					w.WriteHeader(http.StatusOK)
+				}
+			}))
+			defer server.Close()
+
+			ap, err := threescaleapi.NewAdminPortalFromStr(server.URL)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			threescaleAPIClient := threescaleapi.NewThreeScale(ap, "token", server.Client())
+
+			plansAPIClient, err := controllerhelper.NewPlansAPIClient(&controllerhelper.ProviderAccount{AdminURLStr: server.URL, Token: "token"}, false)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			logger := logf.Log.WithName("application plan retirement test")
+			productEntity := controllerhelper.NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: 10}}, threescaleAPIClient, logger)
+
+			reconciler := &ProductThreescaleReconciler{
+				resource: &capabilitiesv1beta1.Product{
+					Spec: capabilitiesv1beta1.ProductSpec{
+						SystemName: "product",
+						ApplicationPlans: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+							"old": {Retire: &capabilitiesv1beta1.ApplicationPlanRetireSpec{MigrateTo: "new"}},
+							"new": {},
+						},
+					},
+				},
+				productEntity:       productEntity,
+				threescaleAPIClient: threescaleAPIClient,
+				plansAPIClient:      plansAPIClient,
+				logger:              logger,
+			}
+
+			// Pending migrations do not stop the other tasks
+			err = reconciler.retireApplicationPlans(nil)
+			if err != nil {
+				subT.Fatalf("retireApplicationPlans() error = %v", err)
+			}
+
+			err = reconciler.planRetirementsPendingError()
+			if helper.IsWaitError(err) != tt.wantWaitErr {
+				subT.Errorf("planRetirementsPendingError() error = %v, wantWaitErr %v", err, tt.wantWaitErr)
+			}
+
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				subT.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			if !reflect.DeepEqual(reconciler.planRetirements, tt.wantRetirements) {
+				subT.Errorf("planRetirements = %v, want %v", reconciler.planRetirements, tt.wantRetirements)
+			}
+		})
+	}
+}
@@ -53,6 +53,11 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), existingMap[systemName], t.threescaleAPIClient, t.logger)
 		// desired spec
 		planSpec := t.resource.Spec.ApplicationPlans[systemName]
+		if planSpec.IsRetired() {
+			// Retired plans are hidden to prevent new subscriptions
+			falseValue := false
+			planSpec.Published = &falseValue
+		}
 		reconciler := newApplicationPlanReconciler(t.BaseReconciler, systemName, planSpec, t.threescaleAPIClient, t.productEntity, t.backendRemoteIndex, planEntity, t.logger)
 		err := reconciler.Reconcile()
 		if err != nil {
@@ -70,6 +75,10 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 		// key is expected to exist
 		// desiredNewKeys is a subset of the Spec.ApplicationPlans map key set
 		planSpec := t.resource.Spec.ApplicationPlans[systemName]
+		if planSpec.IsRetired() {
+			// Retired plans are not created again once deleted
+			continue
+		}
 
 		// Create Application Plan using system_name.
 		// it cannot be modified later
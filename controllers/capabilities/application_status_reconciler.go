@@ -90,6 +90,8 @@ func (s *ApplicationStatusReconciler) calculateStatus() *capabilitiesv1beta1.App
 	newStatus.Conditions = s.applicationResource.Status.Conditions.Copy()
 	newStatus.Conditions.SetCondition(s.ReadyCondition())
 
+	newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.applicationResource.Status.LastSyncTime, s.syncError, s.applicationResource.GetAnnotations())
+
 	return newStatus
 }
 
@@ -117,9 +117,11 @@ func (t *ApplicationThreescaleReconciler) findPlan() (*threescaleapi.Application
 		return nil, fmt.Errorf("reconcile3scaleApplications application [%s]: %w", t.applicationResource.Spec.ApplicationPlanName, err)
 	}
 
+	// Applications of retired plans are subscribed to the plan they migrate to
+	planSystemName := t.productResource.ApplicationPlanTarget(t.applicationResource.Spec.ApplicationPlanName)
 	planID, planExists := func(pList []threescaleapi.ApplicationPlan) (int, bool) {
 		for i, item := range pList {
-			if item.Element.SystemName == t.applicationResource.Spec.ApplicationPlanName {
+			if item.Element.SystemName == planSystemName {
 				return i, true
 			}
 		}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/go-logr/logr"
+)
+
+// ApplicationPlanTemplateToProductEventMapper is an EventHandler that maps an ApplicationPlanTemplate CR to the Product CRs referencing it,
+// so updating a template synchronizes the application plans of every dependent product
+type ApplicationPlanTemplateToProductEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (p *ApplicationPlanTemplateToProductEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	productList := &capabilitiesv1beta1.ProductList{}
+
+	// Application plan templates are referenced from the same namespace
+	err := p.K8sClient.List(ctx, productList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		p.Logger.Error(err, "failed to list Product resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range productList.Items {
+		for _, ref := range productList.Items[idx].Spec.ApplicationPlanTemplateRefs {
+			if ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name:      productList.Items[idx].GetName(),
+					Namespace: productList.Items[idx].GetNamespace(),
+				}})
+				break
+			}
+		}
+	}
+
+	p.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
+	return requests
+}
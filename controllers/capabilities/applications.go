@@ -58,9 +58,11 @@ func (t *ApplicationThreescaleReconciler) findPlanId(productID int64) (int64, er
 		return -1, fmt.Errorf("reconcile3scaleApplication application [%s]: %w", t.applicationResource.Spec.ApplicationPlanName, err)
 	}
 
+	// Applications of retired plans are subscribed to the plan they migrate to
+	planSystemName := t.productResource.ApplicationPlanTarget(t.applicationResource.Spec.ApplicationPlanName)
 	planID, planExists := func(pList []threescaleapi.ApplicationPlan) (int, bool) {
 		for i, item := range pList {
-			if item.Element.SystemName == t.applicationResource.Spec.ApplicationPlanName {
+			if item.Element.SystemName == planSystemName {
 				return i, true
 			}
 		}
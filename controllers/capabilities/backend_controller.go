@@ -28,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -85,14 +86,17 @@ func (r *BackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Ignore deleted Backends, this can happen when foregroundDeletion is enabled
 	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if backend.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(backend, backendFinalizer) {
-		res, err := r.removeBackendReferencesFromProducts(backend)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
+		// Product CRs keep referencing orphaned and observed backends, so backend usages are kept in 3scale
+		if !controllerhelper.IsOrphanDeletionPolicy(backend.GetAnnotations()) && !backend.IsObserved() {
+			res, err := r.removeBackendReferencesFromProducts(backend)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
 
-		if res.Requeue {
-			reqLogger.Info("Removed backend references from product CRs. Requeueing.")
-			return res, nil
+			if res.Requeue {
+				reqLogger.Info("Removed backend references from product CRs. Requeueing.")
+				return res, nil
+			}
 		}
 
 		err = r.removeBackendFrom3scale(backend)
@@ -183,13 +187,20 @@ func (r *BackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{}, nil
 		}
 
+		if helper.IsConflictError(reconcileErr) {
+			// On Conflict error, no need to retry until the backend is adopted
+			reqLogger.Info("ERROR", "conflict error", reconcileErr)
+			r.EventRecorder().Eventf(backend, corev1.EventTypeWarning, "Conflict", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
 		reqLogger.Error(reconcileErr, "Failed to reconcile")
 		r.EventRecorder().Eventf(backend, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
 		return ctrl.Result{}, reconcileErr
 	}
 
 	reqLogger.Info("END", "error", reconcileErr)
-	return ctrl.Result{}, nil
+	return controllerhelper.WithResync(ctrl.Result{}, backend.GetAnnotations()), nil
 }
 
 func (r *BackendReconciler) reconcile(backendResource *capabilitiesv1beta1.Backend) (*BackendStatusReconciler, error) {
@@ -223,6 +234,7 @@ func (r *BackendReconciler) reconcile(backendResource *capabilitiesv1beta1.Backe
 	reconciler := NewThreescaleReconciler(r.BaseReconciler, backendResource, threescaleAPIClient, backendRemoteIndex, providerAccount)
 	backendAPIEntity, err := reconciler.Reconcile()
 	statusReconciler := NewBackendStatusReconciler(r.BaseReconciler, backendResource, backendAPIEntity, providerAccount.AdminURLStr, err)
+	statusReconciler.drifts = reconciler.drifts
 	return statusReconciler, err
 }
 
@@ -284,6 +296,16 @@ func (r *BackendReconciler) removeBackendReferencesFromProducts(backend *capabil
 func (r *BackendReconciler) removeBackendFrom3scale(backend *capabilitiesv1beta1.Backend) error {
 	logger := r.Logger().WithValues("backend", client.ObjectKey{Name: backend.Name, Namespace: backend.Namespace})
 
+	if controllerhelper.IsOrphanDeletionPolicy(backend.GetAnnotations()) {
+		logger.Info("backend not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
+	if backend.IsObserved() {
+		logger.Info("backend not deleted from 3scale, management policy is Observe")
+		return nil
+	}
+
 	// Attempt to remove backend only if backend.Status.ID is present
 	if backend.Status.ID == nil {
 		logger.Info("could not remove backend because ID is missing in status")
@@ -346,6 +368,6 @@ func (r *BackendReconciler) fetchTenantProductCRs(productsCRsList *capabilitiesv
 
 func (r *BackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.Backend{}).
+		For(&capabilitiesv1beta1.Backend{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Complete(r)
 }
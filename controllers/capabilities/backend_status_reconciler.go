@@ -21,7 +21,9 @@ type BackendStatusReconciler struct {
 	backendAPIEntity    *controllerhelper.BackendAPIEntity
 	providerAccountHost string
 	syncError           error
-	logger              logr.Logger
+	// drifts is nil when the backend in 3scale has not been compared with the spec
+	drifts driftList
+	logger logr.Logger
 }
 
 func NewBackendStatusReconciler(b *reconcilers.BaseReconciler, backendResource *capabilitiesv1beta1.Backend, backendAPIEntity *controllerhelper.BackendAPIEntity, providerAccountHost string, syncError error) *BackendStatusReconciler {
@@ -76,6 +78,9 @@ func (s *BackendStatusReconciler) calculateStatus() *capabilitiesv1beta1.Backend
 	if s.backendAPIEntity != nil {
 		tmp := s.backendAPIEntity.ID()
 		newStatus.ID = &tmp
+	} else if s.syncError != nil {
+		// The ID is the ownership marker of the backend in 3scale, it must survive failed reconciliations
+		newStatus.ID = s.backendResource.Status.ID
 	}
 
 	newStatus.ProviderAccountHost = s.providerAccountHost
@@ -86,6 +91,12 @@ func (s *BackendStatusReconciler) calculateStatus() *capabilitiesv1beta1.Backend
 	newStatus.Conditions.SetCondition(s.syncCondition())
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
+	newStatus.Conditions.SetCondition(s.conflictCondition())
+	if s.drifts != nil {
+		newStatus.Conditions.SetCondition(s.drifts.driftedCondition(capabilitiesv1beta1.BackendDriftedConditionType, s.backendResource.IsObserved()))
+	}
+
+	newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.backendResource.Status.LastSyncTime, s.syncError, s.backendResource.GetAnnotations())
 
 	return newStatus
 }
@@ -131,3 +142,18 @@ func (s *BackendStatusReconciler) failedCondition() common.Condition {
 
 	return condition
 }
+
+func (s *BackendStatusReconciler) conflictCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.BackendConflictConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if helper.IsConflictError(s.syncError) {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "NotOwned"
+		condition.Message = s.syncError.Error()
+	}
+
+	return condition
+}
@@ -3,7 +3,6 @@ package controllers
 import (
 	"errors"
 	"fmt"
-	"strconv"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
@@ -12,6 +11,8 @@ import (
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type BackendThreescaleReconciler struct {
@@ -21,7 +22,9 @@ type BackendThreescaleReconciler struct {
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
 	providerAccount     *controllerhelper.ProviderAccount
-	logger              logr.Logger
+	// drifts is nil until the backend in 3scale is compared with the spec
+	drifts driftList
+	logger logr.Logger
 }
 
 func NewThreescaleReconciler(b *reconcilers.BaseReconciler,
@@ -42,6 +45,31 @@ func NewThreescaleReconciler(b *reconcilers.BaseReconciler,
 }
 
 func (t *BackendThreescaleReconciler) Reconcile() (*controllerhelper.BackendAPIEntity, error) {
+	if t.backendResource.IsObserved() {
+		return t.observe()
+	}
+
+	// Changes made out of the operator are overwritten.
+	// Compare before synchronizing to report what is overwritten
+	drifts := driftList{}
+	if backendAPIEntity, exists := t.backendRemoteIndex.FindBySystemName(t.backendResource.Spec.SystemName); exists {
+		adopt, err := checkRemoteOwnership(t.backendResource, t.backendResource.Status.ID, backendAPIEntity.ID(), "backend", t.backendResource.Spec.SystemName)
+		if err != nil {
+			return nil, err
+		}
+
+		if adopt {
+			t.EventRecorder().Eventf(t.backendResource, corev1.EventTypeNormal, "Adopted", "backend [%s] with ID %d adopted", t.backendResource.Spec.SystemName, backendAPIEntity.ID())
+		}
+
+		t.backendAPIEntity = backendAPIEntity
+
+		drifts, err = t.detectDrift()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	taskRunner := helper.NewTaskRunner(nil, t.logger)
 	taskRunner.AddTask("SyncBackend", t.syncBackend)
 	// First methods and metrics, then mapping rules.
@@ -53,9 +81,32 @@ func (t *BackendThreescaleReconciler) Reconcile() (*controllerhelper.BackendAPIE
 	taskRunner.AddTask("SyncMappingRules", t.syncMappingRules)
 
 	err := taskRunner.Run()
+	if err != nil {
+		// When the backend exists in 3scale, its ID is kept in the status as ownership marker
+		return t.backendAPIEntity, err
+	}
+
+	t.drifts = drifts
+	t.drifts.recordOverwrites(t.BaseReconciler, t.backendResource)
+
+	return t.backendAPIEntity, nil
+}
+
+// observe compares the backend in 3scale with the spec, never changing the backend in 3scale
+func (t *BackendThreescaleReconciler) observe() (*controllerhelper.BackendAPIEntity, error) {
+	backendAPIEntity, exists := t.backendRemoteIndex.FindBySystemName(t.backendResource.Spec.SystemName)
+	if !exists {
+		t.drifts = driftList{{field: "spec.systemName", existing: driftMissingValue, desired: t.backendResource.Spec.SystemName}}
+		return nil, nil
+	}
+
+	t.backendAPIEntity = backendAPIEntity
+
+	drifts, err := t.detectDrift()
 	if err != nil {
 		return nil, err
 	}
+	t.drifts = drifts
 
 	return t.backendAPIEntity, nil
 }
@@ -80,6 +131,15 @@ func (t *BackendThreescaleReconciler) syncBackend(_ interface{}) error {
 		if err != nil {
 			return fmt.Errorf("Error sync backend [%s]: %w", t.backendResource.Spec.SystemName, err)
 		}
+
+		backendID := backendAPIEntity.ID()
+		t.backendResource.Status.ID = &backendID
+		err = saveRemoteOwnership(t.Context(), t.Client(), t.backendResource, func(obj client.Object) {
+			obj.(*capabilitiesv1beta1.Backend).Status.ID = &backendID
+		})
+		if err != nil {
+			return fmt.Errorf("Error sync backend [%s] saving ID %d: %w", t.backendResource.Spec.SystemName, backendID, err)
+		}
 	}
 
 	// Will be used by coming steps
@@ -567,8 +627,6 @@ func (t *BackendThreescaleReconciler) getExistingMappingRules() (map[string]thre
 }
 
 func (t *BackendThreescaleReconciler) reconcileMappingRuleWithPosition(desired capabilitiesv1beta1.MappingRuleSpec, desiredPosition int, existing threescaleapi.MappingRuleItem) error {
-	params := threescaleapi.Params{}
-
 	//
 	// Reconcile metric or method
 	//
@@ -582,35 +640,8 @@ func (t *BackendThreescaleReconciler) reconcileMappingRuleWithPosition(desired c
 		return errors.New("backend metric method ref for mapping rule not found")
 	}
 
-	if metricID != existing.MetricID {
-		params["metric_id"] = strconv.FormatInt(metricID, 10)
-	}
-
-	//
-	// Reconcile delta
-	//
-	if desired.Increment != existing.Delta {
-		params["delta"] = strconv.Itoa(desired.Increment)
-	}
-
-	//
-	// Reconcile last
-	//
-	desiredLastAttribute := false
-	if desired.Last != nil {
-		desiredLastAttribute = *desired.Last
-	}
-
-	if desiredLastAttribute != existing.Last {
-		params["last"] = strconv.FormatBool(desiredLastAttribute)
-	}
-
-	//
-	// Reconcile Position
-	//
-	if desiredPosition != existing.Position {
-		params["position"] = strconv.FormatInt(int64(desiredPosition), 10)
-	}
+	desiredItem := controllerhelper.NewMappingRuleItem(desired, desiredPosition, metricID)
+	params := controllerhelper.MappingRuleParams(existing, desiredItem)
 
 	if len(params) > 0 {
 		err := t.backendAPIEntity.UpdateMappingRule(existing.ID, params)
@@ -633,18 +664,7 @@ func (t *BackendThreescaleReconciler) createNewMappingRuleWithPosition(desired c
 		return errors.New("backend metric method ref for mapping rule not found")
 	}
 
-	params := threescaleapi.Params{
-		"pattern":     desired.Pattern,
-		"http_method": desired.HTTPMethod,
-		"metric_id":   strconv.FormatInt(metricID, 10),
-		"delta":       strconv.Itoa(desired.Increment),
-	}
-
-	if desired.Last != nil {
-		params["last"] = strconv.FormatBool(*desired.Last)
-	}
-
-	params["position"] = strconv.FormatInt(int64(desiredPosition), 10)
+	params := controllerhelper.NewMappingRuleParams(controllerhelper.NewMappingRuleItem(desired, desiredPosition, metricID))
 
 	err = t.backendAPIEntity.CreateMappingRule(params)
 	if err != nil {
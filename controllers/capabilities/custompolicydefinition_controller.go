@@ -24,6 +24,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
@@ -31,9 +34,12 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
 )
 
+const customPolicyDefinitionFinalizer = "custompolicydefinition.capabilities.3scale.net/finalizer"
+
 // CustomPolicyDefinitionReconciler reconciles a CustomPolicyDefinition object
 type CustomPolicyDefinitionReconciler struct {
 	*reconcilers.BaseReconciler
@@ -44,6 +50,7 @@ var _ reconcile.Reconciler = &CustomPolicyDefinitionReconciler{}
 
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=custompolicydefinitions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=custompolicydefinitions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=custompolicydefinitions/finalizers,verbs=get;list;watch;create;update;patch;delete
 
 func (r *CustomPolicyDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := r.Logger().WithValues("custompolicydefinition", req.NamespacedName)
@@ -72,12 +79,40 @@ func (r *CustomPolicyDefinitionReconciler) Reconcile(ctx context.Context, req ct
 		reqLogger.V(1).Info(string(jsonData))
 	}
 
+	// CustomPolicyDefinition has been marked for deletion
+	if customPolicyDefinitionCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(customPolicyDefinitionCR, customPolicyDefinitionFinalizer) {
+		err = r.removeCustomPolicyDefinitionFrom3scale(customPolicyDefinitionCR)
+		if err != nil {
+			r.EventRecorder().Eventf(customPolicyDefinitionCR, corev1.EventTypeWarning, "Failed to delete custompolicydefinition", "%v", err)
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(customPolicyDefinitionCR, customPolicyDefinitionFinalizer)
+		err = r.UpdateResource(customPolicyDefinitionCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
 	// Ignore deleted resource, this can happen when foregroundDeletion is enabled
 	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if customPolicyDefinitionCR.DeletionTimestamp != nil {
 		return ctrl.Result{}, nil
 	}
 
+	if !controllerutil.ContainsFinalizer(customPolicyDefinitionCR, customPolicyDefinitionFinalizer) {
+		controllerutil.AddFinalizer(customPolicyDefinitionCR, customPolicyDefinitionFinalizer)
+		err = r.UpdateResource(customPolicyDefinitionCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// No need requeue because the reconcile will trigger automatically since updating the CustomPolicyDefinition CR
+		return ctrl.Result{}, nil
+	}
+
 	statusReconciler, reconcileErr := r.reconcileSpec(customPolicyDefinitionCR, reqLogger)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
@@ -105,7 +140,7 @@ func (r *CustomPolicyDefinitionReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, reconcileErr
 	}
 
-	return ctrl.Result{}, nil
+	return controllerhelper.WithResync(ctrl.Result{}, customPolicyDefinitionCR.GetAnnotations()), nil
 }
 
 func (r *CustomPolicyDefinitionReconciler) reconcileSpec(customPolicyDefinitionCR *capabilitiesv1beta1.CustomPolicyDefinition, logger logr.Logger) (*CustomPolicyDefinitionStatusReconciler, error) {
@@ -129,8 +164,45 @@ func (r *CustomPolicyDefinitionReconciler) reconcileSpec(customPolicyDefinitionC
 	return statusReconciler, err
 }
 
+func (r *CustomPolicyDefinitionReconciler) removeCustomPolicyDefinitionFrom3scale(customPolicyDefinitionCR *capabilitiesv1beta1.CustomPolicyDefinition) error {
+	logger := r.Logger().WithValues("custompolicydefinition", client.ObjectKey{Name: customPolicyDefinitionCR.Name, Namespace: customPolicyDefinitionCR.Namespace})
+
+	if controllerhelper.IsOrphanDeletionPolicy(customPolicyDefinitionCR.GetAnnotations()) {
+		logger.Info("custom policy not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
+	// Attempt to remove custom policy only if customPolicyDefinitionCR.Status.ID is present
+	if customPolicyDefinitionCR.Status.ID == nil {
+		logger.Info("could not remove custom policy because ID is missing in status")
+		return nil
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), customPolicyDefinitionCR.Namespace, customPolicyDefinitionCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("custom policy not deleted from 3scale, provider account not found")
+			return nil
+		}
+		return err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(customPolicyDefinitionCR.GetAnnotations())
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	err = threescaleAPIClient.DeleteAPIcastPolicy(*customPolicyDefinitionCR.Status.ID)
+	if err != nil && !threescaleapi.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 func (r *CustomPolicyDefinitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.CustomPolicyDefinition{}).
+		For(&capabilitiesv1beta1.CustomPolicyDefinition{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Complete(r)
 }
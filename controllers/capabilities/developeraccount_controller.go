@@ -38,12 +38,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
 	// accountIdAnnotation matches the developeraccount.status.ID
-	accountIdAnnotation = "accountID"
+	accountIdAnnotation = controllerhelper.AccountIDAnnotation
 
 	developerAccountFinalizer = "developeraccount.capabilities.3scale.net/finalizer"
 )
@@ -181,7 +182,7 @@ func (r *DeveloperAccountReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, reconcileErr
 	}
 
-	return ctrl.Result{}, nil
+	return controllerhelper.WithResync(ctrl.Result{}, developerAccountCR.GetAnnotations()), nil
 }
 
 func (r *DeveloperAccountReconciler) reconcileMetadata(devAccountCR *capabilitiesv1beta1.DeveloperAccount) bool {
@@ -229,7 +230,19 @@ func (r *DeveloperAccountReconciler) reconcileSpec(accountCR *capabilitiesv1beta
 		return statusReconciler, err
 	}
 
-	reconciler := NewDeveloperAccountThreescaleReconciler(r.BaseReconciler, accountCR, threescaleAPIClient, providerAccount.AdminURLStr, logger)
+	plansAPIClient, err := controllerhelper.NewPlansAPIClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	accountPlanID, err := r.findAccountPlanID(accountCR, providerAccount)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewDeveloperAccountThreescaleReconciler(r.BaseReconciler, accountCR, threescaleAPIClient, plansAPIClient, accountPlanID, providerAccount.AdminURLStr, logger)
 	accountObj, err := reconciler.Reconcile()
 
 	statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, accountObj, err)
@@ -250,15 +263,60 @@ func (r *DeveloperAccountReconciler) validateSpec(resource *capabilitiesv1beta1.
 	}
 }
 
+// findAccountPlanID returns the 3scale ID of the account plan referenced by the developer account.
+// Nil when no account plan is referenced
+func (r *DeveloperAccountReconciler) findAccountPlanID(accountCR *capabilitiesv1beta1.DeveloperAccount, providerAccount *controllerhelper.ProviderAccount) (*int64, error) {
+	if accountCR.Spec.AccountPlanRef == nil {
+		return nil, nil
+	}
+
+	accountPlanRefFldPath := field.NewPath("spec").Child("accountPlanRef")
+
+	accountPlanCR := &capabilitiesv1beta1.AccountPlan{}
+	accountPlanKey := client.ObjectKey{Name: accountCR.Spec.AccountPlanRef.Name, Namespace: accountCR.Namespace}
+	err := r.Client().Get(r.Context(), accountPlanKey, accountPlanCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &helper.WaitError{
+				Err: fmt.Errorf("AccountPlan %s not found", accountCR.Spec.AccountPlanRef.Name),
+			}
+		}
+		return nil, err
+	}
+
+	if accountPlanCR.Status.ProviderAccountHost != "" && accountPlanCR.Status.ProviderAccountHost != providerAccount.AdminURLStr {
+		fieldErrors := field.ErrorList{
+			field.Invalid(accountPlanRefFldPath, accountCR.Spec.AccountPlanRef, "AccountPlan belongs to a different provider account"),
+		}
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	if !accountPlanCR.Status.IsReady() || accountPlanCR.Status.ID == nil {
+		return nil, &helper.WaitError{
+			Err: fmt.Errorf("AccountPlan %s not ready", accountCR.Spec.AccountPlanRef.Name),
+		}
+	}
+
+	return accountPlanCR.Status.ID, nil
+}
+
 func (r *DeveloperAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.DeveloperAccount{}).
+		For(&capabilitiesv1beta1.DeveloperAccount{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Complete(r)
 }
 
 func (r *DeveloperAccountReconciler) removeDeveloperAccountFrom3scale(developerAccountCR *capabilitiesv1beta1.DeveloperAccount) error {
 	logger := r.Logger().WithValues("developeraccount", client.ObjectKey{Name: developerAccountCR.Name, Namespace: developerAccountCR.Namespace})
 
+	if controllerhelper.IsOrphanDeletionPolicy(developerAccountCR.GetAnnotations()) {
+		logger.Info("developer account not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
 	// Attempt to remove developer account only if developerAccountCR.Status.ID is present
 	if developerAccountCR.Status.ID == nil {
 		logger.Info("could not remove developer account because ID is missing in status")
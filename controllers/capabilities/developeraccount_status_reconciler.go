@@ -5,6 +5,7 @@ import (
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -101,6 +102,8 @@ func (s *DeveloperAccountStatusReconciler) calculateStatus() (*capabilitiesv1bet
 	newStatus.Conditions.SetCondition(s.waitingCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.resource.Status.LastSyncTime, s.reconcileError, s.resource.GetAnnotations())
+
 	return newStatus, nil
 }
 
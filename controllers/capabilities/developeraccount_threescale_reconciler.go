@@ -22,15 +22,20 @@ type DeveloperAccountThreescaleReconciler struct {
 	*reconcilers.BaseReconciler
 	resource            *capabilitiesv1beta1.DeveloperAccount
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	plansAPIClient      *controllerhelper.PlansAPIClient
+	// accountPlanID is the 3scale ID of the referenced account plan. Nil when not referenced
+	accountPlanID       *int64
 	providerAccountHost string
 	logger              logr.Logger
 }
 
-func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, providerAccountHost string, logger logr.Logger) *DeveloperAccountThreescaleReconciler {
+func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, plansAPIClient *controllerhelper.PlansAPIClient, accountPlanID *int64, providerAccountHost string, logger logr.Logger) *DeveloperAccountThreescaleReconciler {
 	return &DeveloperAccountThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		plansAPIClient:      plansAPIClient,
+		accountPlanID:       accountPlanID,
 		providerAccountHost: providerAccountHost,
 		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
 	}
@@ -39,6 +44,21 @@ func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, reso
 func (s *DeveloperAccountThreescaleReconciler) Reconcile() (*threescaleapi.DeveloperAccount, error) {
 	s.logger.V(1).Info("START")
 
+	devAccount, err := s.reconcileDeveloperAccount()
+	if err != nil || devAccount == nil {
+		return devAccount, err
+	}
+
+	err = s.syncAccountPlan(devAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return devAccount, nil
+}
+
+func (s *DeveloperAccountThreescaleReconciler) reconcileDeveloperAccount() (*threescaleapi.DeveloperAccount, error) {
+
 	// Reconciliation is based on the ID stored in the CR's annotation or .status block
 	// This is required because none of the fields in the DeveloperAccount CR's .spec are unique
 	// For instance, there may exist several DevAccounts with the same Organization Name.
@@ -181,6 +201,10 @@ func (s *DeveloperAccountThreescaleReconciler) createDevAccount() (*threescaleap
 		params["monthly_charging_enabled"] = strconv.FormatBool(*s.resource.Spec.MonthlyChargingEnabled)
 	}
 
+	if s.accountPlanID != nil {
+		params["account_plan_id"] = strconv.FormatInt(*s.accountPlanID, 10)
+	}
+
 	devAccountObj, signupErr := s.threescaleAPIClient.Signup(params)
 
 	return devAccountObj, signupErr, devAdminUserCR
@@ -280,6 +304,31 @@ func (s *DeveloperAccountThreescaleReconciler) syncDeveloperAccount(devAccount *
 	return updatedDevAccount, nil
 }
 
+// syncAccountPlan changes the account plan of the developer account when it is not the referenced one
+func (s *DeveloperAccountThreescaleReconciler) syncAccountPlan(devAccount *threescaleapi.DeveloperAccount) error {
+	if s.accountPlanID == nil || devAccount.Element.ID == nil {
+		return nil
+	}
+
+	accountID := *devAccount.Element.ID
+	currentPlan, err := s.plansAPIClient.AccountPlanOfAccount(accountID)
+	if err != nil {
+		return fmt.Errorf("developer account [%d] account plan: %w", accountID, err)
+	}
+
+	if currentPlan.Element.ID == *s.accountPlanID {
+		return nil
+	}
+
+	s.logger.V(1).Info("change account plan", "from", currentPlan.Element.ID, "to", *s.accountPlanID)
+	err = s.plansAPIClient.ChangeAccountPlan(accountID, *s.accountPlanID)
+	if err != nil {
+		return fmt.Errorf("developer account [%d] change account plan: %w", accountID, err)
+	}
+
+	return nil
+}
+
 func (s *DeveloperAccountThreescaleReconciler) getAdminUserPassword(adminUserCR *capabilitiesv1beta1.DeveloperUser) (string, error) {
 	// Get password from secret reference
 	secret := &corev1.Secret{}
@@ -39,12 +39,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
 	// userIdAnnotation matches the developeruser.status.ID
-	userIdAnnotation = "userID"
+	userIdAnnotation = controllerhelper.UserIDAnnotation
 
 	developerUserFinalizer = "developeruser.capabilities.3scale.net/finalizer"
 )
@@ -188,7 +189,7 @@ func (r *DeveloperUserReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, reconcileErr
 	}
 
-	return ctrl.Result{}, nil
+	return controllerhelper.WithResync(ctrl.Result{}, developerUserCR.GetAnnotations()), nil
 }
 
 func (r *DeveloperUserReconciler) reconcileMetadata(devUserCR *capabilitiesv1beta1.DeveloperUser) bool {
@@ -312,6 +313,11 @@ func (r *DeveloperUserReconciler) findParentAccount(userCR *capabilitiesv1beta1.
 func (r *DeveloperUserReconciler) removeDeveloperUserFrom3scale(developerUser *capabilitiesv1beta1.DeveloperUser) error {
 	logger := r.Logger().WithValues("developerUser", client.ObjectKey{Name: developerUser.Name, Namespace: developerUser.Namespace})
 
+	if controllerhelper.IsOrphanDeletionPolicy(developerUser.GetAnnotations()) {
+		logger.Info("developer user not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
 	// Attempt to remove developerUser only if developerUser.Status.ID is present
 	if developerUser.Status.ID == nil {
 		logger.Info("could not remove developerUser because ID is missing in status")
@@ -362,6 +368,6 @@ func (r *DeveloperUserReconciler) retrieveDevelopAccountCR(developerUser *capabi
 
 func (r *DeveloperUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.DeveloperUser{}).
+		For(&capabilitiesv1beta1.DeveloperUser{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Complete(r)
 }
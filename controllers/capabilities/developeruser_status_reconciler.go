@@ -5,6 +5,7 @@ import (
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -113,6 +114,8 @@ func (s *DeveloperUserStatusReconciler) calculateStatus() (*capabilitiesv1beta1.
 	newStatus.Conditions.SetCondition(s.orphanCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.userCR.Status.LastSyncTime, s.reconcileError, s.userCR.GetAnnotations())
+
 	return newStatus, nil
 }
 
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const driftMissingValue = "<none>"
+
+// fieldDrift is a spec field whose value in 3scale differs from the desired value
+type fieldDrift struct {
+	field    string
+	existing string
+	desired  string
+}
+
+// driftList holds the differences between a 3scale object and its custom resource.
+// nil when the differences have not been computed
+type driftList []fieldDrift
+
+func (d *driftList) add(field, existing, desired string) {
+	if existing != desired {
+		*d = append(*d, fieldDrift{field: field, existing: existing, desired: desired})
+	}
+}
+
+// fields returns the sorted list of drifted spec fields
+func (d driftList) fields() []string {
+	fields := make([]string, 0, len(d))
+	for _, drift := range d {
+		fields = append(fields, drift.field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// driftedCondition reports the drifted fields when the 3scale object is observed.
+// Enforced objects have been overwritten, so they never drift.
+func (d driftList) driftedCondition(conditionType common.ConditionType, observed bool) common.Condition {
+	condition := common.Condition{
+		Type:   conditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if observed && len(d) > 0 {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "FieldsDrifted"
+		condition.Message = fmt.Sprintf("fields differ from 3scale: %s", strings.Join(d.fields(), ", "))
+	}
+
+	return condition
+}
+
+// recordOverwrites publishes one event per drifted field overwritten by the operator
+func (d driftList) recordOverwrites(b *reconcilers.BaseReconciler, object runtime.Object) {
+	for _, drift := range d {
+		b.EventRecorder().Eventf(object, corev1.EventTypeNormal, "Overwritten",
+			"%s overwritten in 3scale: %q replaced by %q", drift.field, drift.existing, drift.desired)
+	}
+}
+
+// metricsEntity is implemented by both ProductEntity and BackendAPIEntity
+type metricsEntity interface {
+	Methods() (*threescaleapi.MethodList, error)
+	Metrics() (*threescaleapi.MetricJSONList, error)
+	MetricsAndMethods() (*threescaleapi.MetricJSONList, error)
+	MappingRules() (*threescaleapi.MappingRuleJSONList, error)
+}
+
+// detectMetricsDrift adds the drifted methods, metrics and mapping rules of a product or backend
+func detectMetricsDrift(drifts *driftList, entity metricsEntity,
+	methods map[string]capabilitiesv1beta1.MethodSpec,
+	metrics map[string]capabilitiesv1beta1.MetricSpec,
+	mappingRules []capabilitiesv1beta1.MappingRuleSpec) error {
+	methodList, err := entity.Methods()
+	if err != nil {
+		return err
+	}
+
+	existingMethods := map[string]threescaleapi.MethodItem{}
+	existingMethodKeys := make([]string, 0, len(methodList.Methods))
+	for _, method := range methodList.Methods {
+		existingMethods[method.Element.SystemName] = method.Element
+		existingMethodKeys = append(existingMethodKeys, method.Element.SystemName)
+	}
+
+	for systemName, desired := range methods {
+		existing, ok := existingMethods[systemName]
+		if !ok {
+			drifts.add(fmt.Sprintf("spec.methods.%s", systemName), driftMissingValue, desired.Name)
+			continue
+		}
+		drifts.add(fmt.Sprintf("spec.methods.%s.friendlyName", systemName), existing.Name, desired.Name)
+		drifts.add(fmt.Sprintf("spec.methods.%s.description", systemName), existing.Description, desired.Description)
+	}
+
+	desiredMethodKeys := make([]string, 0, len(methods))
+	for systemName := range methods {
+		desiredMethodKeys = append(desiredMethodKeys, systemName)
+	}
+
+	for _, systemName := range helper.ArrayStringDifference(existingMethodKeys, desiredMethodKeys) {
+		drifts.add(fmt.Sprintf("spec.methods.%s", systemName), existingMethods[systemName].Name, driftMissingValue)
+	}
+
+	metricList, err := entity.Metrics()
+	if err != nil {
+		return err
+	}
+
+	existingMetrics := map[string]threescaleapi.MetricItem{}
+	existingMetricKeys := make([]string, 0, len(metricList.Metrics))
+	for _, metric := range metricList.Metrics {
+		existingMetrics[metric.Element.SystemName] = metric.Element
+		existingMetricKeys = append(existingMetricKeys, metric.Element.SystemName)
+	}
+
+	for systemName, desired := range metrics {
+		existing, ok := existingMetrics[systemName]
+		if !ok {
+			drifts.add(fmt.Sprintf("spec.metrics.%s", systemName), driftMissingValue, desired.Name)
+			continue
+		}
+		drifts.add(fmt.Sprintf("spec.metrics.%s.friendlyName", systemName), existing.Name, desired.Name)
+		drifts.add(fmt.Sprintf("spec.metrics.%s.unit", systemName), existing.Unit, desired.Unit)
+		drifts.add(fmt.Sprintf("spec.metrics.%s.description", systemName), existing.Description, desired.Description)
+	}
+
+	desiredMetricKeys := make([]string, 0, len(metrics))
+	for systemName := range metrics {
+		desiredMetricKeys = append(desiredMetricKeys, systemName)
+	}
+
+	for _, systemName := range helper.ArrayStringDifference(existingMetricKeys, desiredMetricKeys) {
+		drifts.add(fmt.Sprintf("spec.metrics.%s", systemName), existingMetrics[systemName].Name, driftMissingValue)
+	}
+
+	metricsAndMethods, err := entity.MetricsAndMethods()
+	if err != nil {
+		return err
+	}
+
+	metricSystemNames := map[int64]string{}
+	for _, metric := range metricsAndMethods.Metrics {
+		metricSystemNames[metric.Element.ID] = metric.Element.SystemName
+	}
+
+	mappingRuleList, err := entity.MappingRules()
+	if err != nil {
+		return err
+	}
+
+	existingRules := make([]threescaleapi.MappingRuleItem, 0, len(mappingRuleList.MappingRules))
+	for _, rule := range mappingRuleList.MappingRules {
+		existingRules = append(existingRules, rule.Element)
+	}
+	sort.SliceStable(existingRules, func(i, j int) bool { return existingRules[i].Position < existingRules[j].Position })
+
+	existingRuleKeys := make([]string, 0, len(existingRules))
+	for _, rule := range existingRules {
+		existingRuleKeys = append(existingRuleKeys, fmt.Sprintf("%s %s %s %d %t",
+			rule.HTTPMethod, rule.Pattern, metricSystemNames[rule.MetricID], rule.Delta, rule.Last))
+	}
+
+	desiredRuleKeys := make([]string, 0, len(mappingRules))
+	for _, rule := range mappingRules {
+		last := rule.Last != nil && *rule.Last
+		desiredRuleKeys = append(desiredRuleKeys, fmt.Sprintf("%s %s %s %d %t",
+			rule.HTTPMethod, rule.Pattern, rule.MetricMethodRef, rule.Increment, last))
+	}
+
+	drifts.add("spec.mappingRules", strings.Join(existingRuleKeys, "; "), strings.Join(desiredRuleKeys, "; "))
+
+	return nil
+}
+
+// detectDrift compares the product in 3scale with the spec.
+// Proxy, policies, OIDC configuration and plan limits and pricing rules are not compared
+func (t *ProductThreescaleReconciler) detectDrift() (driftList, error) {
+	drifts := driftList{}
+
+	drifts.add("spec.name", t.productEntity.Name(), t.resource.Spec.Name)
+	drifts.add("spec.description", t.productEntity.Description(), t.resource.Spec.Description)
+
+	if specDeploymentOption := t.resource.Spec.DeploymentOption(); specDeploymentOption != nil {
+		drifts.add("spec.deployment", t.productEntity.DeploymentOption(), *specDeploymentOption)
+	}
+
+	if specAuthMode := t.resource.Spec.AuthenticationMode(); specAuthMode != nil {
+		drifts.add("spec.deployment.authentication", t.productEntity.BackendVersion(), *specAuthMode)
+	}
+
+	err := detectMetricsDrift(&drifts, t.productEntity, t.resource.Spec.Methods, t.resource.Spec.Metrics, t.resource.Spec.MappingRules)
+	if err != nil {
+		return nil, fmt.Errorf("Error detecting product [%s] drift: %w", t.resource.Spec.SystemName, err)
+	}
+
+	backendUsages, err := t.productEntity.BackendUsages()
+	if err != nil {
+		return nil, fmt.Errorf("Error detecting product [%s] drift: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingPaths := map[string]string{}
+	for _, usage := range backendUsages {
+		if backend, ok := t.backendRemoteIndex.FindByID(usage.Element.BackendAPIID); ok {
+			existingPaths[backend.SystemName()] = usage.Element.Path
+		}
+	}
+
+	for systemName, usage := range t.resource.Spec.BackendUsages {
+		path, ok := existingPaths[systemName]
+		if !ok {
+			path = driftMissingValue
+		}
+		drifts.add(fmt.Sprintf("spec.backendUsages.%s", systemName), path, usage.Path)
+	}
+
+	for systemName, path := range existingPaths {
+		if _, ok := t.resource.Spec.BackendUsages[systemName]; !ok {
+			drifts.add(fmt.Sprintf("spec.backendUsages.%s", systemName), path, driftMissingValue)
+		}
+	}
+
+	planList, err := t.productEntity.ApplicationPlans()
+	if err != nil {
+		return nil, fmt.Errorf("Error detecting product [%s] drift: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingPlans := map[string]threescaleapi.ApplicationPlanItem{}
+	for _, plan := range planList.Plans {
+		existingPlans[plan.Element.SystemName] = plan.Element
+	}
+
+	for systemName, planSpec := range t.resource.Spec.ApplicationPlans {
+		// Retired plans are expected to disappear from 3scale
+		if planSpec.IsRetired() {
+			continue
+		}
+
+		existing, ok := existingPlans[systemName]
+		if !ok {
+			drifts.add(fmt.Sprintf("spec.applicationPlans.%s", systemName), driftMissingValue, systemName)
+			continue
+		}
+
+		if planSpec.Name != nil {
+			drifts.add(fmt.Sprintf("spec.applicationPlans.%s.name", systemName), existing.Name, *planSpec.Name)
+		}
+
+		if planSpec.Published != nil {
+			// If the state is not published then we assume it is "hidden"
+			existingIsPublished := existing.State == "published"
+			drifts.add(fmt.Sprintf("spec.applicationPlans.%s.published", systemName), strconv.FormatBool(existingIsPublished), strconv.FormatBool(*planSpec.Published))
+		}
+	}
+
+	for systemName, existing := range existingPlans {
+		if _, ok := t.resource.Spec.ApplicationPlans[systemName]; !ok {
+			drifts.add(fmt.Sprintf("spec.applicationPlans.%s", systemName), existing.Name, driftMissingValue)
+		}
+	}
+
+	return drifts, nil
+}
+
+// detectDrift compares the backend in 3scale with the spec
+func (t *BackendThreescaleReconciler) detectDrift() (driftList, error) {
+	drifts := driftList{}
+
+	drifts.add("spec.name", t.backendAPIEntity.Name(), t.backendResource.Spec.Name)
+	drifts.add("spec.description", t.backendAPIEntity.Description(), t.backendResource.Spec.Description)
+	drifts.add("spec.privateBaseURL", t.backendAPIEntity.PrivateEndpoint(), t.backendResource.Spec.PrivateBaseURL)
+
+	err := detectMetricsDrift(&drifts, t.backendAPIEntity, t.backendResource.Spec.Methods, t.backendResource.Spec.Metrics, t.backendResource.Spec.MappingRules)
+	if err != nil {
+		return nil, fmt.Errorf("Error detecting backend [%s] drift: %w", t.backendResource.Spec.SystemName, err)
+	}
+
+	return drifts, nil
+}
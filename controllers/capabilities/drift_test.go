@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type fakeMetricsEntity struct {
+	methods      *threescaleapi.MethodList
+	metrics      *threescaleapi.MetricJSONList
+	mappingRules *threescaleapi.MappingRuleJSONList
+}
+
+func (f *fakeMetricsEntity) Methods() (*threescaleapi.MethodList, error) { return f.methods, nil }
+
+func (f *fakeMetricsEntity) Metrics() (*threescaleapi.MetricJSONList, error) { return f.metrics, nil }
+
+func (f *fakeMetricsEntity) MetricsAndMethods() (*threescaleapi.MetricJSONList, error) {
+	list := &threescaleapi.MetricJSONList{Metrics: append([]threescaleapi.MetricJSON{}, f.metrics.Metrics...)}
+	for _, method := range f.methods.Methods {
+		list.Metrics = append(list.Metrics, threescaleapi.MetricJSON{
+			Element: threescaleapi.MetricItem{ID: method.Element.ID, SystemName: method.Element.SystemName},
+		})
+	}
+	return list, nil
+}
+
+func (f *fakeMetricsEntity) MappingRules() (*threescaleapi.MappingRuleJSONList, error) {
+	return f.mappingRules, nil
+}
+
+func TestDetectMetricsDrift(t *testing.T) {
+	entity := &fakeMetricsEntity{
+		methods: &threescaleapi.MethodList{Methods: []threescaleapi.Method{
+			{Element: threescaleapi.MethodItem{ID: 2, Name: "Pets", SystemName: "pets"}},
+			{Element: threescaleapi.MethodItem{ID: 3, Name: "Added in UI", SystemName: "ui"}},
+		}},
+		metrics: &threescaleapi.MetricJSONList{Metrics: []threescaleapi.MetricJSON{
+			{Element: threescaleapi.MetricItem{ID: 1, Name: "Hits", SystemName: "hits", Unit: "hit"}},
+		}},
+		mappingRules: &threescaleapi.MappingRuleJSONList{MappingRules: []threescaleapi.MappingRuleJSON{
+			{Element: threescaleapi.MappingRuleItem{HTTPMethod: "GET", Pattern: "/pets", MetricID: 2, Delta: 1, Position: 1}},
+		}},
+	}
+
+	methods := map[string]capabilitiesv1beta1.MethodSpec{
+		"pets": {Name: "Pets"},
+	}
+	metrics := map[string]capabilitiesv1beta1.MetricSpec{
+		"hits": {Name: "Hits", Unit: "request"},
+	}
+	mappingRules := []capabilitiesv1beta1.MappingRuleSpec{
+		{HTTPMethod: "GET", Pattern: "/pets", MetricMethodRef: "pets", Increment: 1},
+	}
+
+	drifts := driftList{}
+	if err := detectMetricsDrift(&drifts, entity, methods, metrics, mappingRules); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFields := []string{"spec.methods.ui", "spec.metrics.hits.unit"}
+	if !reflect.DeepEqual(drifts.fields(), expectedFields) {
+		t.Errorf("drifted fields = %v, want %v", drifts.fields(), expectedFields)
+	}
+
+	mappingRules[0].Increment = 2
+	drifts = driftList{}
+	if err := detectMetricsDrift(&drifts, entity, methods, metrics, mappingRules); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFields = []string{"spec.mappingRules", "spec.methods.ui", "spec.metrics.hits.unit"}
+	if !reflect.DeepEqual(drifts.fields(), expectedFields) {
+		t.Errorf("drifted fields = %v, want %v", drifts.fields(), expectedFields)
+	}
+}
+
+func TestDriftListDriftedCondition(t *testing.T) {
+	drifts := driftList{}
+	drifts.add("spec.name", "Edited in UI", "Product")
+	drifts.add("spec.description", "same", "same")
+
+	condition := drifts.driftedCondition(capabilitiesv1beta1.ProductDriftedConditionType, true)
+	if condition.Status != corev1.ConditionTrue {
+		t.Errorf("observed drifted condition status = %s, want True", condition.Status)
+	}
+
+	if condition.Message != "fields differ from 3scale: spec.name" {
+		t.Errorf("unexpected condition message: %s", condition.Message)
+	}
+
+	condition = drifts.driftedCondition(capabilitiesv1beta1.ProductDriftedConditionType, false)
+	if condition.Status != corev1.ConditionFalse {
+		t.Errorf("enforced drifted condition status = %s, want False", condition.Status)
+	}
+}
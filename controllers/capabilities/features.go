@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"fmt"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func (t *ProductThreescaleReconciler) syncFeatures(_ interface{}) error {
+	// Features are only managed when set in the spec.
+	// Otherwise, the features created from the 3scale admin portal are kept
+	if t.resource.Spec.Features == nil {
+		return nil
+	}
+
+	desiredKeys := make([]string, 0, len(t.resource.Spec.Features))
+	for systemName := range t.resource.Spec.Features {
+		desiredKeys = append(desiredKeys, systemName)
+	}
+
+	existingList, err := t.plansAPIClient.ListProductFeatures(t.productEntity.ID())
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingKeys := make([]string, 0, len(existingList.Features))
+	existingMap := map[string]controllerhelper.FeatureItem{}
+	for _, existing := range existingList.Features {
+		systemName := existing.Element.SystemName
+		existingKeys = append(existingKeys, systemName)
+		existingMap[systemName] = existing.Element
+	}
+
+	//
+	// Deleted existing and not desired
+	// 3scale disables deleted features on every plan
+	//
+
+	notDesiredExistingKeys := helper.ArrayStringDifference(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncFeatures", "notDesiredExistingKeys", notDesiredExistingKeys)
+	for _, systemName := range notDesiredExistingKeys {
+		// key is expected to exist
+		// notDesiredExistingKeys is a subset of the existingMap key set
+		err := t.plansAPIClient.DeleteProductFeature(t.productEntity.ID(), existingMap[systemName].ID)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] features: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
+	//
+	// Reconcile existing and desired
+	//
+
+	desiredExistingKeys := helper.ArrayStringIntersection(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncFeatures", "desiredExistingKeys", desiredExistingKeys)
+	for _, systemName := range desiredExistingKeys {
+		// key is expected to exist
+		// desiredExistingKeys is a subset of the existingMap key set
+		existing := existingMap[systemName]
+		desired := t.resource.Spec.Features[systemName]
+
+		params := threescaleapi.Params{}
+		if desired.Name != existing.Name {
+			params["name"] = desired.Name
+		}
+
+		if desired.Description != existing.Description {
+			params["description"] = desired.Description
+		}
+
+		if desired.ScopeOrDefault() != existing.Scope {
+			params["scope"] = desired.ScopeOrDefault()
+		}
+
+		if len(params) > 0 {
+			t.logger.V(1).Info("syncFeatures", "systemName", systemName, "params", params)
+			_, err := t.plansAPIClient.UpdateProductFeature(t.productEntity.ID(), existing.ID, params)
+			if err != nil {
+				return fmt.Errorf("Error sync product [%s] feature [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+			}
+		}
+	}
+
+	//
+	// Create not existing and desired
+	//
+
+	desiredNewKeys := helper.ArrayStringDifference(desiredKeys, existingKeys)
+	t.logger.V(1).Info("syncFeatures", "desiredNewKeys", desiredNewKeys)
+	for _, systemName := range desiredNewKeys {
+		// key is expected to exist
+		// desiredNewKeys is a subset of the Spec.Features map key set
+		desired := t.resource.Spec.Features[systemName]
+		params := threescaleapi.Params{
+			"system_name": systemName,
+			"name":        desired.Name,
+			"description": desired.Description,
+			"scope":       desired.ScopeOrDefault(),
+		}
+		_, err := t.plansAPIClient.CreateProductFeature(t.productEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] feature [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+	}
+
+	return nil
+}
+
+// syncPlanFeatures enables and disables the product features on the application plans and service plans.
+// Plans have been synchronized already
+func (t *ProductThreescaleReconciler) syncPlanFeatures(_ interface{}) error {
+	if t.resource.Spec.Features == nil {
+		return nil
+	}
+
+	featureList, err := t.plansAPIClient.ListProductFeatures(t.productEntity.ID())
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] plan features: %w", t.resource.Spec.SystemName, err)
+	}
+
+	featureIDs := map[string]int64{}
+	for _, feature := range featureList.Features {
+		featureIDs[feature.Element.SystemName] = feature.Element.ID
+	}
+
+	applicationPlanList, err := t.productEntity.ApplicationPlans()
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] plan features: %w", t.resource.Spec.SystemName, err)
+	}
+
+	for _, plan := range applicationPlanList.Plans {
+		planSpec, ok := t.resource.Spec.ApplicationPlans[plan.Element.SystemName]
+		if !ok {
+			continue
+		}
+
+		planID := plan.Element.ID
+		err := syncEnabledFeatures(planSpec.Features, featureIDs,
+			func() (*controllerhelper.FeatureList, error) {
+				return t.plansAPIClient.ListApplicationPlanFeatures(planID)
+			},
+			func(featureID int64) error { return t.plansAPIClient.EnableApplicationPlanFeature(planID, featureID) },
+			func(featureID int64) error { return t.plansAPIClient.DisableApplicationPlanFeature(planID, featureID) },
+		)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] application plan [%s] features: %w", t.resource.Spec.SystemName, plan.Element.SystemName, err)
+		}
+	}
+
+	// Features of service plans are only managed when service plans are managed
+	if t.resource.Spec.ServicePlans == nil {
+		return nil
+	}
+
+	servicePlanList, err := t.plansAPIClient.ListServicePlans(t.productEntity.ID())
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] plan features: %w", t.resource.Spec.SystemName, err)
+	}
+
+	for _, plan := range servicePlanList.Plans {
+		planSpec, ok := t.resource.Spec.ServicePlans[plan.Element.SystemName]
+		if !ok {
+			continue
+		}
+
+		planID := plan.Element.ID
+		err := syncEnabledFeatures(planSpec.Features, featureIDs,
+			func() (*controllerhelper.FeatureList, error) { return t.plansAPIClient.ListServicePlanFeatures(planID) },
+			func(featureID int64) error { return t.plansAPIClient.EnableServicePlanFeature(planID, featureID) },
+			func(featureID int64) error { return t.plansAPIClient.DisableServicePlanFeature(planID, featureID) },
+		)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plan [%s] features: %w", t.resource.Spec.SystemName, plan.Element.SystemName, err)
+		}
+	}
+
+	return nil
+}
+
+// syncEnabledFeatures makes the features enabled on a plan match the desired feature system names.
+// featureIDs maps the system names of the available features to their 3scale IDs
+func syncEnabledFeatures(desired []string, featureIDs map[string]int64,
+	listFn func() (*controllerhelper.FeatureList, error),
+	enableFn func(int64) error, disableFn func(int64) error) error {
+	enabledList, err := listFn()
+	if err != nil {
+		return err
+	}
+
+	enabledKeys := make([]string, 0, len(enabledList.Features))
+	enabledMap := map[string]int64{}
+	for _, feature := range enabledList.Features {
+		enabledKeys = append(enabledKeys, feature.Element.SystemName)
+		enabledMap[feature.Element.SystemName] = feature.Element.ID
+	}
+
+	for _, systemName := range helper.ArrayStringDifference(enabledKeys, desired) {
+		if err := disableFn(enabledMap[systemName]); err != nil {
+			return err
+		}
+	}
+
+	for _, systemName := range helper.ArrayStringDifference(desired, enabledKeys) {
+		featureID, ok := featureIDs[systemName]
+		if !ok {
+			return fmt.Errorf("feature [%s] not found", systemName)
+		}
+
+		if err := enableFn(featureID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
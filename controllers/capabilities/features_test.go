@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProductThreescaleReconciler_syncFeatures(t *testing.T) {
+	servicePlanScope := capabilitiesv1beta1.FeatureScopeServicePlan
+
+	tests := []struct {
+		name         string
+		features     map[string]capabilitiesv1beta1.FeatureSpec
+		wantRequests []string
+	}{
+		{
+			name:         "features not managed",
+			features:     nil,
+			wantRequests: []string{},
+		},
+		{
+			name: "create, update and delete features",
+			features: map[string]capabilitiesv1beta1.FeatureSpec{
+				"support":   {Name: "24/7 Support", Scope: &servicePlanScope},
+				"analytics": {Name: "Analytics", Description: "Usage analytics"},
+			},
+			wantRequests: []string{
+				"DELETE /admin/api/services/10/features/2.json",
+				"GET /admin/api/services/10/features.json",
+				"POST /admin/api/services/10/features.json description=Usage+analytics&name=Analytics&scope=ApplicationPlan&system_name=analytics",
+				"PUT /admin/api/services/10/features/1.json name=24%2F7+Support&scope=ServicePlan",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			requests := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					subT.Fatal(err)
+				}
+				request := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+				if len(r.PostForm) > 0 {
+					request = fmt.Sprintf("%s %s", request, r.PostForm.Encode())
+				}
+				requests = append(requests, request)
+
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, `{"features":[`+
+						`{"feature":{"id":1,"name":"Support","system_name":"support","scope":"ApplicationPlan"}},`+
+						`{"feature":{"id":2,"name":"Old","system_name":"old","scope":"ApplicationPlan"}}]}`)
+				case http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"feature":{"id":3,"name":"Analytics","system_name":"analytics","scope":"ApplicationPlan"}}`)
+				default:
+					fmt.Fprint(w, `{"feature":{"id":1,"name":"24/7 Support","system_name":"support","scope":"ServicePlan"}}`)
+				}
+			}))
+			defer server.Close()
+
+			plansAPIClient, err := controllerhelper.NewPlansAPIClient(&controllerhelper.ProviderAccount{AdminURLStr: server.URL, Token: "token"}, false)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			logger := logf.Log.WithName("features test")
+			productEntity := controllerhelper.NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: 10}}, nil, logger)
+
+			reconciler := &ProductThreescaleReconciler{
+				resource: &capabilitiesv1beta1.Product{
+					Spec: capabilitiesv1beta1.ProductSpec{SystemName: "product", Features: tt.features},
+				},
+				productEntity:  productEntity,
+				plansAPIClient: plansAPIClient,
+				logger:         logger,
+			}
+
+			if err := reconciler.syncFeatures(nil); err != nil {
+				subT.Fatal(err)
+			}
+
+			sort.Strings(requests)
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				subT.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestSyncEnabledFeatures(t *testing.T) {
+	featureIDs := map[string]int64{"support": 1, "analytics": 2, "sla": 3}
+
+	enabled := []int64{}
+	disabled := []int64{}
+	err := syncEnabledFeatures([]string{"support", "sla"}, featureIDs,
+		func() (*controllerhelper.FeatureList, error) {
+			return &controllerhelper.FeatureList{Features: []controllerhelper.Feature{
+				{Element: controllerhelper.FeatureItem{ID: 1, SystemName: "support"}},
+				{Element: controllerhelper.FeatureItem{ID: 2, SystemName: "analytics"}},
+			}}, nil
+		},
+		func(featureID int64) error { enabled = append(enabled, featureID); return nil },
+		func(featureID int64) error { disabled = append(disabled, featureID); return nil },
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(enabled, []int64{3}) {
+		t.Errorf("enabled = %v, want [3]", enabled)
+	}
+
+	if !reflect.DeepEqual(disabled, []int64{2}) {
+		t.Errorf("disabled = %v, want [2]", disabled)
+	}
+
+	err = syncEnabledFeatures([]string{"unknown"}, featureIDs,
+		func() (*controllerhelper.FeatureList, error) { return &controllerhelper.FeatureList{}, nil },
+		func(featureID int64) error { return nil },
+		func(featureID int64) error { return nil },
+	)
+	if err == nil {
+		t.Error("expected error enabling unknown feature")
+	}
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/go-logr/logr"
+)
+
+// gitCredentialsSecretIndexField indexes OpenAPI and ActiveDoc CRs by the name of the referenced git credentials secret,
+// so secret events only look up the CRs referencing the secret
+const gitCredentialsSecretIndexField = "gitCredentialsSecretRef.name"
+
+// GitCredentialsSecretToOpenAPIEventMapper is an EventHandler that maps a git credentials secret to the OpenAPI CRs
+// referencing it, so fixed or rotated credentials are used right away
+type GitCredentialsSecretToOpenAPIEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (g *GitCredentialsSecretToOpenAPIEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	openAPIList := &capabilitiesv1beta1.OpenAPIList{}
+
+	// Credentials secrets are referenced from the same namespace
+	err := g.K8sClient.List(ctx, openAPIList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{gitCredentialsSecretIndexField: obj.GetName()})
+	if err != nil {
+		g.Logger.Error(err, "failed to list OpenAPI resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range openAPIList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      openAPIList.Items[idx].GetName(),
+			Namespace: openAPIList.Items[idx].GetNamespace(),
+		}})
+	}
+
+	g.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
+	return requests
+}
+
+// GitCredentialsSecretToActiveDocEventMapper is an EventHandler that maps a git credentials secret to the ActiveDoc CRs
+// referencing it, so fixed or rotated credentials are used right away
+type GitCredentialsSecretToActiveDocEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (g *GitCredentialsSecretToActiveDocEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	activeDocList := &capabilitiesv1beta1.ActiveDocList{}
+
+	// Credentials secrets are referenced from the same namespace
+	err := g.K8sClient.List(ctx, activeDocList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{gitCredentialsSecretIndexField: obj.GetName()})
+	if err != nil {
+		g.Logger.Error(err, "failed to list ActiveDoc resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range activeDocList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      activeDocList.Items[idx].GetName(),
+			Namespace: activeDocList.Items[idx].GetNamespace(),
+		}})
+	}
+
+	g.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
+	return requests
+}
+
+// openAPIGitCredentialsSecretIndexer returns the git credentials secret name referenced by an OpenAPI CR
+func openAPIGitCredentialsSecretIndexer(obj client.Object) []string {
+	openapiCR, ok := obj.(*capabilitiesv1beta1.OpenAPI)
+	if !ok {
+		return nil
+	}
+
+	return gitCredentialsSecretNames(openapiCR.Spec.OpenAPIRef.Git)
+}
+
+// activeDocGitCredentialsSecretIndexer returns the git credentials secret name referenced by an ActiveDoc CR
+func activeDocGitCredentialsSecretIndexer(obj client.Object) []string {
+	activeDoc, ok := obj.(*capabilitiesv1beta1.ActiveDoc)
+	if !ok {
+		return nil
+	}
+
+	return gitCredentialsSecretNames(activeDoc.Spec.ActiveDocOpenAPIRef.Git)
+}
+
+func gitCredentialsSecretNames(gitSource *capabilitiesv1beta1.GitSourceSpec) []string {
+	if gitSource == nil || gitSource.CredentialsSecretRef == nil {
+		return nil
+	}
+
+	return []string{gitSource.CredentialsSecretRef.Name}
+}
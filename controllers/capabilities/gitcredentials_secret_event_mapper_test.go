@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+func TestGitCredentialsSecretEventMappers(t *testing.T) {
+	s := scheme.Scheme
+	err := capabilitiesv1beta1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitSource := func(secretName string) *capabilitiesv1beta1.GitSourceSpec {
+		return &capabilitiesv1beta1.GitSourceSpec{
+			URL:                  "https://git.example.com/apis.git",
+			Path:                 "petstore.yaml",
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: secretName},
+		}
+	}
+	openAPI := func(name, namespace, secretName string) *capabilitiesv1beta1.OpenAPI {
+		return &capabilitiesv1beta1.OpenAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: capabilitiesv1beta1.OpenAPISpec{
+				OpenAPIRef: capabilitiesv1beta1.OpenAPIRefSpec{Git: gitSource(secretName)},
+			},
+		}
+	}
+	activeDoc := func(name, namespace, secretName string) *capabilitiesv1beta1.ActiveDoc {
+		return &capabilitiesv1beta1.ActiveDoc{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: capabilitiesv1beta1.ActiveDocSpec{
+				ActiveDocOpenAPIRef: capabilitiesv1beta1.ActiveDocOpenAPIRefSpec{Git: gitSource(secretName)},
+			},
+		}
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(s).
+		WithIndex(&capabilitiesv1beta1.OpenAPI{}, gitCredentialsSecretIndexField, openAPIGitCredentialsSecretIndexer).
+		WithIndex(&capabilitiesv1beta1.ActiveDoc{}, gitCredentialsSecretIndexField, activeDocGitCredentialsSecretIndexer).
+		WithObjects(
+			openAPI("referencing", "test", "git-credentials"),
+			openAPI("other", "test", "other-credentials"),
+			openAPI("other-namespace", "other", "git-credentials"),
+			&capabilitiesv1beta1.OpenAPI{ObjectMeta: metav1.ObjectMeta{Name: "secret-source", Namespace: "test"}},
+			activeDoc("referencing", "test", "git-credentials"),
+			activeDoc("other", "test", "other-credentials"),
+		).Build()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "git-credentials", Namespace: "test"}}
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "referencing", Namespace: "test"}}}
+
+	openAPIMapper := &GitCredentialsSecretToOpenAPIEventMapper{
+		Context:   context.TODO(),
+		K8sClient: k8sClient,
+		Logger:    logf.Log.WithName("test"),
+	}
+	got := openAPIMapper.Map(context.TODO(), secret)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GitCredentialsSecretToOpenAPIEventMapper.Map() = %v, want %v", got, want)
+	}
+
+	activeDocMapper := &GitCredentialsSecretToActiveDocEventMapper{
+		Context:   context.TODO(),
+		K8sClient: k8sClient,
+		Logger:    logf.Log.WithName("test"),
+	}
+	got = activeDocMapper.Map(context.TODO(), secret)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GitCredentialsSecretToActiveDocEventMapper.Map() = %v, want %v", got, want)
+	}
+}
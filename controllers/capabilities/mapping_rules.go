@@ -3,9 +3,9 @@ package controllers
 import (
 	"errors"
 	"fmt"
-	"strconv"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
@@ -132,8 +132,6 @@ func (t *ProductThreescaleReconciler) getExistingMappingRules() (map[string]thre
 }
 
 func (t *ProductThreescaleReconciler) reconcileMappingRuleWithPosition(desired capabilitiesv1beta1.MappingRuleSpec, desiredPosition int, existing threescaleapi.MappingRuleItem) error {
-	params := threescaleapi.Params{}
-
 	//
 	// Reconcile metric or method
 	//
@@ -147,34 +145,8 @@ func (t *ProductThreescaleReconciler) reconcileMappingRuleWithPosition(desired c
 		return errors.New("product metric method ref for mapping rule not found")
 	}
 
-	if metricID != existing.MetricID {
-		params["metric_id"] = strconv.FormatInt(metricID, 10)
-	}
-
-	//
-	// Reconcile delta
-	//
-	if desired.Increment != existing.Delta {
-		params["delta"] = strconv.Itoa(desired.Increment)
-	}
-
-	//
-	// Reconcile last
-	//
-	desiredLastAttribute := false
-	if desired.Last != nil {
-		desiredLastAttribute = *desired.Last
-	}
-
-	if desiredLastAttribute != existing.Last {
-		params["last"] = strconv.FormatBool(desiredLastAttribute)
-	}
-
-	// Reconcile Position
-	//
-	if desiredPosition != existing.Position {
-		params["position"] = strconv.FormatInt(int64(desiredPosition), 10)
-	}
+	desiredItem := controllerhelper.NewMappingRuleItem(desired, desiredPosition, metricID)
+	params := controllerhelper.MappingRuleParams(existing, desiredItem)
 
 	if len(params) > 0 {
 		err := t.productEntity.UpdateMappingRule(existing.ID, params)
@@ -197,18 +169,7 @@ func (t *ProductThreescaleReconciler) createNewMappingRuleWithPosition(desired c
 		return errors.New("product metric method ref for mapping rule not found")
 	}
 
-	params := threescaleapi.Params{
-		"pattern":     desired.Pattern,
-		"http_method": desired.HTTPMethod,
-		"metric_id":   strconv.FormatInt(metricID, 10),
-		"delta":       strconv.Itoa(desired.Increment),
-	}
-
-	if desired.Last != nil {
-		params["last"] = strconv.FormatBool(*desired.Last)
-	}
-
-	params["position"] = strconv.FormatInt(int64(desiredPosition), 10)
+	params := controllerhelper.NewMappingRuleParams(controllerhelper.NewMappingRuleItem(desired, desiredPosition, metricID))
 
 	err = t.productEntity.CreateMappingRule(params)
 	if err != nil {
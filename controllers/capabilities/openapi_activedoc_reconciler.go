@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// openAPIDocumentHashAnnotation holds the hash of the OpenAPI document the
+	// managed ActiveDoc was generated from. Any change of the document, including
+	// the ones fetched from the URL source, updates the ActiveDoc object and
+	// triggers its synchronization
+	openAPIDocumentHashAnnotation = "capabilities.3scale.net/openapi-document-hash"
+
+	// activeDocSecretKey is the key of the managed secret holding the OpenAPI
+	// document read by the managed ActiveDoc
+	activeDocSecretKey = "openapi.json"
+)
+
+type OpenAPIActiveDocReconciler struct {
+	*reconcilers.BaseReconciler
+	openapiCR       *capabilitiesv1beta1.OpenAPI
+	openapiObj      *openapi3.T
+	providerAccount *controllerhelper.ProviderAccount
+	logger          logr.Logger
+}
+
+func NewOpenAPIActiveDocReconciler(b *reconcilers.BaseReconciler,
+	openapiCR *capabilitiesv1beta1.OpenAPI,
+	openapiObj *openapi3.T,
+	providerAccount *controllerhelper.ProviderAccount,
+	logger logr.Logger,
+) *OpenAPIActiveDocReconciler {
+	return &OpenAPIActiveDocReconciler{
+		BaseReconciler:  b,
+		openapiCR:       openapiCR,
+		openapiObj:      openapiObj,
+		providerAccount: providerAccount,
+		logger:          logger,
+	}
+}
+
+func (p *OpenAPIActiveDocReconciler) Logger() logr.Logger {
+	return p.logger
+}
+
+// Reconcile creates or updates the managed ActiveDoc when enabled in the
+// OpenAPI CR, and deletes it otherwise. It returns whether the managed
+// ActiveDoc is ready. When not enabled, it is always ready
+func (p *OpenAPIActiveDocReconciler) Reconcile() (bool, error) {
+	openapiJSON, err := p.openapiObj.MarshalJSON()
+	if err != nil {
+		return false, err
+	}
+
+	desiredSecret, err := p.desiredSecret(openapiJSON)
+	if err != nil {
+		return false, err
+	}
+
+	desired, err := p.desired(openapiJSON)
+	if err != nil {
+		return false, err
+	}
+
+	if p.openapiCR.Spec.ActiveDoc == nil {
+		common.TagObjectToDelete(desiredSecret)
+		common.TagObjectToDelete(desired)
+	}
+
+	err = p.ReconcileResource(&corev1.Secret{}, desiredSecret, reconcilers.DeploymentSecretMutator(reconcilers.SecretReconcileField(activeDocSecretKey)))
+	if err != nil {
+		return false, err
+	}
+
+	if p.Logger().V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(desired, "", "  ")
+		if err != nil {
+			return false, err
+		}
+		p.Logger().V(1).Info(string(jsonData))
+	}
+
+	err = p.ReconcileResource(&capabilitiesv1beta1.ActiveDoc{}, desired, p.activeDocMutator)
+	if err != nil {
+		return false, err
+	}
+
+	if p.openapiCR.Spec.ActiveDoc == nil {
+		return true, nil
+	}
+
+	return p.checkActiveDocReady(desired)
+}
+
+// desiredSecret returns the secret holding the OpenAPI document read by the
+// managed ActiveDoc. The document is the one loaded by the OpenAPI controller,
+// which is always an OpenAPI 3.0 document without external references. Hence
+// the ActiveDoc works for any source and format supported by the OpenAPI CR
+func (p *OpenAPIActiveDocReconciler) desiredSecret(openapiJSON []byte) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.desiredSecretName(),
+			Namespace: p.openapiCR.Namespace,
+		},
+		StringData: map[string]string{
+			activeDocSecretKey: string(openapiJSON),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	err := p.SetControllerOwnerReference(p.openapiCR, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (p *OpenAPIActiveDocReconciler) desired(openapiJSON []byte) (*capabilitiesv1beta1.ActiveDoc, error) {
+	documentHash := sha256.Sum256(openapiJSON)
+
+	productSystemName := p.desiredProductSystemName()
+
+	// activedoc system name
+	systemName := helper.NonAlphanumRegexp.ReplaceAllString(strings.ToLower(productSystemName), "")
+
+	activeDoc := &capabilitiesv1beta1.ActiveDoc{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.ActiveDocKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.desiredObjName(),
+			Namespace: p.openapiCR.Namespace,
+			Annotations: map[string]string{
+				openAPIDocumentHashAnnotation:             hex.EncodeToString(documentHash[:]),
+				controllerhelper.DeletionPolicyAnnotation: controllerhelper.GetDeletionPolicyAnnotation(p.openapiCR.GetAnnotations()),
+			},
+		},
+		Spec: capabilitiesv1beta1.ActiveDocSpec{
+			ProviderAccountRef: p.openapiCR.Spec.ProviderAccountRef,
+			Name:               p.openapiObj.Info.Title,
+			SystemName:         &systemName,
+			ActiveDocOpenAPIRef: capabilitiesv1beta1.ActiveDocOpenAPIRefSpec{
+				SecretRef: &corev1.ObjectReference{
+					Name:      p.desiredSecretName(),
+					Namespace: p.openapiCR.Namespace,
+				},
+			},
+			ProductSystemName: &productSystemName,
+		},
+	}
+
+	if p.openapiObj.Info.Description != "" {
+		description := p.openapiObj.Info.Description
+		activeDoc.Spec.Description = &description
+	}
+
+	if p.openapiCR.Spec.ActiveDoc != nil {
+		activeDoc.Spec.Published = p.openapiCR.Spec.ActiveDoc.Published
+		activeDoc.Spec.SkipSwaggerValidations = p.openapiCR.Spec.ActiveDoc.SkipSwaggerValidations
+	}
+
+	err := p.SetControllerOwnerReference(p.openapiCR, activeDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return activeDoc, nil
+}
+
+func (p *OpenAPIActiveDocReconciler) activeDocMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*capabilitiesv1beta1.ActiveDoc)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.ActiveDoc", existingObj)
+	}
+	desired, ok := desiredObj.(*capabilitiesv1beta1.ActiveDoc)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *capabilitiesv1beta1.ActiveDoc", desiredObj)
+	}
+
+	// Metadata labels and annotations
+	updated := helper.EnsureObjectMeta(existing, desired)
+
+	// OwnerRefenrence
+	updatedTmp, err := p.EnsureOwnerReference(p.openapiCR, existing)
+	if err != nil {
+		return false, err
+	}
+	updated = updated || updatedTmp
+
+	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+		diff := cmp.Diff(existing.Spec, desired.Spec)
+		p.Logger().Info(fmt.Sprintf("%s spec has changed: %s", common.ObjectInfo(desired), diff))
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
+
+func (p *OpenAPIActiveDocReconciler) checkActiveDocReady(desired *capabilitiesv1beta1.ActiveDoc) (bool, error) {
+	activeDoc := &capabilitiesv1beta1.ActiveDoc{}
+	err := p.Client().Get(p.Context(), client.ObjectKeyFromObject(desired), activeDoc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Not ready until the activedoc controller synchronizes the latest document.
+	// Document changes only update the hash annotation, not the activedoc generation
+	if activeDoc.Status.OpenAPIDocumentHash != desired.Annotations[openAPIDocumentHashAnnotation] {
+		return false, nil
+	}
+
+	return activeDoc.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ActiveDocReadyConditionType), nil
+}
+
+func (p *OpenAPIActiveDocReconciler) desiredProductSystemName() string {
+	// Same as product system name
+	if p.openapiCR.Spec.ProductSystemName != nil {
+		return *p.openapiCR.Spec.ProductSystemName
+	}
+
+	return helper.SystemNameFromOpenAPITitle(p.openapiObj)
+}
+
+func (p *OpenAPIActiveDocReconciler) desiredObjName() string {
+	// Same as product obj name
+	return fmt.Sprintf("%s-%s", helper.K8sNameFromOpenAPITitle(p.openapiObj), string(p.openapiCR.UID))
+}
+
+func (p *OpenAPIActiveDocReconciler) desiredSecretName() string {
+	return fmt.Sprintf("%s-activedoc", p.desiredObjName())
+}
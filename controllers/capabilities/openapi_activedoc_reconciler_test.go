@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+func TestOpenAPIActiveDocReconciler_Reconcile(t *testing.T) {
+	openapiCR := getOpenAPICR()
+	openapiCR.UID = types.UID("4686df5a-da77-4099-904a-edc3d273aa53")
+	openapiCR.Spec.ActiveDoc = &capabilitiesv1beta1.OpenAPIActiveDocSpec{
+		Published: ptr.To(true),
+	}
+	openapiObj := getOpenAPIObj(getValidOpenAPISecret())
+	baseReconciler := getOpenAPIBaseReconciler(openapiCR)
+
+	reconciler := NewOpenAPIActiveDocReconciler(baseReconciler, openapiCR, openapiObj, nil, getOpenAPITestLogger())
+	ready, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready {
+		t.Error("expected activedoc not ready before being synchronized")
+	}
+
+	activeDoc := &capabilitiesv1beta1.ActiveDoc{}
+	activeDocKey := client.ObjectKey{Name: reconciler.desiredObjName(), Namespace: openapiCR.Namespace}
+	err = baseReconciler.Client().Get(baseReconciler.Context(), activeDocKey, activeDoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if activeDoc.Spec.Name != "Swagger Petstore" {
+		t.Errorf("expected activedoc name Swagger Petstore, got %s", activeDoc.Spec.Name)
+	}
+	if activeDoc.Spec.ProductSystemName == nil || *activeDoc.Spec.ProductSystemName != reconciler.desiredProductSystemName() {
+		t.Errorf("expected activedoc bound to product %s, got %v", reconciler.desiredProductSystemName(), activeDoc.Spec.ProductSystemName)
+	}
+	if activeDoc.Spec.ActiveDocOpenAPIRef.SecretRef == nil || activeDoc.Spec.ActiveDocOpenAPIRef.SecretRef.Name != reconciler.desiredSecretName() {
+		t.Errorf("expected activedoc reading the managed secret, got %v", activeDoc.Spec.ActiveDocOpenAPIRef)
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Name: reconciler.desiredSecretName(), Namespace: openapiCR.Namespace}
+	err = baseReconciler.Client().Get(baseReconciler.Context(), secretKey, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(secret.StringData[activeDocSecretKey], "Swagger Petstore") {
+		t.Errorf("expected OpenAPI document in the managed secret, got %v", secret.StringData)
+	}
+	if activeDoc.Spec.Published == nil || !*activeDoc.Spec.Published {
+		t.Error("expected published activedoc")
+	}
+	if activeDoc.Annotations[openAPIDocumentHashAnnotation] == "" {
+		t.Error("expected OpenAPI document hash annotation")
+	}
+	if len(activeDoc.OwnerReferences) != 1 || activeDoc.OwnerReferences[0].UID != openapiCR.UID {
+		t.Errorf("expected activedoc owned by the OpenAPI CR, got %v", activeDoc.OwnerReferences)
+	}
+
+	// Ready once the activedoc controller synchronizes the latest document
+	activeDoc.Status.Conditions.SetCondition(common.Condition{
+		Type:   capabilitiesv1beta1.ActiveDocReadyConditionType,
+		Status: corev1.ConditionTrue,
+	})
+	activeDoc.Status.OpenAPIDocumentHash = "previous-document-hash"
+	err = baseReconciler.Client().Update(baseReconciler.Context(), activeDoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready {
+		t.Error("expected activedoc not ready before the latest document is synchronized")
+	}
+
+	err = baseReconciler.Client().Get(baseReconciler.Context(), activeDocKey, activeDoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	activeDoc.Status.OpenAPIDocumentHash = activeDoc.Annotations[openAPIDocumentHashAnnotation]
+	err = baseReconciler.Client().Update(baseReconciler.Context(), activeDoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ready {
+		t.Error("expected activedoc ready once the latest document is synchronized")
+	}
+
+	// Disabling the activedoc deletes it
+	openapiCR.Spec.ActiveDoc = nil
+	ready, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ready {
+		t.Error("expected ready when activedoc is not enabled")
+	}
+	err = baseReconciler.Client().Get(baseReconciler.Context(), activeDocKey, activeDoc)
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected activedoc deleted, got %v", err)
+	}
+	err = baseReconciler.Client().Get(baseReconciler.Context(), secretKey, secret)
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected managed secret deleted, got %v", err)
+	}
+}
+
+func TestActiveDocStatusReconciler_OpenAPIDocumentHash(t *testing.T) {
+	tests := []struct {
+		name           string
+		reconcileError error
+		want           string
+	}{
+		{"synchronized document", nil, "latest-document-hash"},
+		{"failed synchronization", fmt.Errorf("test"), "previous-document-hash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			activeDoc := &capabilitiesv1beta1.ActiveDoc{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "test",
+					Annotations: map[string]string{openAPIDocumentHashAnnotation: "latest-document-hash"},
+				},
+				Status: capabilitiesv1beta1.ActiveDocStatus{
+					OpenAPIDocumentHash: "previous-document-hash",
+				},
+			}
+			s := NewActiveDocStatusReconciler(getBaseReconciler(), activeDoc, "", "", nil, tt.reconcileError)
+			newStatus, err := s.calculateStatus()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if newStatus.OpenAPIDocumentHash != tt.want {
+				subT.Errorf("OpenAPIDocumentHash = %s, want %s", newStatus.OpenAPIDocumentHash, tt.want)
+			}
+		})
+	}
+}
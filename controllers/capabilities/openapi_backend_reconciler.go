@@ -113,7 +113,8 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 			Name:      objName,
 			Namespace: p.openapiCR.Namespace,
 			Annotations: map[string]string{
-				"insecure_skip_verify": strconv.FormatBool(insecureSkipVerify),
+				"insecure_skip_verify":                    strconv.FormatBool(insecureSkipVerify),
+				controllerhelper.DeletionPolicyAnnotation: controllerhelper.GetDeletionPolicyAnnotation(p.openapiCR.GetAnnotations()),
 			},
 		},
 		Spec: capabilitiesv1beta1.BackendSpec{
@@ -125,6 +126,11 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 		},
 	}
 
+	// 3scale objects created out of the operator are only taken over when adopted explicitly
+	if controllerhelper.IsAdoptAnnotationTrue(p.openapiCR.GetAnnotations()) {
+		backend.Annotations[controllerhelper.AdoptAnnotation] = "true"
+	}
+
 	backend.SetDefaults(p.Logger())
 
 	// internal validation
@@ -46,6 +46,7 @@ const (
 	oasSecretLabelSelectorKey   = "apimanager.apps.3scale.net/watched-by"
 	oasSecretLabelSelectorValue = "openapi"
 	openAPISecretRefLabelKey    = "apimanager.apps.3scale.net/oas-source-secret-uid"
+	openAPIConfigMapRefLabelKey = "apimanager.apps.3scale.net/oas-source-configmap-uid"
 )
 
 // OpenAPIReconciler reconciles a OpenAPI object
@@ -130,7 +131,7 @@ func (r *OpenAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, reconcileErr
 	}
 
-	return reconcileStatus, nil
+	return controllerhelper.WithResync(reconcileStatus, openapiCR.GetAnnotations()), nil
 }
 
 func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -140,6 +141,17 @@ func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Logger:    r.Logger().WithName("secretToOpenAPIEventMapper"),
 	}
 
+	gitCredentialsSecretToOpenAPIEventMapper := &GitCredentialsSecretToOpenAPIEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("gitCredentialsSecretToOpenAPIEventMapper"),
+	}
+
+	err := mgr.GetFieldIndexer().IndexField(r.Context(), &capabilitiesv1beta1.OpenAPI{}, gitCredentialsSecretIndexField, openAPIGitCredentialsSecretIndexer)
+	if err != nil {
+		return err
+	}
+
 	oasSecretLabelSelectorPredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			oasSecretLabelSelectorKey: oasSecretLabelSelectorValue,
@@ -150,10 +162,13 @@ func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.OpenAPI{}).
-		Owns(&capabilitiesv1beta1.Product{}).
-		Owns(&capabilitiesv1beta1.Backend{}).
+		For(&capabilitiesv1beta1.OpenAPI{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
+		Owns(&capabilitiesv1beta1.Product{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
+		Owns(&capabilitiesv1beta1.Backend{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
+		Owns(&capabilitiesv1beta1.ActiveDoc{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(secretToOpenAPIEventMapper.Map), builder.WatchesOption(builder.WithPredicates(oasSecretLabelSelectorPredicate))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(secretToOpenAPIEventMapper.Map), builder.WatchesOption(builder.WithPredicates(oasSecretLabelSelectorPredicate))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(gitCredentialsSecretToOpenAPIEventMapper.Map)).
 		Complete(r)
 }
 
@@ -162,13 +177,13 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 
 	err := r.validateSpec(openapiCR)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", openapiCR.Status.OpenAPIVersion, openapiCR.Status.GitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), openapiCR.Namespace, openapiCR.Spec.ProviderAccountRef, logger)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", openapiCR.Status.OpenAPIVersion, openapiCR.Status.GitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
@@ -176,7 +191,7 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 	// Retrieve ownersReference of tenant CR that owns the Backend CR
 	tenantCR, err := controllerhelper.RetrieveTenantCR(providerAccount, r.Client(), r.Logger(), openapiCR.Namespace)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", openapiCR.Status.OpenAPIVersion, openapiCR.Status.GitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
@@ -184,56 +199,63 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 	if tenantCR != nil {
 		updated, err := r.EnsureOwnerReference(tenantCR, openapiCR)
 		if err != nil {
-			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", openapiCR.Status.OpenAPIVersion, openapiCR.Status.GitCommitSHA, err, false)
 			return statusReconciler, ctrl.Result{}, err
 		}
 
 		if updated {
 			err := r.Client().Update(r.Context(), openapiCR)
 			if err != nil {
-				statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+				statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", openapiCR.Status.OpenAPIVersion, openapiCR.Status.GitCommitSHA, err, false)
 				return statusReconciler, ctrl.Result{}, err
 			}
-			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+			statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiCR.Status.OpenAPIVersion, openapiCR.Status.GitCommitSHA, err, false)
 			return statusReconciler, ctrl.Result{Requeue: true}, err
 		}
 	}
 
-	openapiObj, err := r.readOpenAPI(openapiCR)
+	openapiObj, openapiVersion, gitCommitSHA, err := r.readOpenAPI(openapiCR)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	err = r.validateOpenAPIAs3scaleProduct(openapiCR, openapiObj)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	err = r.validateOIDCSettingsInCR(openapiCR, openapiObj)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	err = r.validateOASExtensions(openapiObj)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	backendReconciler := NewOpenAPIBackendReconciler(r.BaseReconciler, openapiCR, openapiObj, providerAccount, logger)
 	_, err = backendReconciler.Reconcile()
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
 	productReconciler := NewOpenAPIProductReconciler(r.BaseReconciler, openapiCR, openapiObj, providerAccount, logger)
 	_, err = productReconciler.Reconcile()
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
+		return statusReconciler, ctrl.Result{}, err
+	}
+
+	activeDocReconciler := NewOpenAPIActiveDocReconciler(r.BaseReconciler, openapiCR, openapiObj, providerAccount, logger)
+	activeDocReady, err := activeDocReconciler.Reconcile()
+	if err != nil {
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
@@ -243,15 +265,19 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 	// The product controller makes sure the backend usage's items are valid Backend CRs and are sync'ed.
 	productSynced, err := r.checkProductSynced(openapiCR)
 	if err != nil {
-		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, false)
+		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, false)
 		return statusReconciler, ctrl.Result{}, err
 	}
 
-	statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, err, productSynced)
+	// The managed activedoc, when enabled, has to be ready as well
+	productSynced = productSynced && activeDocReady
+
+	statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, providerAccount.AdminURLStr, openapiVersion, gitCommitSHA, err, productSynced)
 
-	// If the product is successfully synced AND the OpenAPI CR is using URL ref, then requeue after 5 minutes
-	// We have to requeue like this in case there were updates to the URL source because we can't watch the URL directly
-	if productSynced && openapiCR.Spec.OpenAPIRef.SecretRef == nil {
+	// If the product is successfully synced AND the OpenAPI CR is using URL or git ref, then requeue after 5 minutes
+	// We have to requeue like this in case there were updates to the URL or git source because we can't watch them directly.
+	// The git repository is only fetched again when the ref resolves to a new commit
+	if productSynced && (openapiCR.Spec.OpenAPIRef.URL != nil || openapiCR.Spec.OpenAPIRef.Git != nil) {
 		return statusReconciler, ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Minute}, err
 	}
 
@@ -293,20 +319,40 @@ func (r *OpenAPIReconciler) checkProductSynced(resource *capabilitiesv1beta1.Ope
 	return product.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ProductSyncedConditionType), nil
 }
 
-func (r *OpenAPIReconciler) readOpenAPI(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, error) {
-	// OpenAPIRef is oneOf by CRD openapiV3 validation
+// readOpenAPI returns the OpenAPI document, the detected version of the source document
+// and, for git sources, the commit SHA the document was read from
+func (r *OpenAPIReconciler) readOpenAPI(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, string, string, error) {
+	// OpenAPIRef is oneOf by spec validation
 	if resource.Spec.OpenAPIRef.SecretRef != nil {
 		// Label the OAS source secret and OpenAPI so the secret can be watched by the openapi_controller
 		err := r.labelOpenAPISecretAndCR(resource)
 		if err != nil {
-			return nil, err
+			return nil, "", "", err
+		}
+
+		openapiObj, openapiVersion, err := r.readOpenAPISecret(resource)
+		return openapiObj, openapiVersion, "", err
+	}
+
+	if resource.Spec.OpenAPIRef.ConfigMapRef != nil {
+		// Label the OAS source configmap and OpenAPI so the configmap can be watched by the openapi_controller
+		err := r.labelOpenAPIConfigMapAndCR(resource)
+		if err != nil {
+			return nil, "", "", err
 		}
 
-		return r.readOpenAPISecret(resource)
+		openapiObj, openapiVersion, err := r.readOpenAPIConfigMap(resource)
+		return openapiObj, openapiVersion, "", err
+	}
+
+	if resource.Spec.OpenAPIRef.Git != nil {
+		gitFldPath := field.NewPath("spec").Child("openapiRef", "git")
+		return readOpenAPIFromGit(r.Context(), r.Client(), resource.Namespace, resource.Spec.OpenAPIRef.Git, gitFldPath)
 	}
 
 	// Must be URL
-	return r.readOpenAPIFromURL(resource)
+	openapiObj, openapiVersion, err := r.readOpenAPIFromURL(resource)
+	return openapiObj, openapiVersion, "", err
 }
 
 func (r *OpenAPIReconciler) labelOpenAPISecretAndCR(openAPICR *capabilitiesv1beta1.OpenAPI) error {
@@ -331,26 +377,62 @@ func (r *OpenAPIReconciler) labelOpenAPISecretAndCR(openAPICR *capabilitiesv1bet
 		return err
 	}
 
-	// Add label to OAS source secret so it can be watched
-	if oasSourceSecret.ObjectMeta.Labels == nil {
-		oasSourceSecret.ObjectMeta.Labels = map[string]string{}
+	return r.labelOpenAPISourceAndCR(openAPICR, oasSourceSecret, openAPISecretRefLabelKey, openAPIConfigMapRefLabelKey)
+}
+
+func (r *OpenAPIReconciler) labelOpenAPIConfigMapAndCR(openAPICR *capabilitiesv1beta1.OpenAPI) error {
+	fieldErrors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+	openapiRefFldPath := specFldPath.Child("openapiRef")
+	configMapRefFldPath := openapiRefFldPath.Child("configMapRef")
+
+	oasSourceConfigMap := &corev1.ConfigMap{}
+	objectKey := types.NamespacedName{Name: openAPICR.Spec.OpenAPIRef.ConfigMapRef.Name, Namespace: openAPICR.Spec.OpenAPIRef.ConfigMapRef.Namespace}
+
+	// Read the OAS source configmap
+	if err := r.Client().Get(r.Context(), objectKey, oasSourceConfigMap); err != nil {
+		if errors.IsNotFound(err) {
+			fieldErrors = append(fieldErrors, field.Invalid(configMapRefFldPath, openAPICR.Spec.OpenAPIRef.ConfigMapRef, "ConfigMap not found"))
+			return &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: fieldErrors,
+			}
+		}
+		// Unexpected error
+		return err
 	}
-	oasSourceSecret.ObjectMeta.Labels[oasSecretLabelSelectorKey] = oasSecretLabelSelectorValue
-	if err := r.Client().Update(r.Context(), oasSourceSecret); err != nil {
+
+	return r.labelOpenAPISourceAndCR(openAPICR, oasSourceConfigMap, openAPIConfigMapRefLabelKey, openAPISecretRefLabelKey)
+}
+
+// labelOpenAPISourceAndCR labels the OAS source object so it can be watched,
+// and the OpenAPI CR with the source object's UID so the source object events
+// can be mapped to the OpenAPI CR. The label of the previous source object
+// kind, if any, is removed from the OpenAPI CR
+func (r *OpenAPIReconciler) labelOpenAPISourceAndCR(openAPICR *capabilitiesv1beta1.OpenAPI, oasSource client.Object, sourceRefLabelKey, staleSourceRefLabelKey string) error {
+	// Add label to OAS source object so it can be watched
+	oasSourceLabels := oasSource.GetLabels()
+	if oasSourceLabels == nil {
+		oasSourceLabels = map[string]string{}
+	}
+	oasSourceLabels[oasSecretLabelSelectorKey] = oasSecretLabelSelectorValue
+	oasSource.SetLabels(oasSourceLabels)
+	if err := r.Client().Update(r.Context(), oasSource); err != nil {
 		return err
 	}
 
 	// Re-fetch the OpenAPI CR in case it's been modified
-	objectKey = types.NamespacedName{Name: openAPICR.Name, Namespace: openAPICR.Namespace}
+	objectKey := types.NamespacedName{Name: openAPICR.Name, Namespace: openAPICR.Namespace}
 	if err := r.Client().Get(r.Context(), objectKey, openAPICR); err != nil {
 		return err
 	}
 
-	// Add label to OpenAPI CR with source secret's UID
+	// Add label to OpenAPI CR with source object's UID
 	if openAPICR.ObjectMeta.Labels == nil {
 		openAPICR.ObjectMeta.Labels = map[string]string{}
 	}
-	openAPICR.ObjectMeta.Labels[openAPISecretRefLabelKey] = string(oasSourceSecret.GetUID())
+	openAPICR.ObjectMeta.Labels[sourceRefLabelKey] = string(oasSource.GetUID())
+	delete(openAPICR.ObjectMeta.Labels, staleSourceRefLabelKey)
 	if err := r.Client().Update(r.Context(), openAPICR); err != nil {
 		return err
 	}
@@ -358,7 +440,7 @@ func (r *OpenAPIReconciler) labelOpenAPISecretAndCR(openAPICR *capabilitiesv1bet
 	return nil
 }
 
-func (r *OpenAPIReconciler) readOpenAPISecret(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, error) {
+func (r *OpenAPIReconciler) readOpenAPISecret(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, string, error) {
 	fieldErrors := field.ErrorList{}
 	specFldPath := field.NewPath("spec")
 	openapiRefFldPath := specFldPath.Child("openapiRef")
@@ -371,36 +453,91 @@ func (r *OpenAPIReconciler) readOpenAPISecret(resource *capabilitiesv1beta1.Open
 	if err := r.Client().Get(r.Context(), objectKey, openapiSecretObj); err != nil {
 		if errors.IsNotFound(err) {
 			fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, "Secret not found"))
-			return nil, &helper.SpecFieldError{
+			return nil, "", &helper.SpecFieldError{
 				ErrorType:      helper.InvalidError,
 				FieldErrorList: fieldErrors,
 			}
 		}
 
 		// unexpected error
-		return nil, err
+		return nil, "", err
 	}
 
-	if len(openapiSecretObj.Data) != 1 {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, "Secret was empty or contains too many fields. Only one is required."))
-		return nil, &helper.SpecFieldError{
-			ErrorType:      helper.InvalidError,
-			FieldErrorList: fieldErrors,
+	return r.loadOpenAPIFiles(resource, "Secret", openapiSecretObj.Data, secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef)
+}
+
+func (r *OpenAPIReconciler) readOpenAPIConfigMap(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, string, error) {
+	fieldErrors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+	openapiRefFldPath := specFldPath.Child("openapiRef")
+	configMapRefFldPath := openapiRefFldPath.Child("configMapRef")
+
+	objectKey := types.NamespacedName{Name: resource.Spec.OpenAPIRef.ConfigMapRef.Name, Namespace: resource.Spec.OpenAPIRef.ConfigMapRef.Namespace}
+	openapiConfigMapObj := &corev1.ConfigMap{}
+
+	// Read configmap
+	if err := r.Client().Get(r.Context(), objectKey, openapiConfigMapObj); err != nil {
+		if errors.IsNotFound(err) {
+			fieldErrors = append(fieldErrors, field.Invalid(configMapRefFldPath, resource.Spec.OpenAPIRef.ConfigMapRef, "ConfigMap not found"))
+			return nil, "", &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: fieldErrors,
+			}
 		}
+
+		// unexpected error
+		return nil, "", err
+	}
+
+	files := map[string][]byte{}
+	for key, value := range openapiConfigMapObj.Data {
+		files[key] = []byte(value)
 	}
+	for key, value := range openapiConfigMapObj.BinaryData {
+		files[key] = value
+	}
+
+	return r.loadOpenAPIFiles(resource, "ConfigMap", files, configMapRefFldPath, resource.Spec.OpenAPIRef.ConfigMapRef)
+}
+
+// loadOpenAPIFiles loads the OpenAPI document from the files of the OAS source
+// secret or configmap. The other files act as a virtual filesystem where the
+// relative external references of the document are resolved
+func (r *OpenAPIReconciler) loadOpenAPIFiles(resource *capabilitiesv1beta1.OpenAPI, kind string, files map[string][]byte, sourceFldPath *field.Path, sourceRef *corev1.ObjectReference) (*openapi3.T, string, error) {
+	fieldErrors := field.ErrorList{}
 
-	// Get key value
-	dataByteArray := func(secret *corev1.Secret) []byte {
-		for _, v := range secret.Data {
-			return v
+	var key string
+	if resource.Spec.OpenAPIRef.Key != nil {
+		key = *resource.Spec.OpenAPIRef.Key
+		if _, ok := files[key]; !ok {
+			fieldErrors = append(fieldErrors, field.Invalid(sourceFldPath.Root().Child("openapiRef", "key"), key, fmt.Sprintf("%s does not contain the key", kind)))
+			return nil, "", &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: fieldErrors,
+			}
+		}
+	} else {
+		if len(files) != 1 {
+			fieldErrors = append(fieldErrors, field.Invalid(sourceFldPath, sourceRef, fmt.Sprintf("%s was empty or contains too many fields. Only one is required unless the key is set.", kind)))
+			return nil, "", &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: fieldErrors,
+			}
 		}
-		return nil
-	}(openapiSecretObj)
 
-	openapiObj, err := openapi3.NewLoader().LoadFromData(dataByteArray)
+		for k := range files {
+			key = k
+		}
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = helper.ReadFromFiles(files)
+
+	openapiObj, openapiVersion, err := helper.LoadOpenAPIFromData(loader, files[key], &url.URL{Path: helper.OpenAPIFilePath(key)})
 	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, err.Error()))
-		return nil, &helper.SpecFieldError{
+		fieldErrors = append(fieldErrors, field.Invalid(sourceFldPath, sourceRef, err.Error()))
+		return nil, "", &helper.SpecFieldError{
 			ErrorType:      helper.InvalidError,
 			FieldErrorList: fieldErrors,
 		}
@@ -408,14 +545,14 @@ func (r *OpenAPIReconciler) readOpenAPISecret(resource *capabilitiesv1beta1.Open
 
 	err = openapiObj.Validate(r.Context())
 	if err != nil {
-		fieldErrors = append(fieldErrors, field.Invalid(secretRefFldPath, resource.Spec.OpenAPIRef.SecretRef, err.Error()))
-		return nil, &helper.SpecFieldError{
+		fieldErrors = append(fieldErrors, field.Invalid(sourceFldPath, sourceRef, err.Error()))
+		return nil, "", &helper.SpecFieldError{
 			ErrorType:      helper.InvalidError,
 			FieldErrorList: fieldErrors,
 		}
 	}
 
-	return openapiObj, nil
+	return openapiObj, openapiVersion, nil
 }
 
 func (r *OpenAPIReconciler) validateOpenAPIAs3scaleProduct(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T) error {
@@ -503,7 +640,7 @@ func (r *OpenAPIReconciler) validateOASExtensions(openapiObj *openapi3.T) error
 	}
 }
 
-func (r *OpenAPIReconciler) readOpenAPIFromURL(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, error) {
+func (r *OpenAPIReconciler) readOpenAPIFromURL(resource *capabilitiesv1beta1.OpenAPI) (*openapi3.T, string, error) {
 	fieldErrors := field.ErrorList{}
 	specFldPath := field.NewPath("spec")
 	openapiRefFldPath := specFldPath.Child("openapiRef")
@@ -512,7 +649,7 @@ func (r *OpenAPIReconciler) readOpenAPIFromURL(resource *capabilitiesv1beta1.Ope
 	openAPIURL, err := url.Parse(*resource.Spec.OpenAPIRef.URL)
 	if err != nil {
 		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
+		return nil, "", &helper.SpecFieldError{
 			ErrorType:      helper.InvalidError,
 			FieldErrorList: fieldErrors,
 		}
@@ -522,10 +659,24 @@ func (r *OpenAPIReconciler) readOpenAPIFromURL(resource *capabilitiesv1beta1.Ope
 	// The openapi3 library will otherwise cache the previous version of the OAS source by default
 	openAPIURL.RawQuery = fmt.Sprintf("t=%d", time.Now().Unix())
 
-	openapiObj, err := openapi3.NewLoader().LoadFromURI(openAPIURL)
+	// Relative external references are resolved against the URL.
+	// Only references to the host of the document are read
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = helper.ReadFromHTTPHost(openAPIURL.Host)
+	data, err := loader.ReadFromURIFunc(loader, openAPIURL)
+	if err != nil {
+		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
+		return nil, "", &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	openapiObj, openapiVersion, err := helper.LoadOpenAPIFromData(loader, data, openAPIURL)
 	if err != nil {
 		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
+		return nil, "", &helper.SpecFieldError{
 			ErrorType:      helper.InvalidError,
 			FieldErrorList: fieldErrors,
 		}
@@ -534,13 +685,13 @@ func (r *OpenAPIReconciler) readOpenAPIFromURL(resource *capabilitiesv1beta1.Ope
 	err = openapiObj.Validate(r.Context())
 	if err != nil {
 		fieldErrors = append(fieldErrors, field.Invalid(urlRefFldPath, resource.Spec.OpenAPIRef.URL, err.Error()))
-		return nil, &helper.SpecFieldError{
+		return nil, "", &helper.SpecFieldError{
 			ErrorType:      helper.InvalidError,
 			FieldErrorList: fieldErrors,
 		}
 	}
 
-	return openapiObj, nil
+	return openapiObj, openapiVersion, nil
 }
 
 func (r *OpenAPIReconciler) validateOIDCSettingsInCR(openapiCR *capabilitiesv1beta1.OpenAPI, openapiObj *openapi3.T) error {
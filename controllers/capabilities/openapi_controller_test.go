@@ -2,8 +2,14 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
@@ -11,6 +17,9 @@ import (
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +29,7 @@ import (
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -114,6 +124,228 @@ func TestOpenAPIReconciler_validateOASExtensions(t *testing.T) {
 	}
 }
 
+func TestOpenAPIReconciler_readOpenAPIConfigMap(t *testing.T) {
+	openapiCR := getOpenAPICR()
+	openapiCR.Spec.OpenAPIRef = capabilitiesv1beta1.OpenAPIRefSpec{
+		ConfigMapRef: &corev1.ObjectReference{
+			Name:      "testOpenAPIConfigMap",
+			Namespace: "testNamespace",
+		},
+		Key: ptr.To("openapi.yaml"),
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testOpenAPIConfigMap",
+			Namespace: "testNamespace",
+			UID:       "6f6c2b4e-8f0a-4a4e-9d7b-000000000000",
+		},
+		Data: map[string]string{
+			"openapi.yaml": `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+servers:
+  - url: http://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: A list of pets
+          content:
+            application/json:
+              schema:
+                $ref: ./schemas/pets.yaml
+`,
+			"schemas__pets.yaml": `
+type: array
+items:
+  $ref: ./pet.yaml
+`,
+			"schemas__pet.yaml": `
+type: object
+properties:
+  name:
+    type: string
+`,
+		},
+	}
+
+	openAPIReconciler := OpenAPIReconciler{
+		BaseReconciler: getOpenAPIBaseReconciler(configMap, openapiCR),
+	}
+
+	openapiObj, openapiVersion, _, err := openAPIReconciler.readOpenAPI(openapiCR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if openapiVersion != "3.0.0" {
+		t.Errorf("expected version 3.0.0 got %s", openapiVersion)
+	}
+
+	// External references are internalized
+	schemaRef := openapiObj.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema
+	if schemaRef.Ref != "#/components/schemas/pets" {
+		t.Errorf("expected internalized reference, got %s", schemaRef.Ref)
+	}
+	if schemaRef.Value.Items.Value.Properties["name"] == nil {
+		t.Error("expected referenced pet schema resolved")
+	}
+
+	// OpenAPI CR labelled to be reconciled on configmap events
+	if openapiCR.Labels[openAPIConfigMapRefLabelKey] != string(configMap.GetUID()) {
+		t.Errorf("expected configmap UID label, got %v", openapiCR.Labels)
+	}
+
+	// Missing referenced file
+	err = openAPIReconciler.Client().Get(context.TODO(), client.ObjectKeyFromObject(configMap), configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(configMap.Data, "schemas__pet.yaml")
+	err = openAPIReconciler.Client().Update(context.TODO(), configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, err = openAPIReconciler.readOpenAPI(openapiCR)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("expected invalid spec error for missing referenced file, got %v", err)
+	}
+}
+
+func TestOpenAPIReconciler_readOpenAPIGit(t *testing.T) {
+	// Local bare repository fed from a work tree
+	workDir := t.TempDir()
+	workRepo, err := git.PlainInit(workDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bareDir := t.TempDir()
+	if _, err := git.PlainInit(bareDir, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := workRepo.CreateRemote(&gitconfig.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{bareDir}}); err != nil {
+		t.Fatal(err)
+	}
+
+	openapiData := getValidOpenAPISecret().Data["oas"]
+	if err := os.MkdirAll(filepath.Join(workDir, "specs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "specs", "petstore.yaml"), openapiData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := workRepo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Add("specs/petstore.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	commit, err := worktree.Commit("petstore", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := workRepo.Push(&git.PushOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	openapiCR := getOpenAPICR()
+	openapiCR.Spec.OpenAPIRef = capabilitiesv1beta1.OpenAPIRefSpec{
+		Git: &capabilitiesv1beta1.GitSourceSpec{
+			URL:  "file://" + bareDir,
+			Path: "specs/petstore.yaml",
+		},
+	}
+
+	openAPIReconciler := OpenAPIReconciler{
+		BaseReconciler: getOpenAPIBaseReconciler(openapiCR),
+	}
+
+	openapiObj, _, gitCommitSHA, err := openAPIReconciler.readOpenAPI(openapiCR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gitCommitSHA != commit.String() {
+		t.Errorf("expected commit %s got %s", commit, gitCommitSHA)
+	}
+	if openapiObj.Info.Title != "Swagger Petstore" {
+		t.Errorf("expected Swagger Petstore document, got %s", openapiObj.Info.Title)
+	}
+
+	// Missing credentials secret
+	openapiCR.Spec.OpenAPIRef.Git.CredentialsSecretRef = &corev1.LocalObjectReference{Name: "unknown"}
+	_, _, _, err = openAPIReconciler.readOpenAPI(openapiCR)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("expected invalid spec error for missing credentials secret, got %v", err)
+	}
+}
+
+func TestOpenAPIReconciler_readOpenAPIURL(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret.yaml")
+	if err := os.WriteFile(secretFile, []byte("type: object\ndescription: operator file\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	openapiTemplate := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: A list of pets
+          content:
+            application/json:
+              schema:
+                $ref: %s
+`
+	files := map[string]string{
+		"/openapi.yaml":      fmt.Sprintf(openapiTemplate, "./schemas/pet.yaml"),
+		"/openapi-file.yaml": fmt.Sprintf(openapiTemplate, "file://"+secretFile),
+		"/schemas/pet.yaml":  "type: object\nproperties:\n  name:\n    type: string\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(data))
+	}))
+	defer server.Close()
+
+	openapiCR := getOpenAPICR()
+	openAPIReconciler := OpenAPIReconciler{
+		BaseReconciler: getOpenAPIBaseReconciler(openapiCR),
+	}
+
+	openapiCR.Spec.OpenAPIRef = capabilitiesv1beta1.OpenAPIRefSpec{URL: ptr.To(server.URL + "/openapi.yaml")}
+	openapiObj, _, _, err := openAPIReconciler.readOpenAPI(openapiCR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaRef := openapiObj.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema
+	if schemaRef.Value.Properties["name"] == nil {
+		t.Error("expected relative reference resolved against the URL")
+	}
+
+	// References to the filesystem of the operator are rejected
+	openapiCR.Spec.OpenAPIRef = capabilitiesv1beta1.OpenAPIRefSpec{URL: ptr.To(server.URL + "/openapi-file.yaml")}
+	_, _, _, err = openAPIReconciler.readOpenAPI(openapiCR)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("expected invalid spec error for file reference, got %v", err)
+	}
+}
+
 func getOpenAPIBaseReconciler(objects ...runtime.Object) (baseReconciler *reconcilers.BaseReconciler) {
 	// Register operator types with the runtime scheme.
 	s := scheme.Scheme
@@ -174,7 +406,7 @@ func getOpenAPIObj(openAPISecret *corev1.Secret) *openapi3.T {
 		BaseReconciler: getOpenAPIBaseReconciler(openAPISecret, getOpenAPICR()),
 	}
 
-	openapiObj, err := openAPIReconciler.readOpenAPI(getOpenAPICR())
+	openapiObj, _, _, err := openAPIReconciler.readOpenAPI(getOpenAPICR())
 	if err != nil {
 		panic(err)
 	}
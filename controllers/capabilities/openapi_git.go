@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/getkin/kin-openapi/openapi3"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	gitCredentialsUsernameKey = "username"
+	gitCredentialsPasswordKey = "password"
+)
+
+// readOpenAPIFromGit loads the OpenAPI document from the git source. It returns the
+// loaded document, the detected version of the source document and the commit SHA.
+// The repository is only fetched when the ref resolves to a new commit.
+// Returns an invalid spec error when the source cannot be loaded from the repository
+func readOpenAPIFromGit(ctx context.Context, k8sclient client.Client, namespace string, gitSource *capabilitiesv1beta1.GitSourceSpec, gitFldPath *field.Path) (*openapi3.T, string, string, error) {
+	fieldErrors := field.ErrorList{}
+
+	source := helper.GitOpenAPISource{
+		URL:  gitSource.URL,
+		Path: gitSource.Path,
+	}
+	if gitSource.Ref != nil {
+		source.Ref = *gitSource.Ref
+	}
+
+	if gitSource.CredentialsSecretRef != nil {
+		credentialsFldPath := gitFldPath.Child("credentialsSecretRef")
+		objectKey := types.NamespacedName{Name: gitSource.CredentialsSecretRef.Name, Namespace: namespace}
+		credentialsSecret := &corev1.Secret{}
+		if err := k8sclient.Get(ctx, objectKey, credentialsSecret); err != nil {
+			if errors.IsNotFound(err) {
+				fieldErrors = append(fieldErrors, field.Invalid(credentialsFldPath, gitSource.CredentialsSecretRef, "Secret not found"))
+				return nil, "", "", &helper.SpecFieldError{
+					ErrorType:      helper.InvalidError,
+					FieldErrorList: fieldErrors,
+				}
+			}
+
+			// unexpected error
+			return nil, "", "", err
+		}
+
+		source.Auth = &githttp.BasicAuth{
+			Username: string(credentialsSecret.Data[gitCredentialsUsernameKey]),
+			Password: string(credentialsSecret.Data[gitCredentialsPasswordKey]),
+		}
+	}
+
+	openapiObj, openapiVersion, commit, err := helper.DefaultGitOpenAPILoader.Load(ctx, source)
+	if err != nil {
+		// Failures reaching the repository are retried
+		if !helper.IsGitSourceError(err) {
+			return nil, "", "", err
+		}
+
+		fieldErrors = append(fieldErrors, field.Invalid(gitFldPath, gitSource, err.Error()))
+		return nil, "", "", &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	err = openapiObj.Validate(ctx)
+	if err != nil {
+		fieldErrors = append(fieldErrors, field.Invalid(gitFldPath, gitSource, err.Error()))
+		return nil, "", "", &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	return openapiObj, openapiVersion, commit, nil
+}
@@ -111,7 +111,8 @@ func (p *OpenAPIProductReconciler) desired() (*capabilitiesv1beta1.Product, erro
 			Name:      objName,
 			Namespace: p.openapiCR.Namespace,
 			Annotations: map[string]string{
-				"insecure_skip_verify": strconv.FormatBool(insecureSkipVerify),
+				"insecure_skip_verify":                    strconv.FormatBool(insecureSkipVerify),
+				controllerhelper.DeletionPolicyAnnotation: controllerhelper.GetDeletionPolicyAnnotation(p.openapiCR.GetAnnotations()),
 			},
 		},
 		Spec: capabilitiesv1beta1.ProductSpec{
@@ -122,6 +123,11 @@ func (p *OpenAPIProductReconciler) desired() (*capabilitiesv1beta1.Product, erro
 		},
 	}
 
+	// 3scale objects created out of the operator are only taken over when adopted explicitly
+	if controllerhelper.IsAdoptAnnotationTrue(p.openapiCR.GetAnnotations()) {
+		product.Annotations[controllerhelper.AdoptAnnotation] = "true"
+	}
+
 	// Deployment
 	product.Spec.Deployment = p.desiredDeployment()
 
@@ -5,6 +5,7 @@ import (
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -19,16 +20,20 @@ type OpenAPIStatusReconciler struct {
 	*reconcilers.BaseReconciler
 	resource            *capabilitiesv1beta1.OpenAPI
 	providerAccountHost string
+	openapiVersion      string
+	gitCommitSHA        string
 	reconcileError      error
 	reconcileReady      bool
 	logger              logr.Logger
 }
 
-func NewOpenAPIStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.OpenAPI, providerAccountHost string, reconcileError error, reconcileReady bool) *OpenAPIStatusReconciler {
+func NewOpenAPIStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.OpenAPI, providerAccountHost, openapiVersion, gitCommitSHA string, reconcileError error, reconcileReady bool) *OpenAPIStatusReconciler {
 	return &OpenAPIStatusReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		providerAccountHost: providerAccountHost,
+		openapiVersion:      openapiVersion,
+		gitCommitSHA:        gitCommitSHA,
 		reconcileError:      reconcileError,
 		reconcileReady:      reconcileReady,
 		logger:              b.Logger().WithValues("Status Reconciler", resource.Name),
@@ -79,6 +84,9 @@ func (s *OpenAPIStatusReconciler) calculateStatus() (*capabilitiesv1beta1.OpenAP
 
 	newStatus.ProviderAccountHost = s.providerAccountHost
 
+	newStatus.OpenAPIVersion = s.openapiVersion
+	newStatus.GitCommitSHA = s.gitCommitSHA
+
 	productResourceName, err := s.getManagedProduct()
 	if err != nil {
 		return nil, err
@@ -91,6 +99,12 @@ func (s *OpenAPIStatusReconciler) calculateStatus() (*capabilitiesv1beta1.OpenAP
 	}
 	newStatus.BackendResourceNames = backendResourceNames
 
+	activeDocResourceName, err := s.getManagedActiveDoc()
+	if err != nil {
+		return nil, err
+	}
+	newStatus.ActiveDocResourceName = activeDocResourceName
+
 	newStatus.ObservedGeneration = s.resource.Status.ObservedGeneration
 
 	newStatus.Conditions = s.resource.Status.Conditions.Copy()
@@ -98,6 +112,12 @@ func (s *OpenAPIStatusReconciler) calculateStatus() (*capabilitiesv1beta1.OpenAP
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
 
+	// Synchronized when all the managed custom resources are ready
+	newStatus.LastSyncTime = s.resource.Status.LastSyncTime
+	if s.reconcileReady {
+		newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.resource.Status.LastSyncTime, s.reconcileError, s.resource.GetAnnotations())
+	}
+
 	return newStatus, nil
 }
 
@@ -189,3 +209,26 @@ func (s *OpenAPIStatusReconciler) getManagedBackends() ([]corev1.LocalObjectRefe
 
 	return managedBackends, nil
 }
+
+func (s *OpenAPIStatusReconciler) getManagedActiveDoc() (*corev1.LocalObjectReference, error) {
+	listOps := []client.ListOption{
+		client.InNamespace(s.resource.Namespace),
+	}
+	activeDocList := &capabilitiesv1beta1.ActiveDocList{}
+	err := s.Client().List(s.Context(), activeDocList, listOps...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list activedoc: %w", err)
+	}
+
+	for _, activeDoc := range activeDocList.Items {
+		for _, ownerRef := range activeDoc.GetOwnerReferences() {
+			if ownerRef.UID == s.resource.UID {
+				return &corev1.LocalObjectReference{
+					Name: activeDoc.Name,
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
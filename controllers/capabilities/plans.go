@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"strconv"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// planSpec holds the desired attributes shared by account plans and service plans
+type planSpec struct {
+	Name             *string
+	ApprovalRequired *bool
+	TrialPeriod      *int
+	SetupFee         *string
+	CostMonth        *string
+	Published        bool
+}
+
+// planUpdateParams returns the params needed to update the existing plan to the desired state.
+// Empty when the existing plan is in sync
+func planUpdateParams(desired planSpec, existing controllerhelper.PlanItem) threescaleapi.Params {
+	params := threescaleapi.Params{}
+
+	if desired.Name != nil {
+		if existing.Name != *desired.Name {
+			params["name"] = *desired.Name
+		}
+	}
+
+	if desired.ApprovalRequired != nil {
+		if existing.ApprovalRequired != *desired.ApprovalRequired {
+			params["approval_required"] = strconv.FormatBool(*desired.ApprovalRequired)
+		}
+	}
+
+	if desired.TrialPeriod != nil {
+		if existing.TrialPeriodDays != *desired.TrialPeriod {
+			params["trial_period_days"] = strconv.Itoa(*desired.TrialPeriod)
+		}
+	}
+
+	if desired.SetupFee != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*desired.SetupFee, 64)
+		if existing.SetupFee != desiredValue {
+			params["setup_fee"] = *desired.SetupFee
+		}
+	}
+
+	if desired.CostMonth != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*desired.CostMonth, 64)
+		if existing.CostPerMonth != desiredValue {
+			params["cost_per_month"] = *desired.CostMonth
+		}
+	}
+
+	existingIsPublished := existing.State == "published" // If the state is not published then we assume it is "hidden"
+	if existingIsPublished != desired.Published {
+		stateEventValue := "hide"
+		if desired.Published {
+			stateEventValue = "publish"
+		}
+		params["state_event"] = stateEventValue
+	}
+
+	return params
+}
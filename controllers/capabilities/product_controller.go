@@ -27,8 +27,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
@@ -51,6 +53,9 @@ var _ reconcile.Reconciler = &ProductReconciler{}
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=products,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=products/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=products/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=policychains,verbs=get;list;watch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=applicationplantemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 
 func (r *ProductReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = context.Background()
@@ -170,25 +175,46 @@ func (r *ProductReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{}, nil
 		}
 
+		if helper.IsConflictError(reconcileErr) {
+			// On Conflict error, no need to retry until the product is adopted
+			reqLogger.Info("ERROR", "conflict error", reconcileErr)
+			r.EventRecorder().Eventf(product, corev1.EventTypeWarning, "Conflict", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
 		if helper.IsOrphanSpecError(reconcileErr) {
 			// On Orphan spec error, retry
 			reqLogger.Info("ERROR", "spec orphan error", reconcileErr)
 			return ctrl.Result{Requeue: true}, nil
 		}
 
+		if helper.IsWaitError(reconcileErr) {
+			// On Wait error, like applications pending migration, retry
+			reqLogger.Info("ERROR", "wait error", reconcileErr)
+			return ctrl.Result{Requeue: true}, nil
+		}
+
 		reqLogger.Error(reconcileErr, "Failed to reconcile")
 		r.EventRecorder().Eventf(product, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
 		return ctrl.Result{}, reconcileErr
 	}
 
 	reqLogger.Info("END", "error", reconcileErr)
-	return ctrl.Result{}, reconcileErr
+	return controllerhelper.WithResync(ctrl.Result{}, product.GetAnnotations()), nil
 }
 
 func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Product) (*ProductStatusReconciler, error) {
 	logger := r.Logger().WithValues("product", productResource.Name)
 
-	err := r.validateSpec(productResource)
+	// Template application plans are validated, synchronized and compared as any other product application plan.
+	// The status is reported on the custom resource, the expanded product is a copy
+	expandedProduct, err := controllerhelper.ExpandApplicationPlanTemplates(productResource, controllerhelper.NewApplicationPlanTemplateGetter(r.Context(), r.Client(), productResource.Namespace))
+	if err != nil {
+		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, "", err)
+		return statusReconciler, err
+	}
+
+	err = r.validateSpec(expandedProduct)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, "", err)
 		return statusReconciler, err
@@ -200,7 +226,7 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
-	err = r.checkExternalRefs(productResource, providerAccount)
+	err = r.checkExternalRefs(expandedProduct, providerAccount)
 	logger.Info("checkExternalRefs", "err", err)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
@@ -214,15 +240,25 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
+	plansAPIClient, err := controllerhelper.NewPlansAPIClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
+		return statusReconciler, err
+	}
+
 	backendRemoteIndex, err := controllerhelper.NewBackendAPIRemoteIndex(threescaleAPIClient, logger)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
 		return statusReconciler, err
 	}
 
-	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, backendRemoteIndex)
+	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, expandedProduct, threescaleAPIClient, plansAPIClient, backendRemoteIndex)
 	productEntity, err := reconciler.Reconcile()
 	statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, productEntity, providerAccount.AdminURLStr, err)
+	statusReconciler.planRetirements = reconciler.planRetirements
+	statusReconciler.keptServicePlans = reconciler.keptServicePlans
+	statusReconciler.drifts = reconciler.drifts
+	statusReconciler.proxyConfigVersions = reconciler.proxyConfigVersions
 	return statusReconciler, err
 }
 
@@ -230,6 +266,12 @@ func (r *ProductReconciler) validateSpec(resource *capabilitiesv1beta1.Product)
 	errors := field.ErrorList{}
 	errors = append(errors, resource.Validate()...)
 
+	policyErrors, err := r.validatePolicyConfigurations(resource)
+	if err != nil {
+		return err
+	}
+	errors = append(errors, policyErrors...)
+
 	if len(errors) == 0 {
 		return nil
 	}
@@ -260,6 +302,12 @@ func (r *ProductReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Prod
 	pricingRulesBackendMetricRefErrors := checkAppPricingRulesExternalRefs(resource, backendUsageList)
 	errors = append(errors, pricingRulesBackendMetricRefErrors...)
 
+	policyChainRefErrors, err := r.checkPolicyChainRefs(resource)
+	if err != nil {
+		return fmt.Errorf("checking policy chain references: %w", err)
+	}
+	errors = append(errors, policyChainRefErrors...)
+
 	if len(errors) == 0 {
 		return nil
 	}
@@ -286,6 +334,36 @@ func (r *ProductReconciler) checkBackendUsages(resource *capabilitiesv1beta1.Pro
 	return errors
 }
 
+func (r *ProductReconciler) checkPolicyChainRefs(resource *capabilitiesv1beta1.Product) (field.ErrorList, error) {
+	errors := field.ErrorList{}
+
+	if len(resource.Spec.PolicyChainRefs) == 0 {
+		return errors, nil
+	}
+
+	policyChainList := &capabilitiesv1beta1.PolicyChainList{}
+	err := r.Client().List(r.Context(), policyChainList, client.InNamespace(resource.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	policyChains := map[string]bool{}
+	for idx := range policyChainList.Items {
+		policyChains[policyChainList.Items[idx].Name] = true
+	}
+
+	specFldPath := field.NewPath("spec")
+	policyChainRefsFldPath := specFldPath.Child("policyChainRefs")
+	for idx, ref := range resource.Spec.PolicyChainRefs {
+		if !policyChains[ref.Name] {
+			nameFldPath := policyChainRefsFldPath.Index(idx).Child("name")
+			errors = append(errors, field.Invalid(nameFldPath, ref.Name, "policy chain reference not found."))
+		}
+	}
+
+	return errors, nil
+}
+
 func checkAppLimitsExternalRefs(resource *capabilitiesv1beta1.Product, backendList []capabilitiesv1beta1.Backend) field.ErrorList {
 	// backendList param is expected to be valid product's backendUsageList
 	errors := field.ErrorList{}
@@ -386,6 +464,16 @@ func computeBackendUsageList(list []capabilitiesv1beta1.Backend, backendUsageMap
 func (r *ProductReconciler) removeProductFrom3scale(product *capabilitiesv1beta1.Product) error {
 	logger := r.Logger().WithValues("product", client.ObjectKey{Name: product.Name, Namespace: product.Namespace})
 
+	if controllerhelper.IsOrphanDeletionPolicy(product.GetAnnotations()) {
+		logger.Info("product not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
+	if product.IsObserved() {
+		logger.Info("product not deleted from 3scale, management policy is Observe")
+		return nil
+	}
+
 	// Attempt to remove product only if product.Status.ID is present
 	if product.Status.ID == nil {
 		logger.Info("could not remove product because ID is missing in status")
@@ -416,7 +504,28 @@ func (r *ProductReconciler) removeProductFrom3scale(product *capabilitiesv1beta1
 }
 
 func (r *ProductReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	proxyConfigPromoteToProductEventMapper := &ProxyConfigPromoteToProductEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("proxyConfigPromoteToProductEventMapper"),
+	}
+
+	policyChainToProductEventMapper := &PolicyChainToProductEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("policyChainToProductEventMapper"),
+	}
+
+	applicationPlanTemplateToProductEventMapper := &ApplicationPlanTemplateToProductEventMapper{
+		Context:   r.Context(),
+		K8sClient: r.Client(),
+		Logger:    r.Logger().WithName("applicationPlanTemplateToProductEventMapper"),
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1beta1.Product{}).
+		For(&capabilitiesv1beta1.Product{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
+		Watches(&capabilitiesv1beta1.ProxyConfigPromote{}, handler.EnqueueRequestsFromMapFunc(proxyConfigPromoteToProductEventMapper.Map)).
+		Watches(&capabilitiesv1beta1.PolicyChain{}, handler.EnqueueRequestsFromMapFunc(policyChainToProductEventMapper.Map)).
+		Watches(&capabilitiesv1beta1.ApplicationPlanTemplate{}, handler.EnqueueRequestsFromMapFunc(applicationPlanTemplateToProductEventMapper.Map)).
 		Complete(r)
 }
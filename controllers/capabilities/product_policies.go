@@ -1,15 +1,18 @@
 package controllers
 
 import (
-	"encoding/json"
 	"fmt"
 	"reflect"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (t *ProductThreescaleReconciler) syncPolicies(_ interface{}) error {
@@ -39,68 +42,125 @@ func (t *ProductThreescaleReconciler) syncPolicies(_ interface{}) error {
 }
 
 // Convert Policies from []capabilitiesv1beta1.PolicyConfig to *threescaleapi.PoliciesConfigList to be comparable
+// The product policies are combined with the referenced policy chains
 func (t *ProductThreescaleReconciler) convertResourcePolicies() (*threescaleapi.PoliciesConfigList, error) {
-	policies := &threescaleapi.PoliciesConfigList{
-		Policies: []threescaleapi.PolicyConfig{},
+	policies, err := controllerhelper.ProductPolicies(t.resource, controllerhelper.NewPolicyChainGetter(t.Context(), t.Client(), t.resource.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("Error sync product [%s] policies: %w", t.resource.Spec.SystemName, err)
 	}
 
-	for _, crdPolicy := range t.resource.Spec.Policies {
-		configuration, err := t.convertPolicyConfiguration(crdPolicy)
-		if err != nil {
-			return nil, err
-		}
+	return controllerhelper.PoliciesConfigList(policies, t.getSecret)
+}
 
-		policies.Policies = append(policies.Policies, threescaleapi.PolicyConfig{
-			Name:          crdPolicy.Name,
-			Version:       crdPolicy.Version,
-			Enabled:       crdPolicy.Enabled,
-			Configuration: configuration,
-		})
-	}
+func (t *ProductThreescaleReconciler) convertPolicyConfiguration(crdPolicy capabilitiesv1beta1.PolicyConfig) (map[string]interface{}, error) {
+	return controllerhelper.PolicyConfiguration(crdPolicy, t.getSecret)
+}
 
-	return policies, nil
+func (t *ProductThreescaleReconciler) getSecret(key types.NamespacedName) (*corev1.Secret, error) {
+	return controllerhelper.NewSecretGetter(t.Context(), t.Client(), t.resource.Namespace)(key)
 }
 
-func (t *ProductThreescaleReconciler) convertPolicyConfiguration(crdPolicy capabilitiesv1beta1.PolicyConfig) (map[string]interface{}, error) {
-	configuration := map[string]interface{}{}
-
-	// If plain value is not the default - use plain value as precedence over secret
-	if string(crdPolicy.Configuration.Raw) != capabilitiesv1beta1.ProductPolicyConfigurationDefault {
-		// CRD validation ensures no error happens
-		// "configuration` type is object
-		//properties:
-		//  configuration:
-		//    description: Configuration defines the policy configuration
-		//    type: object
-		//    x-kubernetes-preserve-unknown-fields: true
-		_ = json.Unmarshal(crdPolicy.Configuration.Raw, &configuration)
-
-		return configuration, nil
+// validatePolicyConfigurations validates the configuration of each policy against the policy JSON schema,
+// the CustomPolicyDefinition schema for custom policies and the embedded schema for built-in APIcast policies.
+// The policies of the referenced policy chains are validated as well.
+// Policies without known schema are not validated.
+// Configuration secrets that cannot be read are reported by the policies synchronization,
+// policy chains that cannot be read are reported by the external references check
+func (r *ProductReconciler) validatePolicyConfigurations(resource *capabilitiesv1beta1.Product) (field.ErrorList, error) {
+	specFldPath := field.NewPath("spec")
+	getSecret := controllerhelper.NewSecretGetter(r.Context(), r.Client(), resource.Namespace)
+
+	var customPolicyDefinitions *capabilitiesv1beta1.CustomPolicyDefinitionList
+
+	validatePolicies := func(policies []capabilitiesv1beta1.PolicyConfig, policiesFldPath *field.Path) (field.ErrorList, error) {
+		fieldErrors := field.ErrorList{}
+
+		for idx, policy := range policies {
+			schema, err := controllerhelper.BuiltinPolicySchema(policy.Name, policy.Version)
+			if err != nil {
+				return nil, err
+			}
+
+			if schema == nil {
+				// Custom policy definitions only read when there are custom policies
+				if customPolicyDefinitions == nil {
+					customPolicyDefinitions = &capabilitiesv1beta1.CustomPolicyDefinitionList{}
+					err := r.Client().List(r.Context(), customPolicyDefinitions, client.InNamespace(resource.Namespace))
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				schema = customPolicySchema(customPolicyDefinitions, policy.Name, policy.Version)
+			}
+
+			if schema == nil {
+				continue
+			}
+
+			configuration, err := controllerhelper.PolicyConfiguration(policy, getSecret)
+			if err != nil {
+				continue
+			}
+
+			fldPath := policiesFldPath.Index(idx).Child("configuration")
+			if string(policy.Configuration.Raw) == capabilitiesv1beta1.ProductPolicyConfigurationDefault && policy.ConfigurationRef.Name != "" {
+				fldPath = policiesFldPath.Index(idx).Child("configurationRef")
+			}
+
+			policyErrors, err := controllerhelper.ValidatePolicyConfiguration(schema, configuration, fldPath)
+			if err != nil {
+				// Not valid custom policy definition schemas do not block the product
+				r.Logger().Info("policy configuration not validated", "product", resource.Name, "policy", policy.Name, "error", err.Error())
+				continue
+			}
+			fieldErrors = append(fieldErrors, policyErrors...)
+		}
+
+		return fieldErrors, nil
 	}
 
-	// If policy is defined in secretRef
-	if crdPolicy.ConfigurationRef.Name != "" {
-		// Get configuration from secret reference
-		secret := &corev1.Secret{}
-		namespace := t.resource.Namespace
-		if crdPolicy.ConfigurationRef.Namespace != "" {
-			namespace = crdPolicy.ConfigurationRef.Namespace
-		}
+	fieldErrors, err := validatePolicies(resource.Spec.Policies, specFldPath.Child("policies"))
+	if err != nil {
+		return nil, err
+	}
 
-		if err := t.Client().Get(t.Context(), types.NamespacedName{Name: crdPolicy.ConfigurationRef.Name, Namespace: namespace}, secret); err != nil {
+	policyChainRefsFldPath := specFldPath.Child("policyChainRefs")
+	getPolicyChain := controllerhelper.NewPolicyChainGetter(r.Context(), r.Client(), resource.Namespace)
+	for idx, ref := range resource.Spec.PolicyChainRefs {
+		policyChain, err := getPolicyChain(ref.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
 			return nil, err
 		}
 
-		configurationByteArray, ok := secret.Data[capabilitiesv1beta1.ProductPolicyConfigurationPasswordSecretField]
-		if !ok {
-			return nil, fmt.Errorf("not found configuration field in secret (ns: %s, name: %s) field: %s",
-				namespace, crdPolicy.ConfigurationRef.Name, capabilitiesv1beta1.ProductPolicyConfigurationPasswordSecretField)
+		// The product policy chain already includes the apicast policy
+		if ref.ChainPosition() != capabilitiesv1beta1.PolicyChainPositionReplace && policyChain.Spec.IncludesApicastPolicy() {
+			fieldErrors = append(fieldErrors, field.Invalid(policyChainRefsFldPath.Index(idx).Child("name"), ref.Name,
+				"policy chain includes the apicast policy, only allowed with the replace position"))
 		}
 
-		if err := json.Unmarshal(configurationByteArray, &configuration); err != nil {
+		// Errors are reported on the policy chain reference, the policy chain is not reconciled
+		policyChainErrors, err := validatePolicies(policyChain.Spec.Policies, policyChainRefsFldPath.Index(idx).Child("policies"))
+		if err != nil {
 			return nil, err
 		}
+		fieldErrors = append(fieldErrors, policyChainErrors...)
 	}
 
-	return configuration, nil
+	return fieldErrors, nil
+}
+
+// customPolicySchema returns the configuration JSON schema of the custom policy definition, nil when not found
+func customPolicySchema(list *capabilitiesv1beta1.CustomPolicyDefinitionList, name, version string) []byte {
+	for idx := range list.Items {
+		definition := &list.Items[idx]
+		if definition.Spec.Name == name && definition.Spec.Version == version && len(definition.Spec.Schema.Configuration.Raw) > 0 {
+			return definition.Spec.Schema.Configuration.Raw
+		}
+	}
+
+	return nil
 }
@@ -252,3 +252,156 @@ func TestProductThreescaleReconciler_convertPolicyConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestProductReconciler_validatePolicyConfigurations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := capabilitiesv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	customPolicyDefinition := &capabilitiesv1beta1.CustomPolicyDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "throttling", Namespace: "test"},
+		Spec: capabilitiesv1beta1.CustomPolicyDefinitionSpec{
+			Name:    "throttling",
+			Version: "0.1",
+			Schema: capabilitiesv1beta1.CustomPolicySchemaSpec{
+				Configuration: runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"limit":{"type":"integer"}},"required":["limit"]}`)},
+			},
+		},
+	}
+	configSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ip-check-config", Namespace: "test"},
+		Data: map[string][]byte{
+			capabilitiesv1beta1.ProductPolicyConfigurationPasswordSecretField: []byte(`{"check_type":"allowlist","ips":["10.0.0.1"]}`),
+		},
+	}
+	policyChain := &capabilitiesv1beta1.PolicyChain{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "test"},
+		Spec: capabilitiesv1beta1.PolicyChainSpec{
+			Policies: []capabilitiesv1beta1.PolicyConfig{
+				{Name: "cors", Version: "builtin", Enabled: true, Configuration: runtime.RawExtension{Raw: []byte(`{"allow_methods":["FETCH"]}`)}},
+			},
+		},
+	}
+
+	apicastPolicyChain := &capabilitiesv1beta1.PolicyChain{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-apicast", Namespace: "test"},
+		Spec: capabilitiesv1beta1.PolicyChainSpec{
+			Policies: []capabilitiesv1beta1.PolicyConfig{
+				{Name: "apicast", Version: "builtin", Enabled: true, Configuration: runtime.RawExtension{Raw: []byte(`{}`)}},
+			},
+		},
+	}
+	replace := capabilitiesv1beta1.PolicyChainPositionReplace
+
+	policy := func(name, version, configuration string, ref string) capabilitiesv1beta1.PolicyConfig {
+		return capabilitiesv1beta1.PolicyConfig{
+			Name:             name,
+			Version:          version,
+			Enabled:          true,
+			Configuration:    runtime.RawExtension{Raw: []byte(configuration)},
+			ConfigurationRef: corev1.SecretReference{Name: ref},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policies []capabilitiesv1beta1.PolicyConfig
+		refs     []capabilitiesv1beta1.PolicyChainRefSpec
+		want     []string
+	}{
+		{
+			name: "valid policies",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("cors", "builtin", `{"allow_methods":["GET"]}`, ""),
+				policy("throttling", "0.1", `{"limit":10}`, ""),
+				policy("apicast", "builtin", `{}`, ""),
+			},
+			want: []string{},
+		},
+		{
+			name: "builtin policy not valid",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("cors", "builtin", `{"allow_methods":["FETCH"]}`, ""),
+			},
+			want: []string{`spec.policies[0].configuration.allow_methods[0]`},
+		},
+		{
+			name: "custom policy not valid",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("apicast", "builtin", `{}`, ""),
+				policy("throttling", "0.1", `{}`, ""),
+			},
+			want: []string{`spec.policies[1].configuration.limit`},
+		},
+		{
+			name: "configuration from secret not valid",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("ip_check", "builtin", capabilitiesv1beta1.ProductPolicyConfigurationDefault, "ip-check-config"),
+			},
+			want: []string{`spec.policies[0].configurationRef.check_type`},
+		},
+		{
+			name: "unknown policies and missing secrets not validated",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("throttling", "0.2", `{}`, ""),
+				policy("soap", "builtin", `{"unexpected":true}`, ""),
+				policy("cors", "builtin", capabilitiesv1beta1.ProductPolicyConfigurationDefault, "missing"),
+			},
+			want: []string{},
+		},
+		{
+			name: "policy chain policy not valid",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("apicast", "builtin", `{}`, ""),
+			},
+			refs: []capabilitiesv1beta1.PolicyChainRefSpec{{Name: "missing"}, {Name: "shared"}},
+			want: []string{`spec.policyChainRefs[1].policies[0].configuration.allow_methods[0]`},
+		},
+		{
+			name: "policy chain adding a second apicast policy",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("apicast", "builtin", `{}`, ""),
+			},
+			refs: []capabilitiesv1beta1.PolicyChainRefSpec{{Name: "with-apicast"}},
+			want: []string{`spec.policyChainRefs[0].name`},
+		},
+		{
+			name: "replacing policy chain with apicast policy",
+			policies: []capabilitiesv1beta1.PolicyConfig{
+				policy("apicast", "builtin", `{}`, ""),
+			},
+			refs: []capabilitiesv1beta1.PolicyChainRefSpec{{Name: "with-apicast", Position: &replace}},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(customPolicyDefinition, configSecret, policyChain, apicastPolicyChain).Build()
+			r := &ProductReconciler{
+				BaseReconciler: reconcilers.NewBaseReconciler(context.Background(), k8sClient, scheme, k8sClient, logr.Discard(), nil, nil),
+			}
+			product := &capabilitiesv1beta1.Product{
+				ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "test"},
+				Spec:       capabilitiesv1beta1.ProductSpec{Policies: tt.policies, PolicyChainRefs: tt.refs},
+			}
+
+			fieldErrors, err := r.validatePolicyConfigurations(product)
+			if err != nil {
+				subT.Fatalf("validatePolicyConfigurations() error = %v", err)
+			}
+
+			got := []string{}
+			for _, fieldError := range fieldErrors {
+				got = append(got, fieldError.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				subT.Errorf("validatePolicyConfigurations() field errors = %v, want %v", fieldErrors, tt.want)
+			}
+		})
+	}
+}
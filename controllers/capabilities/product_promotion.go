@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// proxyConfigVersions are the latest proxy configuration versions after the automatic promotion
+type proxyConfigVersions struct {
+	staging    int
+	production int
+}
+
+// promoteProxyConfig promotes the proxy configuration to staging and, when enabled, to production.
+// 3scale only creates a new staging version when the proxy configuration changed.
+// Production is promoted whenever it is behind the latest staging version, so a promotion
+// that did not happen before, for instance a failed one, is retried
+func (t *ProductThreescaleReconciler) promoteProxyConfig() error {
+	promotion := t.resource.Spec.Deployment.Promotion
+
+	err := t.productEntity.PromoteProxyToStaging()
+	if err != nil {
+		return err
+	}
+
+	stagingVersion, err := t.productEntity.LatestProxyConfigVersion("sandbox")
+	if err != nil {
+		return err
+	}
+
+	if stagingVersion != t.resource.Status.LatestStagingVersion {
+		t.EventRecorder().Eventf(t.resource, corev1.EventTypeNormal, "Promoted", "proxy config version %d promoted to staging", stagingVersion)
+	}
+
+	productionVersion, err := t.productEntity.LatestProxyConfigVersion("production")
+	if err != nil {
+		return err
+	}
+
+	if promotion.IsProductionEnabled() && productionVersion < stagingVersion {
+		err = t.productEntity.PromoteProxyToProduction(stagingVersion)
+		if err != nil {
+			return err
+		}
+
+		productionVersion = stagingVersion
+		t.EventRecorder().Eventf(t.resource, corev1.EventTypeNormal, "Promoted", "proxy config version %d promoted to production", productionVersion)
+	}
+
+	t.proxyConfigVersions = &proxyConfigVersions{staging: stagingVersion, production: productionVersion}
+	return nil
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProductThreescaleReconciler_promoteProxyConfig(t *testing.T) {
+	tests := []struct {
+		name              string
+		promotion         *capabilitiesv1beta1.ProductPromotionSpec
+		stagingVersion    int
+		productionVersion int
+		// statusStagingVersion is the staging version promoted by the previous reconciliation
+		statusStagingVersion int
+		wantRequests         []string
+		wantVersions         *proxyConfigVersions
+	}{
+		{
+			name:              "staging only",
+			promotion:         &capabilitiesv1beta1.ProductPromotionSpec{Staging: ptr.To(true)},
+			stagingVersion:    3,
+			productionVersion: 1,
+			wantRequests: []string{
+				"POST /admin/api/services/10/proxy/deploy.json",
+				"GET /admin/api/services/10/proxy/configs/sandbox/latest.json",
+				"GET /admin/api/services/10/proxy/configs/production/latest.json",
+			},
+			wantVersions: &proxyConfigVersions{staging: 3, production: 1},
+		},
+		{
+			name:              "production behind staging",
+			promotion:         &capabilitiesv1beta1.ProductPromotionSpec{Production: ptr.To(true)},
+			stagingVersion:    3,
+			productionVersion: 1,
+			wantRequests: []string{
+				"POST /admin/api/services/10/proxy/deploy.json",
+				"GET /admin/api/services/10/proxy/configs/sandbox/latest.json",
+				"GET /admin/api/services/10/proxy/configs/production/latest.json",
+				"POST /admin/api/services/10/proxy/configs/sandbox/3/promote.json",
+			},
+			wantVersions: &proxyConfigVersions{staging: 3, production: 3},
+		},
+		{
+			name:              "production never promoted",
+			promotion:         &capabilitiesv1beta1.ProductPromotionSpec{Production: ptr.To(true)},
+			stagingVersion:    1,
+			productionVersion: 0,
+			wantRequests: []string{
+				"POST /admin/api/services/10/proxy/deploy.json",
+				"GET /admin/api/services/10/proxy/configs/sandbox/latest.json",
+				"GET /admin/api/services/10/proxy/configs/production/latest.json",
+				"POST /admin/api/services/10/proxy/configs/sandbox/1/promote.json",
+			},
+			wantVersions: &proxyConfigVersions{staging: 1, production: 1},
+		},
+		{
+			name:              "production up to date",
+			promotion:         &capabilitiesv1beta1.ProductPromotionSpec{Production: ptr.To(true)},
+			stagingVersion:    3,
+			productionVersion: 3,
+			wantRequests: []string{
+				"POST /admin/api/services/10/proxy/deploy.json",
+				"GET /admin/api/services/10/proxy/configs/sandbox/latest.json",
+				"GET /admin/api/services/10/proxy/configs/production/latest.json",
+			},
+			wantVersions: &proxyConfigVersions{staging: 3, production: 3},
+		},
+		{
+			name:                 "production behind staging version promoted before",
+			promotion:            &capabilitiesv1beta1.ProductPromotionSpec{Production: ptr.To(true)},
+			stagingVersion:       3,
+			productionVersion:    2,
+			statusStagingVersion: 3,
+			wantRequests: []string{
+				"POST /admin/api/services/10/proxy/deploy.json",
+				"GET /admin/api/services/10/proxy/configs/sandbox/latest.json",
+				"GET /admin/api/services/10/proxy/configs/production/latest.json",
+				"POST /admin/api/services/10/proxy/configs/sandbox/3/promote.json",
+			},
+			wantVersions: &proxyConfigVersions{staging: 3, production: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			requests := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+
+				switch r.URL.Path {
+				case "/admin/api/services/10/proxy/deploy.json":
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"proxy":{"service_id":10}}`)
+				case "/admin/api/services/10/proxy/configs/sandbox/latest.json":
+					fmt.Fprintf(w, `{"proxy_config":{"version":%d,"environment":"sandbox"}}`, tt.stagingVersion)
+				case "/admin/api/services/10/proxy/configs/production/latest.json":
+					if tt.productionVersion == 0 {
+						w.WriteHeader(http.StatusNotFound)
+						fmt.Fprint(w, `{"status":"Not found"}`)
+						return
+					}
+					fmt.Fprintf(w, `{"proxy_config":{"version":%d,"environment":"production"}}`, tt.productionVersion)
+				default:
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprintf(w, `{"proxy_config":{"version":%d,"environment":"production"}}`, tt.stagingVersion)
+				}
+			}))
+			defer server.Close()
+
+			ap, err := threescaleapi.NewAdminPortalFromStr(server.URL)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			threescaleAPIClient := threescaleapi.NewThreeScale(ap, "token", server.Client())
+
+			logger := logf.Log.WithName("product promotion test")
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &ProductThreescaleReconciler{
+				BaseReconciler: reconcilers.NewBaseReconciler(context.TODO(), nil, nil, nil, logger, nil, recorder),
+				resource: &capabilitiesv1beta1.Product{
+					Spec: capabilitiesv1beta1.ProductSpec{
+						SystemName: "product",
+						Deployment: &capabilitiesv1beta1.ProductDeploymentSpec{Promotion: tt.promotion},
+					},
+					Status: capabilitiesv1beta1.ProductStatus{LatestStagingVersion: tt.statusStagingVersion},
+				},
+				productEntity:       controllerhelper.NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: 10}}, threescaleAPIClient, logger),
+				threescaleAPIClient: threescaleAPIClient,
+				logger:              logger,
+			}
+
+			err = reconciler.promoteProxyConfig()
+			if err != nil {
+				subT.Fatalf("promoteProxyConfig() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				subT.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			if !reflect.DeepEqual(reconciler.proxyConfigVersions, tt.wantVersions) {
+				subT.Errorf("proxyConfigVersions = %v, want %v", reconciler.proxyConfigVersions, tt.wantVersions)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+const (
+	// proxyConfigProductAnnotation references the product a published proxy configuration belongs to
+	proxyConfigProductAnnotation = "capabilities.3scale.net/product"
+	// proxyConfigVersionAnnotationFormat annotates the published proxy configuration version of an environment
+	proxyConfigVersionAnnotationFormat = "capabilities.3scale.net/%s-proxy-config-version"
+	// proxyConfigProductsNamespaceAnnotation lists the products, as comma separated <namespace>/<name> references,
+	// allowed to publish their proxy configuration to the annotated namespace
+	proxyConfigProductsNamespaceAnnotation = "capabilities.3scale.net/proxy-config-products"
+)
+
+// proxyConfigDataKeyRegexp matches the data keys written by the publication, <environment>.json and <environment>-<version>.json
+var proxyConfigDataKeyRegexp = regexp.MustCompile(`^(staging|production)(-[0-9]+)?\.json$`)
+
+// proxyConfigVersionAnnotationRegexp matches the version annotations written by the publication
+var proxyConfigVersionAnnotationRegexp = regexp.MustCompile(`^capabilities\.3scale\.net/(staging|production)-proxy-config-version$`)
+
+// apicastConfiguration is the APIcast configuration file format
+type apicastConfiguration struct {
+	Services []json.RawMessage `json:"services"`
+}
+
+// publishProxyConfig writes the latest proxy configuration of the published environments to a ConfigMap or Secret.
+// Each environment is written in the APIcast configuration file format to the <environment>.json key,
+// and to the <environment>-<version>.json key.
+// Returns ConflictError when the existing ConfigMap or Secret is not annotated with the product,
+// and WaitError until the namespace of the publication allows the product
+func (t *ProductThreescaleReconciler) publishProxyConfig() error {
+	publication := t.resource.ProxyConfigPublication()
+	namespace := publication.PublicationNamespace(t.resource.Namespace)
+
+	if namespace != t.resource.Namespace {
+		err := t.ensureProxyConfigNamespaceAllowed(namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	data := map[string]string{}
+	annotations := map[string]string{
+		proxyConfigProductAnnotation: proxyConfigProductAnnotationValue(t.resource),
+	}
+
+	for _, environment := range publication.PublishedEnvironments() {
+		proxyConfig, err := t.plansAPIClient.ReadLatestProxyConfig(t.productEntity.ID(), threescaleEnvironment(environment))
+		if err != nil {
+			// Nothing promoted to the environment yet
+			if controllerhelper.IsPlansAPINotFound(err) {
+				continue
+			}
+			return fmt.Errorf("product [%s] publish %s proxy config: %w", t.resource.Spec.SystemName, environment, err)
+		}
+
+		content, err := json.Marshal(apicastConfiguration{Services: []json.RawMessage{proxyConfig.Element.Content}})
+		if err != nil {
+			return fmt.Errorf("product [%s] publish %s proxy config: %w", t.resource.Spec.SystemName, environment, err)
+		}
+
+		data[fmt.Sprintf("%s.json", environment)] = string(content)
+		data[fmt.Sprintf("%s-%d.json", environment, proxyConfig.Element.Version)] = string(content)
+		annotations[fmt.Sprintf(proxyConfigVersionAnnotationFormat, environment)] = strconv.Itoa(proxyConfig.Element.Version)
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:        publication.Name,
+		Namespace:   namespace,
+		Annotations: annotations,
+	}
+
+	var desired common.KubernetesObject
+	var existing common.KubernetesObject
+	var mutator reconcilers.MutateFn
+	if publication.PublicationKind() == capabilitiesv1beta1.ProxyConfigPublicationSecretKind {
+		desired = &corev1.Secret{ObjectMeta: objectMeta, StringData: data, Type: corev1.SecretTypeOpaque}
+		existing = &corev1.Secret{}
+		mutator = proxyConfigSecretMutator
+	} else {
+		desired = &corev1.ConfigMap{ObjectMeta: objectMeta, Data: data}
+		existing = &corev1.ConfigMap{}
+		mutator = proxyConfigConfigMapMutator
+	}
+
+	// The owner is only set on creation, existing objects are not deleted with the product.
+	// Owner references across namespaces are not allowed
+	if namespace == t.resource.Namespace {
+		err := t.SetControllerOwnerReference(t.resource, desired)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.ReconcileResource(existing, desired, mutator)
+}
+
+// ensureProxyConfigNamespaceAllowed returns WaitError when the namespace does not list the product
+// in the proxy config products annotation. Products cannot publish to namespaces not opted in
+func (t *ProductThreescaleReconciler) ensureProxyConfigNamespaceAllowed(namespace string) error {
+	// Namespaces are not cached, only the referenced one is read
+	namespaceObj := &corev1.Namespace{}
+	err := t.APIClientReader().Get(t.Context(), client.ObjectKey{Name: namespace}, namespaceObj)
+	if err != nil {
+		return fmt.Errorf("product [%s] proxy config publication namespace %s: %w", t.resource.Spec.SystemName, namespace, err)
+	}
+
+	product := proxyConfigProductAnnotationValue(t.resource)
+	for _, allowed := range strings.Split(namespaceObj.GetAnnotations()[proxyConfigProductsNamespaceAnnotation], ",") {
+		if strings.TrimSpace(allowed) == product {
+			return nil
+		}
+	}
+
+	return &helper.WaitError{
+		Err: fmt.Errorf("proxy config publication: namespace %s does not allow product %s in the %s annotation",
+			namespace, product, proxyConfigProductsNamespaceAnnotation),
+	}
+}
+
+func proxyConfigProductAnnotationValue(product *capabilitiesv1beta1.Product) string {
+	return fmt.Sprintf("%s/%s", product.Namespace, product.Name)
+}
+
+// ensureProxyConfigProduct returns ConflictError when the existing object was not published for the desired product.
+// Objects not created by the operator, or published for another product, are never taken over
+func ensureProxyConfigProduct(existing, desired common.KubernetesObject) error {
+	product := desired.GetAnnotations()[proxyConfigProductAnnotation]
+	if existing.GetAnnotations()[proxyConfigProductAnnotation] != product {
+		return &helper.ConflictError{
+			Err: fmt.Errorf("proxy config publication: %T %s is not annotated with %s: %s",
+				existing, existing.GetName(), proxyConfigProductAnnotation, product),
+		}
+	}
+	return nil
+}
+
+// threescaleEnvironment returns the 3scale name of the proxy configuration environment
+func threescaleEnvironment(environment capabilitiesv1beta1.ProxyConfigEnvironment) string {
+	if environment == capabilitiesv1beta1.ProxyConfigEnvironmentStaging {
+		return "sandbox"
+	}
+	return "production"
+}
+
+func proxyConfigConfigMapMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*corev1.ConfigMap)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.ConfigMap", existingObj)
+	}
+	desired, ok := desiredObj.(*corev1.ConfigMap)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.ConfigMap", desiredObj)
+	}
+
+	if err := ensureProxyConfigProduct(existing, desired); err != nil {
+		return false, err
+	}
+
+	update := helper.EnsureObjectMeta(existing, desired)
+	update = removeStaleProxyConfigAnnotations(existing, desired) || update
+
+	if existing.Data == nil {
+		existing.Data = map[string]string{}
+	}
+	for key := range desired.Data {
+		update = reconcilers.ConfigMapReconcileField(desired, existing, key) || update
+	}
+
+	// Environments no longer published and previous versions are removed, gateways must not load them
+	for key := range existing.Data {
+		if _, ok := desired.Data[key]; !ok && proxyConfigDataKeyRegexp.MatchString(key) {
+			delete(existing.Data, key)
+			update = true
+		}
+	}
+
+	return update, nil
+}
+
+func proxyConfigSecretMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*corev1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", existingObj)
+	}
+	desired, ok := desiredObj.(*corev1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", desiredObj)
+	}
+
+	if err := ensureProxyConfigProduct(existing, desired); err != nil {
+		return false, err
+	}
+
+	update := helper.EnsureObjectMeta(existing, desired)
+	update = removeStaleProxyConfigAnnotations(existing, desired) || update
+
+	for key := range desired.StringData {
+		update = reconcilers.SecretReconcileField(key)(desired, existing) || update
+	}
+
+	// Environments no longer published and previous versions are removed, gateways must not load them
+	for key := range existing.Data {
+		if _, ok := desired.StringData[key]; !ok && proxyConfigDataKeyRegexp.MatchString(key) {
+			delete(existing.Data, key)
+			update = true
+		}
+	}
+	for key := range existing.StringData {
+		if _, ok := desired.StringData[key]; !ok && proxyConfigDataKeyRegexp.MatchString(key) {
+			delete(existing.StringData, key)
+			update = true
+		}
+	}
+
+	return update, nil
+}
+
+// removeStaleProxyConfigAnnotations removes the version annotations of the environments no longer published
+func removeStaleProxyConfigAnnotations(existing, desired common.KubernetesObject) bool {
+	update := false
+	annotations := existing.GetAnnotations()
+	for key := range annotations {
+		if _, ok := desired.GetAnnotations()[key]; !ok && proxyConfigVersionAnnotationRegexp.MatchString(key) {
+			delete(annotations, key)
+			update = true
+		}
+	}
+	existing.SetAnnotations(annotations)
+	return update
+}
@@ -0,0 +1,318 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProductThreescaleReconciler_publishProxyConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := capabilitiesv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		stagingConfig    = `{"services":[{"id":10,"proxy":{"endpoint":"https://staging.example.com"}}]}`
+		productionConfig = `{"services":[{"id":10,"proxy":{"endpoint":"https://production.example.com"}}]}`
+	)
+
+	tests := []struct {
+		name            string
+		publication     *capabilitiesv1beta1.ProxyConfigPublicationSpec
+		existing        []runtime.Object
+		stagingPromoted bool
+		wantData        map[string]string
+		wantAnnotations map[string]string
+		wantNamespace   string
+		wantOwned       bool
+		wantConflict    bool
+		wantWait        bool
+	}{
+		{
+			name:            "production by default",
+			publication:     &capabilitiesv1beta1.ProxyConfigPublicationSpec{Name: "apicast-config"},
+			stagingPromoted: true,
+			wantData:        map[string]string{"production.json": productionConfig, "production-2.json": productionConfig},
+			wantAnnotations: map[string]string{
+				proxyConfigProductAnnotation:                              "test/product",
+				"capabilities.3scale.net/production-proxy-config-version": "2",
+			},
+			wantOwned: true,
+		},
+		{
+			name: "environment never promoted",
+			publication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{
+				Name: "apicast-config",
+				Environments: []capabilitiesv1beta1.ProxyConfigEnvironment{
+					capabilitiesv1beta1.ProxyConfigEnvironmentStaging,
+					capabilitiesv1beta1.ProxyConfigEnvironmentProduction,
+				},
+			},
+			wantData: map[string]string{"production.json": productionConfig, "production-2.json": productionConfig},
+			wantAnnotations: map[string]string{
+				proxyConfigProductAnnotation:                              "test/product",
+				"capabilities.3scale.net/production-proxy-config-version": "2",
+			},
+			wantOwned: true,
+		},
+		{
+			name: "existing config map updated",
+			publication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{
+				Name:         "apicast-config",
+				Environments: []capabilitiesv1beta1.ProxyConfigEnvironment{capabilitiesv1beta1.ProxyConfigEnvironmentStaging},
+			},
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "apicast-config",
+					Namespace: "test",
+					Annotations: map[string]string{
+						proxyConfigProductAnnotation:                              "test/product",
+						"capabilities.3scale.net/production-proxy-config-version": "2",
+					},
+				},
+				// Previous staging version and production, no longer published, are removed
+				Data: map[string]string{"staging.json": "{}", "staging-2.json": "{}", "production.json": "{}", "production-2.json": "{}", "other": "kept"},
+			}},
+			stagingPromoted: true,
+			wantData:        map[string]string{"staging.json": stagingConfig, "staging-3.json": stagingConfig, "other": "kept"},
+			wantAnnotations: map[string]string{
+				proxyConfigProductAnnotation:                           "test/product",
+				"capabilities.3scale.net/staging-proxy-config-version": "3",
+			},
+			wantOwned: false,
+		},
+		{
+			name:        "namespace allowing the product",
+			publication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{Name: "apicast-config", Namespace: ptr.To("gateways")},
+			existing: []runtime.Object{&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "gateways",
+					Annotations: map[string]string{proxyConfigProductsNamespaceAnnotation: "test/other, test/product"},
+				},
+			}},
+			wantData: map[string]string{"production.json": productionConfig, "production-2.json": productionConfig},
+			wantAnnotations: map[string]string{
+				proxyConfigProductAnnotation:                              "test/product",
+				"capabilities.3scale.net/production-proxy-config-version": "2",
+			},
+			wantNamespace: "gateways",
+			wantOwned:     false,
+		},
+		{
+			name:        "namespace not allowing the product",
+			publication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{Name: "apicast-config", Namespace: ptr.To("gateways")},
+			existing: []runtime.Object{&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "gateways",
+					Annotations: map[string]string{proxyConfigProductsNamespaceAnnotation: "test/other"},
+				},
+			}},
+			wantWait: true,
+		},
+		{
+			name:        "existing config map not annotated",
+			publication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{Name: "apicast-config"},
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "apicast-config", Namespace: "test"},
+				Data:       map[string]string{"other": "kept"},
+			}},
+			wantData:     map[string]string{"other": "kept"},
+			wantConflict: true,
+		},
+		{
+			name:        "existing config map of another product",
+			publication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{Name: "apicast-config"},
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "apicast-config",
+					Namespace:   "test",
+					Annotations: map[string]string{proxyConfigProductAnnotation: "test/other"},
+				},
+				Data: map[string]string{"production.json": "{}"},
+			}},
+			wantData:        map[string]string{"production.json": "{}"},
+			wantAnnotations: map[string]string{proxyConfigProductAnnotation: "test/other"},
+			wantConflict:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/admin/api/services/10/proxy/configs/sandbox/latest.json":
+					if !tt.stagingPromoted {
+						w.WriteHeader(http.StatusNotFound)
+						fmt.Fprint(w, `{"status":"Not found"}`)
+						return
+					}
+					fmt.Fprint(w, `{"proxy_config":{"id":1,"version":3,"environment":"sandbox","content":{"id":10,"proxy":{"endpoint":"https://staging.example.com"}}}}`)
+				case "/admin/api/services/10/proxy/configs/production/latest.json":
+					fmt.Fprint(w, `{"proxy_config":{"id":2,"version":2,"environment":"production","content":{"id":10,"proxy":{"endpoint":"https://production.example.com"}}}}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			plansAPIClient, err := controllerhelper.NewPlansAPIClient(&controllerhelper.ProviderAccount{AdminURLStr: server.URL, Token: "token"}, false)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			logger := logf.Log.WithName("product proxy config publication test")
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.existing...).Build()
+			reconciler := &ProductThreescaleReconciler{
+				BaseReconciler: reconcilers.NewBaseReconciler(context.TODO(), k8sClient, scheme, k8sClient, logger, nil, nil),
+				resource: &capabilitiesv1beta1.Product{
+					ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "test", UID: "product-uid"},
+					Spec: capabilitiesv1beta1.ProductSpec{
+						SystemName: "product",
+						Deployment: &capabilitiesv1beta1.ProductDeploymentSpec{
+							ApicastSelfManaged: &capabilitiesv1beta1.ApicastSelfManagedSpec{ProxyConfigPublication: tt.publication},
+						},
+					},
+				},
+				productEntity:  controllerhelper.NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: 10}}, nil, logger),
+				plansAPIClient: plansAPIClient,
+				logger:         logger,
+			}
+
+			err = reconciler.publishProxyConfig()
+			if tt.wantConflict != helper.IsConflictError(err) {
+				subT.Fatalf("publishProxyConfig() error = %v, want conflict %v", err, tt.wantConflict)
+			}
+			if tt.wantWait != helper.IsWaitError(err) {
+				subT.Fatalf("publishProxyConfig() error = %v, want wait %v", err, tt.wantWait)
+			}
+			if err != nil && !tt.wantConflict && !tt.wantWait {
+				subT.Fatalf("publishProxyConfig() error = %v", err)
+			}
+
+			namespace := "test"
+			if tt.wantNamespace != "" {
+				namespace = tt.wantNamespace
+			}
+
+			configMap := &corev1.ConfigMap{}
+			err = k8sClient.Get(context.TODO(), types.NamespacedName{Name: tt.publication.Name, Namespace: namespace}, configMap)
+			if tt.wantWait {
+				if !apierrors.IsNotFound(err) {
+					subT.Errorf("config map published to a namespace not allowing the product: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(configMap.Data, tt.wantData) {
+				subT.Errorf("data = %v, want %v", configMap.Data, tt.wantData)
+			}
+
+			if !reflect.DeepEqual(configMap.Annotations, tt.wantAnnotations) {
+				subT.Errorf("annotations = %v, want %v", configMap.Annotations, tt.wantAnnotations)
+			}
+
+			owned := metav1.IsControlledBy(configMap, reconciler.resource)
+			if owned != tt.wantOwned {
+				subT.Errorf("owned = %v, want %v", owned, tt.wantOwned)
+			}
+		})
+	}
+}
+
+func TestProductThreescaleReconciler_publishProxyConfigSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := capabilitiesv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"proxy_config":{"id":2,"version":2,"environment":"production","content":{"id":10}}}`)
+	}))
+	defer server.Close()
+
+	plansAPIClient, err := controllerhelper.NewPlansAPIClient(&controllerhelper.ProviderAccount{AdminURLStr: server.URL, Token: "token"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := logf.Log.WithName("product proxy config publication test")
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "apicast-config",
+			Namespace:   "test",
+			Annotations: map[string]string{proxyConfigProductAnnotation: "test/product"},
+		},
+		Data: map[string][]byte{"staging.json": []byte("{}"), "other": []byte("kept")},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+	reconciler := &ProductThreescaleReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(context.TODO(), k8sClient, scheme, k8sClient, logger, nil, nil),
+		resource: &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "test"},
+			Spec: capabilitiesv1beta1.ProductSpec{
+				SystemName: "product",
+				Deployment: &capabilitiesv1beta1.ProductDeploymentSpec{
+					ApicastSelfManaged: &capabilitiesv1beta1.ApicastSelfManagedSpec{
+						ProxyConfigPublication: &capabilitiesv1beta1.ProxyConfigPublicationSpec{
+							Kind: ptr.To(capabilitiesv1beta1.ProxyConfigPublicationSecretKind),
+							Name: "apicast-config",
+						},
+					},
+				},
+			},
+		},
+		productEntity:  controllerhelper.NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: 10}}, nil, logger),
+		plansAPIClient: plansAPIClient,
+		logger:         logger,
+	}
+
+	err = reconciler.publishProxyConfig()
+	if err != nil {
+		t.Fatalf("publishProxyConfig() error = %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	err = k8sClient.Get(context.TODO(), client.ObjectKey{Name: "apicast-config", Namespace: "test"}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"production.json": `{"services":[{"id":10}]}`, "production-2.json": `{"services":[{"id":10}]}`}
+	if !reflect.DeepEqual(secret.StringData, want) {
+		t.Errorf("stringData = %v, want %v", secret.StringData, want)
+	}
+
+	// staging is no longer published
+	wantData := map[string][]byte{"other": []byte("kept")}
+	if !reflect.DeepEqual(secret.Data, wantData) {
+		t.Errorf("data = %v, want %v", secret.Data, wantData)
+	}
+}
@@ -21,6 +21,14 @@ type ProductStatusReconciler struct {
 	entity              *controllerhelper.ProductEntity
 	providerAccountHost string
 	syncError           error
+	// planRetirements is nil when application plan retirements have not been processed
+	planRetirements []capabilitiesv1beta1.ApplicationPlanRetirementStatus
+	// keptServicePlans is nil when service plans have not been synchronized
+	keptServicePlans []string
+	// drifts is nil when the product in 3scale has not been compared with the spec
+	drifts driftList
+	// proxyConfigVersions is nil when the proxy configuration has not been promoted
+	proxyConfigVersions *proxyConfigVersions
 	logger              logr.Logger
 }
 
@@ -76,17 +84,54 @@ func (s *ProductStatusReconciler) calculateStatus() *capabilitiesv1beta1.Product
 	if s.entity != nil {
 		tmpID := s.entity.ID()
 		newStatus.ID = &tmpID
+	} else if s.syncError != nil {
+		// The ID is the ownership marker of the product in 3scale, it must survive failed reconciliations
+		newStatus.ID = s.resource.Status.ID
 	}
 
 	newStatus.ProviderAccountHost = s.providerAccountHost
 
 	newStatus.ObservedGeneration = s.resource.Status.ObservedGeneration
 
+	// Keep the last reported progress when retirements have not been processed
+	newStatus.RetiredApplicationPlans = s.resource.Status.RetiredApplicationPlans
+	if s.planRetirements != nil {
+		newStatus.RetiredApplicationPlans = s.planRetirements
+		if len(s.planRetirements) == 0 {
+			newStatus.RetiredApplicationPlans = nil
+		}
+	}
+
+	// Keep the last reported service plans when service plans have not been synchronized
+	newStatus.KeptServicePlans = s.resource.Status.KeptServicePlans
+	if s.keptServicePlans != nil {
+		newStatus.KeptServicePlans = s.keptServicePlans
+		if len(s.keptServicePlans) == 0 {
+			newStatus.KeptServicePlans = nil
+		}
+	}
+
+	// Keep the last promoted versions when the proxy configuration has not been promoted
+	if s.resource.IsPromotionEnabled() && !s.resource.IsObserved() {
+		newStatus.LatestStagingVersion = s.resource.Status.LatestStagingVersion
+		newStatus.LatestProductionVersion = s.resource.Status.LatestProductionVersion
+		if s.proxyConfigVersions != nil {
+			newStatus.LatestStagingVersion = s.proxyConfigVersions.staging
+			newStatus.LatestProductionVersion = s.proxyConfigVersions.production
+		}
+	}
+
 	newStatus.Conditions = s.resource.Status.Conditions.Copy()
 	newStatus.Conditions.SetCondition(s.syncCondition())
 	newStatus.Conditions.SetCondition(s.orphanCondition())
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
+	newStatus.Conditions.SetCondition(s.conflictCondition())
+	if s.drifts != nil {
+		newStatus.Conditions.SetCondition(s.drifts.driftedCondition(capabilitiesv1beta1.ProductDriftedConditionType, s.resource.IsObserved()))
+	}
+
+	newStatus.LastSyncTime = controllerhelper.LastSyncTime(s.resource.Status.LastSyncTime, s.syncError, s.resource.GetAnnotations())
 
 	return newStatus
 }
@@ -101,6 +146,11 @@ func (s *ProductStatusReconciler) syncCondition() common.Condition {
 		condition.Status = corev1.ConditionTrue
 	}
 
+	// Waiting is not a failure, the reason is reported here
+	if helper.IsWaitError(s.syncError) {
+		condition.Message = s.syncError.Error()
+	}
+
 	return condition
 }
 
@@ -139,8 +189,23 @@ func (s *ProductStatusReconciler) failedCondition() common.Condition {
 	}
 
 	// This condition could be activated together with other conditions
-	if s.syncError != nil {
+	if s.syncError != nil && !helper.IsWaitError(s.syncError) {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.syncError.Error()
+	}
+
+	return condition
+}
+
+func (s *ProductStatusReconciler) conflictCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ProductConflictConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if helper.IsConflictError(s.syncError) {
 		condition.Status = corev1.ConditionTrue
+		condition.Reason = "NotOwned"
 		condition.Message = s.syncError.Error()
 	}
 
@@ -10,6 +10,8 @@ import (
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ProductThreescaleReconciler struct {
@@ -18,26 +20,68 @@ type ProductThreescaleReconciler struct {
 	productEntity       *controllerhelper.ProductEntity
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	plansAPIClient      *controllerhelper.PlansAPIClient
+	// planRetirements is nil until application plan retirements are processed
+	planRetirements []capabilitiesv1beta1.ApplicationPlanRetirementStatus
+	// keptServicePlans is nil until service plans are synchronized
+	keptServicePlans []string
+	// subscribedServicePlans is nil until service plan subscribers are looked up
+	subscribedServicePlans map[int64]bool
+	// drifts is nil until the product in 3scale is compared with the spec
+	drifts driftList
+	// proxyConfigVersions is nil until the proxy configuration is promoted
+	proxyConfigVersions *proxyConfigVersions
 	logger              logr.Logger
 }
 
-func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
+func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, plansAPIClient *controllerhelper.PlansAPIClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
 	return &ProductThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		plansAPIClient:      plansAPIClient,
 		backendRemoteIndex:  backendRemoteIndex,
 		logger:              b.Logger().WithValues("3scale Reconciler", resource.Name),
 	}
 }
 
 func (t *ProductThreescaleReconciler) Reconcile() (*controllerhelper.ProductEntity, error) {
-	productEntity, err := t.reconcile3scaleProduct()
+	productObj, err := t.findProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.resource.IsObserved() {
+		return t.observe(productObj)
+	}
+
+	if productObj != nil {
+		adopt, err := checkRemoteOwnership(t.resource, t.resource.Status.ID, productObj.Element.ID, "product", t.resource.Spec.SystemName)
+		if err != nil {
+			return nil, err
+		}
+
+		if adopt {
+			t.EventRecorder().Eventf(t.resource, corev1.EventTypeNormal, "Adopted", "product [%s] with ID %d adopted", t.resource.Spec.SystemName, productObj.Element.ID)
+		}
+	}
+
+	productEntity, err := t.reconcile3scaleProduct(productObj)
 	if err != nil {
 		return nil, err
 	}
 	t.productEntity = productEntity
 
+	// Changes made out of the operator are overwritten.
+	// Compare before synchronizing to report what is overwritten
+	drifts := driftList{}
+	if productObj != nil {
+		drifts, err = t.detectDrift()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	taskRunner := helper.NewTaskRunner(nil, t.logger)
 	taskRunner.AddTask("SyncProduct", t.syncProduct)
 	taskRunner.AddTask("SyncBackendUsage", t.syncBackendUsage)
@@ -49,38 +93,87 @@ func (t *ProductThreescaleReconciler) Reconcile() (*controllerhelper.ProductEnti
 	taskRunner.AddTask("SyncMethods", t.syncMethods)
 	taskRunner.AddTask("SyncMetrics", t.syncMetrics)
 	taskRunner.AddTask("SyncMappingRules", t.syncMappingRules)
+	// Features are enabled on plans once both features and plans exist
+	taskRunner.AddTask("SyncFeatures", t.syncFeatures)
 	taskRunner.AddTask("SyncApplicationPlans", t.syncApplicationPlans)
+	// Retired plans are deleted once their applications are moved to existing plans
+	taskRunner.AddTask("RetireApplicationPlans", t.retireApplicationPlans)
+	taskRunner.AddTask("SyncServicePlans", t.syncServicePlans)
+	taskRunner.AddTask("SyncPlanFeatures", t.syncPlanFeatures)
 	taskRunner.AddTask("SyncPolicies", t.syncPolicies)
 	taskRunner.AddTask("SyncOIDCConfiguration", t.syncOIDCConfiguration)
 
 	err = taskRunner.Run()
+	if err != nil {
+		// The product exists in 3scale, its ID is kept in the status as ownership marker
+		return t.productEntity, err
+	}
+
+	t.drifts = drifts
+	t.drifts.recordOverwrites(t.BaseReconciler, t.resource)
+
+	// Promote once the product is fully synchronized
+	if t.resource.IsPromotionEnabled() {
+		err = t.promoteProxyConfig()
+		if err != nil {
+			return t.productEntity, err
+		}
+	}
+
+	// Publish once promoted, so file based gateways get the promoted version
+	if t.resource.ProxyConfigPublication() != nil {
+		err = t.publishProxyConfig()
+		if err != nil {
+			return t.productEntity, err
+		}
+	}
+
+	// Applications pending migration do not block the other tasks, the product is reconciled again to retry
+	err = t.planRetirementsPendingError()
+	if err != nil {
+		return t.productEntity, err
+	}
+
+	return t.productEntity, nil
+}
+
+// observe compares the product in 3scale with the spec, never changing the product in 3scale
+func (t *ProductThreescaleReconciler) observe(productObj *threescaleapi.Product) (*controllerhelper.ProductEntity, error) {
+	if productObj == nil {
+		t.drifts = driftList{{field: "spec.systemName", existing: driftMissingValue, desired: t.resource.Spec.SystemName}}
+		return nil, nil
+	}
+
+	t.productEntity = controllerhelper.NewProductEntity(productObj, t.threescaleAPIClient, t.logger)
+
+	drifts, err := t.detectDrift()
 	if err != nil {
 		return nil, err
 	}
+	t.drifts = drifts
 
 	return t.productEntity, nil
 }
 
-func (t *ProductThreescaleReconciler) reconcile3scaleProduct() (*controllerhelper.ProductEntity, error) {
+// findProduct returns the product in 3scale with the spec system name, nil when not found
+func (t *ProductThreescaleReconciler) findProduct() (*threescaleapi.Product, error) {
 	productList, err := t.threescaleAPIClient.ListProducts()
 	if err != nil {
 		return nil, fmt.Errorf("reconcile3scaleProduct product [%s]: %w", t.resource.Spec.SystemName, err)
 	}
 
 	// Find product in the list by system name
-	idx, exists := func(pList []threescaleapi.Product) (int, bool) {
-		for i, item := range pList {
-			if item.Element.SystemName == t.resource.Spec.SystemName {
-				return i, true
-			}
+	for i, item := range productList.Products {
+		if item.Element.SystemName == t.resource.Spec.SystemName {
+			return &productList.Products[i], nil
 		}
-		return -1, false
-	}(productList.Products)
+	}
 
-	var productObj *threescaleapi.Product
-	if exists {
-		productObj = &productList.Products[idx]
-	} else {
+	return nil, nil
+}
+
+func (t *ProductThreescaleReconciler) reconcile3scaleProduct(productObj *threescaleapi.Product) (*controllerhelper.ProductEntity, error) {
+	if productObj == nil {
 		// Create product using system_name.
 		// it cannot be modified later
 		params := threescaleapi.Params{
@@ -91,6 +184,15 @@ func (t *ProductThreescaleReconciler) reconcile3scaleProduct() (*controllerhelpe
 			return nil, fmt.Errorf("reconcile3scaleProduct product [%s]: %w", t.resource.Spec.SystemName, err)
 		}
 
+		productID := product.Element.ID
+		t.resource.Status.ID = &productID
+		err = saveRemoteOwnership(t.Context(), t.Client(), t.resource, func(obj client.Object) {
+			obj.(*capabilitiesv1beta1.Product).Status.ID = &productID
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reconcile3scaleProduct product [%s] saving ID %d: %w", t.resource.Spec.SystemName, productID, err)
+		}
+
 		productObj = product
 	}
 
@@ -149,6 +149,11 @@ func (r *ProxyConfigPromoteReconciler) proxyConfigPromoteReconciler(proxyConfigP
 		productIDInt64 := *productID
 		productIDStr := strconv.Itoa(int(productIDInt64))
 
+		// Promote a specific version to production, e.g. to roll back a bad production deploy
+		if proxyConfigPromote.Spec.IsVersionPromotion() {
+			return r.promoteVersionToProduction(proxyConfigPromote, reqLogger, threescaleAPIClient, product, productIDStr)
+		}
+
 		// If wanting to promote to Stage but not production.
 		if proxyConfigPromote.Spec.Production == nil || !*proxyConfigPromote.Spec.Production {
 
@@ -266,6 +271,128 @@ func (r *ProxyConfigPromoteReconciler) proxyConfigPromoteReconciler(proxyConfigP
 	}
 }
 
+// promoteVersionToProduction promotes the spec targetVersion, or the production version preceding the current one, to production.
+// Products promoting to production automatically are refused, the next product sync would promote the latest staging version again
+func (r *ProxyConfigPromoteReconciler) promoteVersionToProduction(proxyConfigPromote *capabilitiesv1beta1.ProxyConfigPromote, reqLogger logr.Logger, threescaleAPIClient *threescaleapi.ThreeScaleClient, product *capabilitiesv1beta1.Product, productIDStr string) (*ProxyConfigPromoteStatusReconciler, error) {
+	specFldPath := field.NewPath("spec")
+	if proxyConfigPromote.Spec.TargetVersion != nil && proxyConfigPromote.Spec.RollbackTo != nil {
+		err := &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Invalid(specFldPath.Child("rollbackTo"), *proxyConfigPromote.Spec.RollbackTo, "targetVersion and rollbackTo are mutually exclusive"),
+			},
+		}
+		statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, 0, 0, err)
+		return statusReconciler, err
+	}
+
+	if product.Spec.Deployment != nil && product.Spec.Deployment.Promotion != nil && product.Spec.Deployment.Promotion.IsProductionEnabled() {
+		var fieldErr *field.Error
+		if proxyConfigPromote.Spec.TargetVersion != nil {
+			fieldErr = field.Invalid(specFldPath.Child("targetVersion"), *proxyConfigPromote.Spec.TargetVersion, "product promotes to production automatically, disable its spec.deployment.promotion.production first")
+		} else {
+			fieldErr = field.Invalid(specFldPath.Child("rollbackTo"), *proxyConfigPromote.Spec.RollbackTo, "product promotes to production automatically, disable its spec.deployment.promotion.production first")
+		}
+		err := &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: field.ErrorList{fieldErr},
+		}
+		statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, 0, 0, err)
+		return statusReconciler, err
+	}
+
+	stageElement, err := threescaleAPIClient.GetLatestProxyConfig(productIDStr, "sandbox")
+	if err != nil {
+		statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, 0, 0, err)
+		return statusReconciler, err
+	}
+	latestStagingVersion := stageElement.ProxyConfig.Version
+
+	// If product has not been promoted to production yet the version would be 0.
+	productionElement, err := threescaleAPIClient.GetLatestProxyConfig(productIDStr, "production")
+	if err != nil && !threescaleapi.IsNotFound(err) {
+		statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, 0, latestStagingVersion, err)
+		return statusReconciler, err
+	}
+	currentProductionVersion := productionElement.ProxyConfig.Version
+
+	var targetVersion int
+	var targetFldPath *field.Path
+	if proxyConfigPromote.Spec.TargetVersion != nil {
+		targetVersion = *proxyConfigPromote.Spec.TargetVersion
+		targetFldPath = specFldPath.Child("targetVersion")
+
+		_, err := threescaleAPIClient.GetProxyConfig(productIDStr, "sandbox", strconv.Itoa(targetVersion))
+		if err != nil {
+			if threescaleapi.IsNotFound(err) {
+				err = &helper.SpecFieldError{
+					ErrorType: helper.InvalidError,
+					FieldErrorList: field.ErrorList{
+						field.Invalid(targetFldPath, targetVersion, "proxy config version does not exist in staging"),
+					},
+				}
+			}
+			statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, currentProductionVersion, latestStagingVersion, err)
+			return statusReconciler, err
+		}
+	} else {
+		targetFldPath = specFldPath.Child("rollbackTo")
+
+		productionConfigs, err := threescaleAPIClient.ListProxyConfig(productIDStr, "production")
+		if err != nil {
+			statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, currentProductionVersion, latestStagingVersion, err)
+			return statusReconciler, err
+		}
+
+		targetVersion = previousProxyConfigVersion(productionConfigs, currentProductionVersion)
+		if targetVersion == 0 {
+			err := &helper.SpecFieldError{
+				ErrorType: helper.InvalidError,
+				FieldErrorList: field.ErrorList{
+					field.Invalid(targetFldPath, *proxyConfigPromote.Spec.RollbackTo, "no previous proxy config version in production"),
+				},
+			}
+			statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, currentProductionVersion, latestStagingVersion, err)
+			return statusReconciler, err
+		}
+	}
+
+	if targetVersion == currentProductionVersion {
+		err := &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Invalid(targetFldPath, targetVersion, "proxy config version is already in production"),
+			},
+		}
+		statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, currentProductionVersion, latestStagingVersion, err)
+		return statusReconciler, err
+	}
+
+	reqLogger.Info("Promoting proxy config version to production", "version", targetVersion, "current version", currentProductionVersion)
+	_, err = threescaleAPIClient.PromoteProxyConfig(productIDStr, "sandbox", strconv.Itoa(targetVersion), "production")
+	if err != nil {
+		statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, currentProductionVersion, latestStagingVersion, err)
+		return statusReconciler, err
+	}
+
+	statusReconciler := NewProxyConfigPromoteStatusReconciler(r.BaseReconciler, proxyConfigPromote, productIDStr, targetVersion, latestStagingVersion, nil)
+	statusReconciler.previousProductionVersion = currentProductionVersion
+	return statusReconciler, nil
+}
+
+// previousProxyConfigVersion returns the highest version lower than the current one, 0 when there is none
+func previousProxyConfigVersion(configs threescaleapi.ProxyConfigList, currentVersion int) int {
+	previousVersion := 0
+	for _, config := range configs.ProxyConfigs {
+		version := config.ProxyConfig.Version
+		if version < currentVersion && version > previousVersion {
+			previousVersion = version
+		}
+	}
+
+	return previousVersion
+}
+
 func (r *ProxyConfigPromoteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.ProxyConfigPromote{}).
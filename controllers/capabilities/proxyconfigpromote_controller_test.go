@@ -6,12 +6,14 @@ import (
 	"fmt"
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
 	"io/ioutil"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"net/http"
 	"reflect"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -448,3 +450,143 @@ func TestProxyConfigPromoteReconciler_proxyConfigPromoteReconciler(t *testing.T)
 		})
 	}
 }
+
+func TestProxyConfigPromoteReconciler_promoteVersionToProduction(t *testing.T) {
+	proxyConfigElement := func(version int) *client.ProxyConfigElement {
+		return &client.ProxyConfigElement{ProxyConfig: client.ProxyConfig{ID: 3, Version: version}}
+	}
+	productionConfigs := &client.ProxyConfigList{
+		ProxyConfigs: []client.ProxyConfigElement{*proxyConfigElement(1), *proxyConfigElement(2), *proxyConfigElement(4)},
+	}
+	rollbackToPrevious := capabilitiesv1beta1.ProxyConfigPromoteRollbackToPrevious
+	targetVersion := func(version int) *int {
+		return &version
+	}
+
+	// Staging latest version is 5, production latest version is 4, staging version 3 exists
+	httpClient := NewTestClient(func(req *http.Request) *http.Response {
+		response := func(statusCode int, body interface{}) *http.Response {
+			return &http.Response{
+				StatusCode: statusCode,
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(bytes.NewBuffer(responseBody(body))),
+			}
+		}
+
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/services/3/proxy/configs/sandbox/latest.json":
+			return response(http.StatusOK, proxyConfigElement(5))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/services/3/proxy/configs/production/latest.json":
+			return response(http.StatusOK, proxyConfigElement(4))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/services/3/proxy/configs/sandbox/3.json":
+			return response(http.StatusOK, proxyConfigElement(3))
+		case req.Method == "GET" && req.URL.Path == "/admin/api/services/3/proxy/configs/production.json":
+			return response(http.StatusOK, productionConfigs)
+		case req.Method == "POST" && req.URL.Path == "/admin/api/services/3/proxy/configs/sandbox/3/promote.json":
+			return response(http.StatusCreated, proxyConfigElement(3))
+		case req.Method == "POST" && req.URL.Path == "/admin/api/services/3/proxy/configs/sandbox/2/promote.json":
+			return response(http.StatusCreated, proxyConfigElement(2))
+		}
+
+		return response(http.StatusNotFound, map[string]string{"status": "Not found"})
+	})
+
+	ap, _ := client.NewAdminPortalFromStr("https://3scale-admin.test.3scale.net")
+	threescaleAPIClient := client.NewThreeScale(ap, "test", httpClient)
+
+	tests := []struct {
+		name                     string
+		spec                     capabilitiesv1beta1.ProxyConfigPromoteSpec
+		productPromotion         *capabilitiesv1beta1.ProductPromotionSpec
+		wantInvalid              bool
+		wantProductionVersion    int
+		wantPreviousVersion      int
+		wantLatestStagingVersion int
+	}{
+		{
+			name:                     "target version",
+			spec:                     capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", TargetVersion: targetVersion(3)},
+			wantProductionVersion:    3,
+			wantPreviousVersion:      4,
+			wantLatestStagingVersion: 5,
+		},
+		{
+			name:                     "rollback to previous",
+			spec:                     capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", RollbackTo: &rollbackToPrevious},
+			wantProductionVersion:    2,
+			wantPreviousVersion:      4,
+			wantLatestStagingVersion: 5,
+		},
+		{
+			name:        "unknown target version",
+			spec:        capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", TargetVersion: targetVersion(6)},
+			wantInvalid: true,
+		},
+		{
+			name:        "target version already in production",
+			spec:        capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", TargetVersion: targetVersion(4)},
+			wantInvalid: true,
+		},
+		{
+			name:        "target version and rollback",
+			spec:        capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", TargetVersion: targetVersion(3), RollbackTo: &rollbackToPrevious},
+			wantInvalid: true,
+		},
+		{
+			name:             "target version with production auto promotion",
+			spec:             capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", TargetVersion: targetVersion(3)},
+			productPromotion: &capabilitiesv1beta1.ProductPromotionSpec{Production: ptr.To(true)},
+			wantInvalid:      true,
+		},
+		{
+			name:             "rollback with production auto promotion",
+			spec:             capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", RollbackTo: &rollbackToPrevious},
+			productPromotion: &capabilitiesv1beta1.ProductPromotionSpec{Production: ptr.To(true)},
+			wantInvalid:      true,
+		},
+		{
+			name:                     "rollback with staging auto promotion",
+			spec:                     capabilitiesv1beta1.ProxyConfigPromoteSpec{ProductCRName: "test", RollbackTo: &rollbackToPrevious},
+			productPromotion:         &capabilitiesv1beta1.ProductPromotionSpec{Staging: ptr.To(true)},
+			wantProductionVersion:    2,
+			wantPreviousVersion:      4,
+			wantLatestStagingVersion: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			r := &ProxyConfigPromoteReconciler{
+				BaseReconciler: getBaseReconciler(),
+			}
+			proxyConfigPromote := getProxyConfigPromoteCRStaging()
+			proxyConfigPromote.Spec = tt.spec
+			product := getProductCR()
+			if tt.productPromotion != nil {
+				product.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+					ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{},
+					Promotion:     tt.productPromotion,
+				}
+			}
+
+			got, err := r.proxyConfigPromoteReconciler(proxyConfigPromote, logf.Log.WithName("test reqlogger"), threescaleAPIClient, product)
+			if tt.wantInvalid {
+				if !helper.IsInvalidSpecError(err) {
+					subT.Fatalf("proxyConfigPromoteReconciler() error = %v, want invalid spec error", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatalf("proxyConfigPromoteReconciler() error = %v", err)
+			}
+			if got.latestProductionVersion != tt.wantProductionVersion {
+				subT.Errorf("latestProductionVersion = %v, want %v", got.latestProductionVersion, tt.wantProductionVersion)
+			}
+			if got.previousProductionVersion != tt.wantPreviousVersion {
+				subT.Errorf("previousProductionVersion = %v, want %v", got.previousProductionVersion, tt.wantPreviousVersion)
+			}
+			if got.latestStagingVersion != tt.wantLatestStagingVersion {
+				subT.Errorf("latestStagingVersion = %v, want %v", got.latestStagingVersion, tt.wantLatestStagingVersion)
+			}
+		})
+	}
+}
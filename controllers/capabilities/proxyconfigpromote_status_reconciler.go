@@ -18,8 +18,10 @@ type ProxyConfigPromoteStatusReconciler struct {
 	productID               string
 	latestProductionVersion int
 	latestStagingVersion    int
-	reconcileError          error
-	logger                  logr.Logger
+	// previousProductionVersion is only known when a specific version is promoted to production
+	previousProductionVersion int
+	reconcileError            error
+	logger                    logr.Logger
 }
 
 func NewProxyConfigPromoteStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.ProxyConfigPromote, productID string, latestProductionVersion int, latestStagingVersion int, reconcileError error) *ProxyConfigPromoteStatusReconciler {
@@ -70,6 +72,7 @@ func (s *ProxyConfigPromoteStatusReconciler) calculateStatus() (*capabilitiesv1b
 	newStatus.ProductId = s.productID
 	newStatus.LatestProductionVersion = s.latestProductionVersion
 	newStatus.LatestStagingVersion = s.latestStagingVersion
+	newStatus.PreviousProductionVersion = s.previousProductionVersion
 
 	newStatus.Conditions = s.resource.Status.Conditions.Copy()
 	newStatus.Conditions.SetCondition(s.readyCondition())
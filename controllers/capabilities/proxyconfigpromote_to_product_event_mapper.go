@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/go-logr/logr"
+)
+
+// ProxyConfigPromoteToProductEventMapper is an EventHandler that maps a ProxyConfigPromote CR to the Product CR it promotes
+// when the product publishes its proxy configuration, so manual promotions are published as well
+type ProxyConfigPromoteToProductEventMapper struct {
+	Context   context.Context
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (p *ProxyConfigPromoteToProductEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
+	proxyConfigPromote, ok := obj.(*capabilitiesv1beta1.ProxyConfigPromote)
+	if !ok {
+		return nil
+	}
+
+	productKey := types.NamespacedName{Name: proxyConfigPromote.Spec.ProductCRName, Namespace: proxyConfigPromote.GetNamespace()}
+	product := &capabilitiesv1beta1.Product{}
+	err := p.K8sClient.Get(ctx, productKey, product)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			p.Logger.Error(err, "failed to get Product resource", "key", productKey)
+		}
+		return nil
+	}
+
+	p.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", product.ProxyConfigPublication() != nil)
+
+	if product.ProxyConfigPublication() == nil {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: productKey}}
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -11,7 +12,7 @@ import (
 	"github.com/go-logr/logr"
 )
 
-// SecretToOpenAPIEventMapper is an EventHandler that maps an OAS source secret to it's corresponding OpenAPI CR
+// SecretToOpenAPIEventMapper is an EventHandler that maps an OAS source secret or configmap to it's corresponding OpenAPI CR
 type SecretToOpenAPIEventMapper struct {
 	Context   context.Context
 	K8sClient client.Client
@@ -21,10 +22,15 @@ type SecretToOpenAPIEventMapper struct {
 func (s *SecretToOpenAPIEventMapper) Map(ctx context.Context, obj client.Object) []reconcile.Request {
 	openAPIList := &capabilitiesv1beta1.OpenAPIList{}
 
-	// Filter by Secret UID
+	sourceRefLabelKey := openAPISecretRefLabelKey
+	if _, ok := obj.(*corev1.ConfigMap); ok {
+		sourceRefLabelKey = openAPIConfigMapRefLabelKey
+	}
+
+	// Filter by Secret or ConfigMap UID
 	opts := []client.ListOption{
 		client.MatchingLabels{
-			openAPISecretRefLabelKey: string(obj.GetUID()),
+			sourceRefLabelKey: string(obj.GetUID()),
 		},
 	}
 
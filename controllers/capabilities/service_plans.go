@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func (t *ProductThreescaleReconciler) syncServicePlans(_ interface{}) error {
+	// Service plans are only managed when set in the spec.
+	// Otherwise, the service plans created by 3scale along with the product are kept
+	t.keptServicePlans = []string{}
+	if t.resource.Spec.ServicePlans == nil {
+		return nil
+	}
+
+	desiredKeys := make([]string, 0, len(t.resource.Spec.ServicePlans))
+	for systemName := range t.resource.Spec.ServicePlans {
+		desiredKeys = append(desiredKeys, systemName)
+	}
+
+	existingList, err := t.plansAPIClient.ListServicePlans(t.productEntity.ID())
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingKeys := make([]string, 0, len(existingList.Plans))
+	existingMap := map[string]controllerhelper.PlanItem{}
+	for _, existing := range existingList.Plans {
+		systemName := existing.Element.SystemName
+		existingKeys = append(existingKeys, systemName)
+		existingMap[systemName] = existing.Element
+	}
+
+	//
+	// Create not existing and desired
+	// Done first, 3scale does not allow deleting the last service plan of a product
+	//
+
+	desiredNewKeys := helper.ArrayStringDifference(desiredKeys, existingKeys)
+	t.logger.V(1).Info("syncServicePlans", "desiredNewKeys", desiredNewKeys)
+	for _, systemName := range desiredNewKeys {
+		// Create Service Plan using system_name.
+		// it cannot be modified later
+		params := threescaleapi.Params{"system_name": systemName, "name": systemName}
+		obj, err := t.plansAPIClient.CreateServicePlan(t.productEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+		existingMap[systemName] = obj.Element
+	}
+
+	//
+	// Reconcile desired
+	//
+
+	for _, systemName := range desiredKeys {
+		// key is expected to exist
+		// existing plans or just created
+		err := t.syncServicePlan(systemName, t.resource.Spec.ServicePlans[systemName], existingMap[systemName])
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+	}
+
+	//
+	// Deleted existing and not desired
+	//
+
+	notDesiredExistingKeys := helper.ArrayStringDifference(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncServicePlans", "notDesiredExistingKeys", notDesiredExistingKeys)
+	if len(notDesiredExistingKeys) == 0 {
+		return nil
+	}
+
+	notDesiredPlanIDs := make([]int64, 0, len(notDesiredExistingKeys))
+	for _, systemName := range notDesiredExistingKeys {
+		// key is expected to exist
+		// notDesiredExistingKeys is a subset of the existingMap key set
+		notDesiredPlanIDs = append(notDesiredPlanIDs, existingMap[systemName].ID)
+	}
+
+	subscribedPlanIDs, err := t.subscribedServicePlanIDs(notDesiredPlanIDs)
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+	}
+
+	for _, systemName := range notDesiredExistingKeys {
+		planID := existingMap[systemName].ID
+
+		// Deleting the plan would cancel the subscriptions of the developer accounts.
+		// It is kept, and reported in the status, until the subscribers are moved to another plan
+		if subscribedPlanIDs[planID] {
+			t.logger.Info("service plan not deleted, it still has subscribers", "systemName", systemName, "planID", planID)
+			t.keptServicePlans = append(t.keptServicePlans, systemName)
+			continue
+		}
+
+		err := t.plansAPIClient.DeleteServicePlan(t.productEntity.ID(), planID)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+		}
+	}
+
+	return nil
+}
+
+// subscribedServicePlanIDs returns which of the service plans have developer accounts subscribed.
+// Looking up subscribers scans the developer accounts of the tenant, it is done at most once per reconciliation
+func (t *ProductThreescaleReconciler) subscribedServicePlanIDs(planIDs []int64) (map[int64]bool, error) {
+	if t.subscribedServicePlans != nil {
+		return t.subscribedServicePlans, nil
+	}
+
+	subscribed, err := t.plansAPIClient.SubscribedServicePlans(t.productEntity.ID(), planIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	t.subscribedServicePlans = subscribed
+	return subscribed, nil
+}
+
+func (t *ProductThreescaleReconciler) syncServicePlan(systemName string, desired capabilitiesv1beta1.ServicePlanSpec, existing controllerhelper.PlanItem) error {
+	params := planUpdateParams(planSpec{
+		Name:             desired.Name,
+		ApprovalRequired: desired.ApprovalRequired,
+		TrialPeriod:      desired.TrialPeriod,
+		SetupFee:         desired.SetupFee,
+		CostMonth:        desired.CostMonth,
+		Published:        desired.IsPublished(),
+	}, existing)
+	if len(params) > 0 {
+		t.logger.V(1).Info("syncServicePlan", "systemName", systemName, "params", params)
+		_, err := t.plansAPIClient.UpdateServicePlan(t.productEntity.ID(), existing.ID, params)
+		if err != nil {
+			return err
+		}
+	}
+
+	if desired.IsDefault() && !existing.Default {
+		t.logger.V(1).Info("syncServicePlan", "systemName", systemName, "default", true)
+		_, err := t.plansAPIClient.SetDefaultServicePlan(t.productEntity.ID(), existing.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
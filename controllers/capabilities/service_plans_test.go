@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProductThreescaleReconciler_syncServicePlans(t *testing.T) {
+	trueValue := true
+	premiumName := "Premium"
+
+	tests := []struct {
+		name         string
+		servicePlans map[string]capabilitiesv1beta1.ServicePlanSpec
+		subscribed   string
+		wantRequests []string
+		wantKept     []string
+	}{
+		{
+			name:         "service plans not managed",
+			servicePlans: nil,
+			wantRequests: []string{},
+			wantKept:     []string{},
+		},
+		{
+			name: "create, update and delete service plans",
+			servicePlans: map[string]capabilitiesv1beta1.ServicePlanSpec{
+				"basic":   {Published: &trueValue},
+				"premium": {Name: &premiumName, Default: &trueValue},
+			},
+			wantRequests: []string{
+				"DELETE /admin/api/services/10/service_plans/5.json",
+				"GET /admin/api/accounts.json",
+				"GET /admin/api/accounts/3/service_subscriptions.json",
+				"GET /admin/api/accounts/4/service_subscriptions.json",
+				"GET /admin/api/services/10/service_plans.json",
+				"POST /admin/api/services/10/service_plans.json name=premium&system_name=premium",
+				"PUT /admin/api/services/10/service_plans/7.json name=Premium",
+				"PUT /admin/api/services/10/service_plans/7/default.json",
+			},
+			wantKept: []string{},
+		},
+		{
+			name: "service plan with subscribers not deleted",
+			servicePlans: map[string]capabilitiesv1beta1.ServicePlanSpec{
+				"basic": {Published: &trueValue, Default: &trueValue},
+			},
+			subscribed: `{"service_subscription":{"id":1,"plan_id":5,"service_id":10,"state":"live"}},` +
+				`{"service_subscription":{"id":2,"plan_id":6,"service_id":11,"state":"live"}}`,
+			wantRequests: []string{
+				"GET /admin/api/accounts.json",
+				"GET /admin/api/accounts/3/service_subscriptions.json",
+				"GET /admin/api/services/10/service_plans.json",
+				"PUT /admin/api/services/10/service_plans/6/default.json",
+			},
+			// Accounts are not scanned any further once all the plans are found subscribed
+			wantKept: []string{"default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			requests := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					subT.Fatal(err)
+				}
+				request := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+				if len(r.PostForm) > 0 {
+					request = fmt.Sprintf("%s %s", request, r.PostForm.Encode())
+				}
+				requests = append(requests, request)
+
+				switch {
+				case r.URL.Path == "/admin/api/accounts.json":
+					fmt.Fprint(w, `{"accounts":[{"account":{"id":3,"state":"approved"}},{"account":{"id":4,"state":"approved"}}]}`)
+				case r.URL.Path == "/admin/api/accounts/3/service_subscriptions.json":
+					fmt.Fprint(w, `{"service_subscriptions":[`+tt.subscribed+`]}`)
+				case r.URL.Path == "/admin/api/accounts/4/service_subscriptions.json":
+					fmt.Fprint(w, `{"service_subscriptions":[]}`)
+				case r.Method == http.MethodGet:
+					fmt.Fprint(w, `{"plans":[`+
+						`{"service_plan":{"id":5,"name":"Default","system_name":"default","state":"published","default":true}},`+
+						`{"service_plan":{"id":6,"name":"basic","system_name":"basic","state":"published"}}]}`)
+				case r.Method == http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"service_plan":{"id":7,"name":"premium","system_name":"premium","state":"hidden"}}`)
+				default:
+					fmt.Fprint(w, `{"service_plan":{"id":7,"name":"Premium","system_name":"premium","state":"hidden"}}`)
+				}
+			}))
+			defer server.Close()
+
+			plansAPIClient, err := controllerhelper.NewPlansAPIClient(&controllerhelper.ProviderAccount{AdminURLStr: server.URL, Token: "token"}, false)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			logger := logf.Log.WithName("service plans test")
+			productEntity := controllerhelper.NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: 10}}, nil, logger)
+
+			reconciler := &ProductThreescaleReconciler{
+				resource: &capabilitiesv1beta1.Product{
+					Spec: capabilitiesv1beta1.ProductSpec{SystemName: "product", ServicePlans: tt.servicePlans},
+				},
+				productEntity:  productEntity,
+				plansAPIClient: plansAPIClient,
+				logger:         logger,
+			}
+
+			if err := reconciler.syncServicePlans(nil); err != nil {
+				subT.Fatal(err)
+			}
+
+			sort.Strings(requests)
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				subT.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			if !reflect.DeepEqual(reconciler.keptServicePlans, tt.wantKept) {
+				subT.Errorf("kept service plans = %v, want %v", reconciler.keptServicePlans, tt.wantKept)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -113,25 +114,11 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	// Reconcile whether or not the tenant should be removed
 	if tenantCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(tenantCR, tenantFinalizer) {
-		existingTenant, err := controllerhelper.FetchTenant(tenantCR.Status.TenantId, portaClient)
+		err = r.removeTenantFrom3scale(tenantCR, portaClient, reqLogger)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
-		// delete tenantCR if tenant is present in 3scale
-		if existingTenant != nil {
-			// do not attempt to delete tenant that is already scheduled for deletion
-			if existingTenant.Signup.Account.State != scheduledForDeletionState {
-				err := portaClient.DeleteTenant(tenantCR.Status.TenantId)
-				if err != nil {
-					r.EventRecorder().Eventf(tenantCR, corev1.EventTypeWarning, "Failed to delete tenant", "%v", err)
-					return ctrl.Result{}, err
-				}
-			} else {
-				reqLogger.Info("Removing tenant CR - tenant is already scheduled for deletion", "tenantID", existingTenant.Signup.Account.ID)
-			}
-		}
-
 		controllerutil.RemoveFinalizer(tenantCR, tenantFinalizer)
 		err = r.UpdateResource(tenantCR)
 		if err != nil {
@@ -174,11 +161,39 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	// If error did not occur and the status was updated, quit the reoncile loop since another reconcile is incoming
 	if !statusIsEqual {
-		return ctrl.Result{}, nil
+		return controllerhelper.WithResync(ctrl.Result{}, tenantCR.GetAnnotations()), nil
 	}
 
 	reqLogger.Info("Tenant reconciled successfully")
-	return ctrl.Result{}, nil
+	return controllerhelper.WithResync(ctrl.Result{}, tenantCR.GetAnnotations()), nil
+}
+
+func (r *TenantReconciler) removeTenantFrom3scale(tenantCR *capabilitiesv1alpha1.Tenant, portaClient *threescaleapi.ThreeScaleClient, logger logr.Logger) error {
+	if controllerhelper.IsOrphanDeletionPolicy(tenantCR.GetAnnotations()) {
+		logger.Info("tenant not deleted from 3scale, deletion policy is Orphan")
+		return nil
+	}
+
+	existingTenant, err := controllerhelper.FetchTenant(tenantCR.Status.TenantId, portaClient)
+	if err != nil {
+		return err
+	}
+
+	// delete tenantCR if tenant is present in 3scale
+	if existingTenant != nil {
+		// do not attempt to delete tenant that is already scheduled for deletion
+		if existingTenant.Signup.Account.State != scheduledForDeletionState {
+			err := portaClient.DeleteTenant(tenantCR.Status.TenantId)
+			if err != nil {
+				r.EventRecorder().Eventf(tenantCR, corev1.EventTypeWarning, "Failed to delete tenant", "%v", err)
+				return err
+			}
+		} else {
+			logger.Info("Removing tenant CR - tenant is already scheduled for deletion", "tenantID", existingTenant.Signup.Account.ID)
+		}
+	}
+
+	return nil
 }
 
 func (r *TenantReconciler) reconcileStatus(tenantCR *capabilitiesv1alpha1.Tenant, reconcileError error) (bool, error) {
@@ -249,6 +264,6 @@ func (r *TenantReconciler) setupPortaClient(tenantCR *capabilitiesv1alpha1.Tenan
 
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1alpha1.Tenant{}).
+		For(&capabilitiesv1alpha1.Tenant{}, builder.WithPredicates(controllerhelper.IgnoreLastSyncTimeUpdates())).
 		Complete(r)
 }
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
@@ -51,6 +52,8 @@ func (s *TenantStatusReconciler) calculateStatus() capabilitiesv1alpha1.TenantSt
 	status.Conditions = s.tenantResource.Status.Conditions.Copy()
 	status.Conditions.SetCondition(s.readyCondition())
 
+	status.LastSyncTime = controllerhelper.LastSyncTime(s.tenantResource.Status.LastSyncTime, s.reconcileError, s.tenantResource.GetAnnotations())
+
 	return status
 }
 
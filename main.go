@@ -239,6 +239,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClientAPIManagerBackupSchedule, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&appscontroller.APIManagerBackupScheduleReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("APIManagerBackupSchedule"),
+			discoveryClientAPIManagerBackupSchedule,
+			mgr.GetEventRecorderFor("APIManagerBackupSchedule")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "APIManagerBackupSchedule")
+		os.Exit(1)
+	}
+
 	discoveryClientAPIManagerRestore, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -368,6 +384,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClientAccountPlan, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.AccountPlanReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("AccountPlan"),
+			discoveryClientAccountPlan,
+			mgr.GetEventRecorderFor("AccountPlan")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AccountPlan")
+		os.Exit(1)
+	}
+
 	discoveryClientDeveloperAccount, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -0,0 +1,70 @@
+package apicastconfig
+
+// Configuration is the APIcast configuration file format,
+// the format loaded by APIcast from the THREESCALE_CONFIG_FILE file
+type Configuration struct {
+	Services []Service `json:"services"`
+}
+
+// Service is the configuration of a product
+type Service struct {
+	ID               int64  `json:"id"`
+	SystemName       string `json:"system_name"`
+	Name             string `json:"name"`
+	BackendVersion   string `json:"backend_version"`
+	DeploymentOption string `json:"deployment_option"`
+	Proxy            Proxy  `json:"proxy"`
+}
+
+// Proxy is the gateway configuration of a product
+type Proxy struct {
+	Hosts           []string `json:"hosts"`
+	Endpoint        string   `json:"endpoint,omitempty"`
+	SandboxEndpoint string   `json:"sandbox_endpoint,omitempty"`
+	HostnameRewrite string   `json:"hostname_rewrite,omitempty"`
+	SecretToken     string   `json:"secret_token,omitempty"`
+
+	CredentialsLocation string `json:"credentials_location"`
+	AuthUserKey         string `json:"auth_user_key"`
+	AuthAppID           string `json:"auth_app_id"`
+	AuthAppKey          string `json:"auth_app_key"`
+
+	OIDCIssuerEndpoint       string `json:"oidc_issuer_endpoint,omitempty"`
+	OIDCIssuerType           string `json:"oidc_issuer_type,omitempty"`
+	JwtClaimWithClientID     string `json:"jwt_claim_with_client_id,omitempty"`
+	JwtClaimWithClientIDType string `json:"jwt_claim_with_client_id_type,omitempty"`
+
+	ErrorStatusAuthFailed      int    `json:"error_status_auth_failed"`
+	ErrorHeadersAuthFailed     string `json:"error_headers_auth_failed"`
+	ErrorAuthFailed            string `json:"error_auth_failed"`
+	ErrorStatusAuthMissing     int    `json:"error_status_auth_missing"`
+	ErrorHeadersAuthMissing    string `json:"error_headers_auth_missing"`
+	ErrorAuthMissing           string `json:"error_auth_missing"`
+	ErrorStatusNoMatch         int    `json:"error_status_no_match"`
+	ErrorHeadersNoMatch        string `json:"error_headers_no_match"`
+	ErrorNoMatch               string `json:"error_no_match"`
+	ErrorStatusLimitsExceeded  int    `json:"error_status_limits_exceeded"`
+	ErrorHeadersLimitsExceeded string `json:"error_headers_limits_exceeded"`
+	ErrorLimitsExceeded        string `json:"error_limits_exceeded"`
+
+	ProxyRules  []ProxyRule `json:"proxy_rules"`
+	PolicyChain []Policy    `json:"policy_chain"`
+}
+
+// ProxyRule is a product or backend mapping rule
+type ProxyRule struct {
+	HTTPMethod       string `json:"http_method"`
+	Pattern          string `json:"pattern"`
+	MetricSystemName string `json:"metric_system_name"`
+	Delta            int    `json:"delta"`
+	Position         int    `json:"position"`
+	Last             bool   `json:"last"`
+	OwnerType        string `json:"owner_type"`
+}
+
+// Policy is an enabled policy of the policy chain
+type Policy struct {
+	Name          string                 `json:"name"`
+	Version       string                 `json:"version"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
@@ -0,0 +1,58 @@
+package apicastconfig
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	utilruntime.Must(capabilitiesv1beta1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+}
+
+// Decode reads the objects of a YAML or JSON stream with one or more documents.
+// Objects of kinds other than the capabilities custom resources and core objects are skipped
+func Decode(reader io.Reader) ([]runtime.Object, error) {
+	objects := []runtime.Object{}
+	deserializer := codecs.UniversalDeserializer()
+	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+
+	for {
+		document, err := yamlReader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		object, _, err := deserializer.Decode(document, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, object)
+	}
+}
@@ -0,0 +1,338 @@
+package apicastconfig
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+const (
+	routingPolicyName = "routing"
+	apicastPolicyName = "apicast"
+	builtinVersion    = "builtin"
+
+	productRuleOwnerType = "Proxy"
+	backendRuleOwnerType = "BackendApi"
+)
+
+// Renderer converts Product and Backend custom resources into the APIcast configuration
+// without connecting to any 3scale tenant.
+// Secrets referenced by the custom resources have to be added as well
+type Renderer struct {
+	logger   logr.Logger
+	products []*capabilitiesv1beta1.Product
+	// backend system name -> backend
+	backends map[string]*capabilitiesv1beta1.Backend
+	secrets  map[types.NamespacedName]*corev1.Secret
+	// namespaced name -> policy chain
+	policyChains map[types.NamespacedName]*capabilitiesv1beta1.PolicyChain
+}
+
+func NewRenderer(logger logr.Logger) *Renderer {
+	return &Renderer{
+		logger:   logger,
+		products: []*capabilitiesv1beta1.Product{},
+		backends: map[string]*capabilitiesv1beta1.Backend{},
+		secrets:  map[types.NamespacedName]*corev1.Secret{},
+
+		policyChains: map[types.NamespacedName]*capabilitiesv1beta1.PolicyChain{},
+	}
+}
+
+// Add adds Product, Backend, PolicyChain and Secret objects. Other objects are ignored
+func (r *Renderer) Add(objects ...runtime.Object) {
+	for _, object := range objects {
+		switch obj := object.(type) {
+		case *capabilitiesv1beta1.Product:
+			r.products = append(r.products, obj.DeepCopy())
+		case *capabilitiesv1beta1.Backend:
+			backend := obj.DeepCopy()
+			backend.SetDefaults(r.logger)
+			r.backends[backend.Spec.SystemName] = backend
+		case *capabilitiesv1beta1.PolicyChain:
+			r.policyChains[types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}] = obj.DeepCopy()
+		case *corev1.Secret:
+			// Secret manifests usually use stringData, merged into data by the API server
+			secret := obj.DeepCopy()
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			for key, value := range secret.StringData {
+				secret.Data[key] = []byte(value)
+			}
+			r.secrets[types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}] = secret
+		}
+	}
+}
+
+// Render returns the APIcast configuration with one service for each product.
+// Products not synchronized yet get the position in the configuration as ID
+func (r *Renderer) Render() (*Configuration, error) {
+	configuration := &Configuration{Services: []Service{}}
+
+	for idx, product := range r.products {
+		service, err := r.renderProduct(product, int64(idx+1))
+		if err != nil {
+			return nil, err
+		}
+		configuration.Services = append(configuration.Services, *service)
+	}
+
+	return configuration, nil
+}
+
+func (r *Renderer) renderProduct(product *capabilitiesv1beta1.Product, defaultID int64) (*Service, error) {
+	// Same defaults and validation applied by the product controller
+	product.SetDefaults(r.logger)
+	if fieldErrors := product.Validate(); len(fieldErrors) > 0 {
+		return nil, fmt.Errorf("product [%s] is not valid: %w", product.Name, fieldErrors.ToAggregate())
+	}
+
+	service := &Service{
+		ID:               defaultID,
+		SystemName:       product.Spec.SystemName,
+		Name:             product.Spec.Name,
+		BackendVersion:   "1",
+		DeploymentOption: "hosted",
+	}
+	if deploymentOption := product.Spec.DeploymentOption(); deploymentOption != nil {
+		service.DeploymentOption = *deploymentOption
+	}
+	if product.Status.ID != nil {
+		service.ID = *product.Status.ID
+	}
+	if authMode := product.Spec.AuthenticationMode(); authMode != nil {
+		service.BackendVersion = *authMode
+	}
+
+	proxy, err := r.renderProxy(product)
+	if err != nil {
+		return nil, err
+	}
+	service.Proxy = *proxy
+
+	return service, nil
+}
+
+func (r *Renderer) renderProxy(product *capabilitiesv1beta1.Product) (*Proxy, error) {
+	// Settings not set in the product keep the 3scale defaults.
+	// Same conversion used by the product controller
+	item, err := controllerhelper.DesiredProxyItem(product, controllerhelper.NewDefaultProxyItem(), r.secretGetter(product))
+	if err != nil {
+		return nil, fmt.Errorf("product [%s] proxy: %w", product.Name, err)
+	}
+
+	proxy := &Proxy{
+		Hosts:                      []string{},
+		Endpoint:                   item.Endpoint,
+		SandboxEndpoint:            item.SandboxEndpoint,
+		HostnameRewrite:            item.HostnameRewrite,
+		SecretToken:                item.SecretToken,
+		CredentialsLocation:        item.CredentialsLocation,
+		AuthUserKey:                item.AuthUserKey,
+		AuthAppID:                  item.AuthAppID,
+		AuthAppKey:                 item.AuthAppKey,
+		OIDCIssuerEndpoint:         item.OidcIssuerEndpoint,
+		OIDCIssuerType:             item.OidcIssuerType,
+		JwtClaimWithClientID:       item.JwtClaimWithClientID,
+		JwtClaimWithClientIDType:   item.JwtClaimWithClientIDType,
+		ErrorStatusAuthFailed:      item.ErrorStatusAuthFailed,
+		ErrorHeadersAuthFailed:     item.ErrorHeadersAuthFailed,
+		ErrorAuthFailed:            item.ErrorAuthFailed,
+		ErrorStatusAuthMissing:     item.ErrorStatusAuthMissing,
+		ErrorHeadersAuthMissing:    item.ErrorHeadersAuthMissing,
+		ErrorAuthMissing:           item.ErrorAuthMissing,
+		ErrorStatusNoMatch:         item.ErrorStatusNoMatch,
+		ErrorHeadersNoMatch:        item.ErrorHeadersNoMatch,
+		ErrorNoMatch:               item.ErrorNoMatch,
+		ErrorStatusLimitsExceeded:  item.ErrorStatusLimitsExceeded,
+		ErrorHeadersLimitsExceeded: item.ErrorHeadersLimitsExceeded,
+		ErrorLimitsExceeded:        item.ErrorLimitsExceeded,
+	}
+
+	for _, endpoint := range []string{proxy.Endpoint, proxy.SandboxEndpoint} {
+		if endpoint == "" {
+			continue
+		}
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("product [%s] public base URL: %w", product.Name, err)
+		}
+		proxy.Hosts = append(proxy.Hosts, endpointURL.Hostname())
+	}
+
+	proxy.ProxyRules, err = r.renderProxyRules(product)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy.PolicyChain, err = r.renderPolicyChain(product)
+	if err != nil {
+		return nil, err
+	}
+
+	return proxy, nil
+}
+
+// renderProxyRules returns the product mapping rules followed by the mapping rules of the used backends.
+// Backend mapping rule patterns are prefixed with the backend usage path
+func (r *Renderer) renderProxyRules(product *capabilitiesv1beta1.Product) ([]ProxyRule, error) {
+	rules := []ProxyRule{}
+
+	for _, spec := range product.Spec.MappingRules {
+		rules = append(rules, newProxyRule(spec, spec.Pattern, spec.MetricMethodRef, productRuleOwnerType, len(rules)+1))
+	}
+
+	for _, backendSystemName := range sortedBackendUsages(product) {
+		backend, ok := r.backends[backendSystemName]
+		if !ok {
+			return nil, fmt.Errorf("product [%s] backend usage [%s]: backend not found", product.Name, backendSystemName)
+		}
+
+		prefix := strings.TrimSuffix(product.Spec.BackendUsages[backendSystemName].Path, "/")
+		for _, spec := range backend.Spec.MappingRules {
+			// 3scale suffixes backend metrics with the backend ID
+			metricSystemName := spec.MetricMethodRef
+			if backend.Status.ID != nil {
+				metricSystemName = fmt.Sprintf("%s.%d", spec.MetricMethodRef, *backend.Status.ID)
+			}
+			rules = append(rules, newProxyRule(spec, prefix+spec.Pattern, metricSystemName, backendRuleOwnerType, len(rules)+1))
+		}
+	}
+
+	return rules, nil
+}
+
+func newProxyRule(spec capabilitiesv1beta1.MappingRuleSpec, pattern, metricSystemName, ownerType string, position int) ProxyRule {
+	// Same conversion used by the product and backend controllers
+	item := controllerhelper.NewMappingRuleItem(spec, position, 0)
+	return ProxyRule{
+		HTTPMethod:       item.HTTPMethod,
+		Pattern:          pattern,
+		MetricSystemName: metricSystemName,
+		Delta:            item.Delta,
+		Position:         item.Position,
+		Last:             item.Last,
+		OwnerType:        ownerType,
+	}
+}
+
+// renderPolicyChain returns the enabled policies of the product policy chain.
+// The routing policy to the used backends goes before the apicast policy
+func (r *Renderer) renderPolicyChain(product *capabilitiesv1beta1.Product) ([]Policy, error) {
+	policies, err := controllerhelper.ProductPolicies(product, r.policyChainGetter(product))
+	if err != nil {
+		return nil, fmt.Errorf("product [%s] policies: %w", product.Name, err)
+	}
+
+	policyList, err := controllerhelper.PoliciesConfigList(policies, r.secretGetter(product))
+	if err != nil {
+		return nil, fmt.Errorf("product [%s] policies: %w", product.Name, err)
+	}
+
+	routingPolicy, err := r.routingPolicy(product)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []Policy{}
+	for _, policy := range policyList.Policies {
+		if !policy.Enabled {
+			continue
+		}
+		if policy.Name == apicastPolicyName && routingPolicy != nil {
+			chain = append(chain, *routingPolicy)
+		}
+		chain = append(chain, Policy{Name: policy.Name, Version: policy.Version, Configuration: policy.Configuration})
+	}
+
+	return chain, nil
+}
+
+// routingPolicy routes requests to the backend with the longest matching usage path, nil without backend usages
+func (r *Renderer) routingPolicy(product *capabilitiesv1beta1.Product) (*Policy, error) {
+	backendSystemNames := sortedBackendUsages(product)
+	if len(backendSystemNames) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(backendSystemNames, func(i, j int) bool {
+		return len(product.Spec.BackendUsages[backendSystemNames[i]].Path) > len(product.Spec.BackendUsages[backendSystemNames[j]].Path)
+	})
+
+	rules := []interface{}{}
+	for _, backendSystemName := range backendSystemNames {
+		backend, ok := r.backends[backendSystemName]
+		if !ok {
+			return nil, fmt.Errorf("product [%s] backend usage [%s]: backend not found", product.Name, backendSystemName)
+		}
+
+		prefix := strings.TrimSuffix(product.Spec.BackendUsages[backendSystemName].Path, "/")
+		rule := map[string]interface{}{
+			"url": backend.Spec.PrivateBaseURL,
+			"condition": map[string]interface{}{
+				"operations": []interface{}{
+					map[string]interface{}{
+						"match": "path",
+						"op":    "matches",
+						"value": fmt.Sprintf("^(%s/.*|%s/?)", prefix, prefix),
+					},
+				},
+			},
+		}
+		if prefix != "" {
+			rule["replace_path"] = fmt.Sprintf("{{original_request.path | remove_first: '%s'}}", prefix)
+		}
+		rules = append(rules, rule)
+	}
+
+	return &Policy{
+		Name:          routingPolicyName,
+		Version:       builtinVersion,
+		Configuration: map[string]interface{}{"rules": rules},
+	}, nil
+}
+
+// secretGetter reads the added secrets. An empty namespace refers to the product namespace
+func (r *Renderer) secretGetter(product *capabilitiesv1beta1.Product) controllerhelper.SecretGetter {
+	return func(key types.NamespacedName) (*corev1.Secret, error) {
+		if key.Namespace == "" {
+			key.Namespace = product.Namespace
+		}
+
+		secret, ok := r.secrets[key]
+		if !ok {
+			return nil, fmt.Errorf("secret (ns: %s, name: %s) not found, secrets referenced by the product have to be rendered as well", key.Namespace, key.Name)
+		}
+		return secret, nil
+	}
+}
+
+// policyChainGetter reads the added policy chains of the product namespace
+func (r *Renderer) policyChainGetter(product *capabilitiesv1beta1.Product) controllerhelper.PolicyChainGetter {
+	return func(name string) (*capabilitiesv1beta1.PolicyChain, error) {
+		policyChain, ok := r.policyChains[types.NamespacedName{Name: name, Namespace: product.Namespace}]
+		if !ok {
+			return nil, fmt.Errorf("policy chain (ns: %s, name: %s) not found, policy chains referenced by the product have to be rendered as well", product.Namespace, name)
+		}
+		return policyChain, nil
+	}
+}
+
+func sortedBackendUsages(product *capabilitiesv1beta1.Product) []string {
+	backendSystemNames := make([]string, 0, len(product.Spec.BackendUsages))
+	for backendSystemName := range product.Spec.BackendUsages {
+		backendSystemNames = append(backendSystemNames, backendSystemName)
+	}
+	sort.Strings(backendSystemNames)
+	return backendSystemNames
+}
@@ -0,0 +1,280 @@
+package apicastconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testManifests = `
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Backend
+metadata:
+  name: pets
+spec:
+  name: Pets API
+  systemName: pets
+  privateBaseURL: https://pets.example.com
+  mappingRules:
+    - httpMethod: GET
+      pattern: /pets
+      metricMethodRef: hits
+      increment: 1
+---
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Backend
+metadata:
+  name: stores
+spec:
+  name: Stores API
+  systemName: stores
+  privateBaseURL: https://stores.example.com
+  mappingRules:
+    - httpMethod: POST
+      pattern: /orders
+      metricMethodRef: hits
+      increment: 2
+      last: true
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: cors-config
+  namespace: apis
+stringData:
+  configuration: '{"allow_origin":"*"}'
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ignored
+---
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Product
+metadata:
+  name: petstore
+  namespace: apis
+spec:
+  name: Pet Store
+  systemName: petstore
+  deployment:
+    apicastSelfManaged:
+      stagingPublicBaseURL: https://staging.petstore.example.com
+      productionPublicBaseURL: https://petstore.example.com
+      authentication:
+        userkey:
+          authUserKey: api-key
+          credentials: headers
+          gatewayResponse:
+            errorStatusNoMatch: 405
+  mappingRules:
+    - httpMethod: GET
+      pattern: /status$
+      metricMethodRef: hits
+      increment: 1
+  backendUsages:
+    pets:
+      path: /
+    stores:
+      path: /stores/
+  policies:
+    - name: cors
+      version: builtin
+      enabled: true
+      configurationRef:
+        name: cors-config
+    - name: logging
+      version: builtin
+      enabled: false
+      configuration: {}
+`
+
+func TestRender(t *testing.T) {
+	objects, err := Decode(strings.NewReader(testManifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 4 {
+		t.Fatalf("decoded objects = %d, want 4", len(objects))
+	}
+
+	renderer := NewRenderer(logr.Discard())
+	renderer.Add(objects...)
+
+	configuration, err := renderer.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configuration.Services) != 1 {
+		t.Fatalf("services = %d, want 1", len(configuration.Services))
+	}
+
+	service := configuration.Services[0]
+	if service.ID != 1 || service.SystemName != "petstore" || service.BackendVersion != "1" || service.DeploymentOption != "self_managed" {
+		t.Errorf("unexpected service %d %s %s %s", service.ID, service.SystemName, service.BackendVersion, service.DeploymentOption)
+	}
+
+	proxy := service.Proxy
+	if diff := cmp.Diff([]string{"petstore.example.com", "staging.petstore.example.com"}, proxy.Hosts); diff != "" {
+		t.Errorf("hosts (-want +got):\n%s", diff)
+	}
+	if proxy.AuthUserKey != "api-key" || proxy.CredentialsLocation != "headers" {
+		t.Errorf("unexpected authentication %s %s", proxy.AuthUserKey, proxy.CredentialsLocation)
+	}
+	if proxy.ErrorStatusNoMatch != 405 || proxy.ErrorStatusAuthFailed != 403 {
+		t.Errorf("unexpected gateway response %d %d", proxy.ErrorStatusNoMatch, proxy.ErrorStatusAuthFailed)
+	}
+
+	expectedRules := []ProxyRule{
+		{HTTPMethod: "GET", Pattern: "/status$", MetricSystemName: "hits", Delta: 1, Position: 1, OwnerType: "Proxy"},
+		{HTTPMethod: "GET", Pattern: "/pets", MetricSystemName: "hits", Delta: 1, Position: 2, OwnerType: "BackendApi"},
+		{HTTPMethod: "POST", Pattern: "/stores/orders", MetricSystemName: "hits", Delta: 2, Position: 3, Last: true, OwnerType: "BackendApi"},
+	}
+	if diff := cmp.Diff(expectedRules, proxy.ProxyRules); diff != "" {
+		t.Errorf("proxy rules (-want +got):\n%s", diff)
+	}
+
+	expectedChain := []Policy{
+		{Name: "cors", Version: "builtin", Configuration: map[string]interface{}{"allow_origin": "*"}},
+		{Name: "routing", Version: "builtin", Configuration: map[string]interface{}{"rules": []interface{}{
+			map[string]interface{}{
+				"url":          "https://stores.example.com",
+				"replace_path": "{{original_request.path | remove_first: '/stores'}}",
+				"condition": map[string]interface{}{"operations": []interface{}{
+					map[string]interface{}{"match": "path", "op": "matches", "value": "^(/stores/.*|/stores/?)"},
+				}},
+			},
+			map[string]interface{}{
+				"url": "https://pets.example.com",
+				"condition": map[string]interface{}{"operations": []interface{}{
+					map[string]interface{}{"match": "path", "op": "matches", "value": "^(/.*|/?)"},
+				}},
+			},
+		}}},
+		{Name: "apicast", Version: "builtin", Configuration: map[string]interface{}{}},
+	}
+	if diff := cmp.Diff(expectedChain, proxy.PolicyChain); diff != "" {
+		t.Errorf("policy chain (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderMissingReferences(t *testing.T) {
+	cases := []struct {
+		testName  string
+		manifests string
+		errSubstr string
+	}{
+		{
+			"backend not found",
+			`
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Product
+metadata:
+  name: petstore
+spec:
+  name: Pet Store
+  backendUsages:
+    pets:
+      path: /
+`,
+			"backend not found",
+		},
+		{
+			"secret not found",
+			`
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Product
+metadata:
+  name: petstore
+spec:
+  name: Pet Store
+  policies:
+    - name: cors
+      version: builtin
+      enabled: true
+      configurationRef:
+        name: cors-config
+`,
+			"secret (ns: , name: cors-config) not found",
+		},
+		{
+			"policy chain not found",
+			`
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Product
+metadata:
+  name: petstore
+spec:
+  name: Pet Store
+  policyChainRefs:
+    - name: shared
+`,
+			"policy chain (ns: , name: shared) not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			objects, err := Decode(strings.NewReader(tc.manifests))
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			renderer := NewRenderer(logr.Discard())
+			renderer.Add(objects...)
+
+			_, err = renderer.Render()
+			if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+				subT.Errorf("error = %v, want %q", err, tc.errSubstr)
+			}
+		})
+	}
+}
+
+func TestRenderPolicyChainRefs(t *testing.T) {
+	manifests := `
+apiVersion: capabilities.3scale.net/v1beta1
+kind: PolicyChain
+metadata:
+  name: shared
+spec:
+  policies:
+    - name: echo
+      version: builtin
+      enabled: true
+      configuration:
+        status: 200
+---
+apiVersion: capabilities.3scale.net/v1beta1
+kind: Product
+metadata:
+  name: petstore
+spec:
+  name: Pet Store
+  policyChainRefs:
+    - name: shared
+      position: prepend
+`
+	objects, err := Decode(strings.NewReader(manifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renderer := NewRenderer(logr.Discard())
+	renderer.Add(objects...)
+
+	configuration, err := renderer.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChain := []Policy{
+		{Name: "echo", Version: "builtin", Configuration: map[string]interface{}{"status": float64(200)}},
+		{Name: "apicast", Version: "builtin", Configuration: map[string]interface{}{}},
+	}
+	if diff := cmp.Diff(expectedChain, configuration.Services[0].Proxy.PolicyChain); diff != "" {
+		t.Errorf("policy chain (-want +got):\n%s", diff)
+	}
+}
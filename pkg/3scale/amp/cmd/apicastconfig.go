@@ -0,0 +1,84 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/apicastconfig"
+)
+
+var apicastConfigFiles []string
+
+// apicastConfigCmd represents the apicast-config command
+var apicastConfigCmd = &cobra.Command{
+	Use:   getAPIcastConfigUsage(),
+	Short: getAPIcastConfigShortDescription(),
+	Long:  getAPIcastConfigLongDescription(),
+	Args:  cobra.NoArgs,
+	RunE:  runAPIcastConfigCommand,
+}
+
+func getAPIcastConfigUsage() string {
+	return "apicast-config"
+}
+
+func getAPIcastConfigShortDescription() string {
+	return "render the APIcast configuration of Product and Backend custom resources"
+}
+
+func getAPIcastConfigLongDescription() string {
+	return `render the APIcast JSON configuration equivalent to Product and Backend custom resources
+without connecting to any 3scale tenant, using the same conversion applied by the operator.
+Files can hold several YAML or JSON documents. Secrets referenced by the products
+(policy configuration, OIDC issuer endpoint) have to be included in the files.
+Other objects are ignored`
+}
+
+func runAPIcastConfigCommand(cmd *cobra.Command, args []string) error {
+	renderer := apicastconfig.NewRenderer(logr.Discard())
+
+	for _, fileName := range apicastConfigFiles {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+
+		objects, err := apicastconfig.Decode(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+
+		renderer.Add(objects...)
+	}
+
+	configuration, err := renderer.Render()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(configuration)
+}
+
+func init() {
+	apicastConfigCmd.PersistentFlags().StringSliceVarP(&apicastConfigFiles, "filename", "f", nil, "Product, Backend, PolicyChain and Secret manifest files")
+	apicastConfigCmd.MarkPersistentFlagRequired("filename")
+	rootCmd.AddCommand(apicastConfigCmd)
+}
@@ -0,0 +1,95 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/export"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+var exportAdminURL string
+var exportToken string
+var exportNamespace string
+var exportProviderAccountRef string
+var exportInsecureSkipVerify bool
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   getExportUsage(),
+	Short: getExportShortDescription(),
+	Long:  getExportLongDescription(),
+	Args:  cobra.NoArgs,
+	RunE:  runExportCommand,
+}
+
+func getExportUsage() string {
+	return "export"
+}
+
+func getExportShortDescription() string {
+	return "export 3scale tenant configuration as capabilities custom resources"
+}
+
+func getExportLongDescription() string {
+	return `export products, backends, developer accounts, developer admin users and applications
+of an existing 3scale tenant as Product, Backend, DeveloperAccount, DeveloperUser and Application
+serialized custom resources.
+Developer user passwords cannot be exported, password secrets have to be created before applying`
+}
+
+func runExportCommand(cmd *cobra.Command, args []string) error {
+	portaClient, err := controllerhelper.PortaClientFromURLString(exportAdminURL, exportToken, exportInsecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	exporter := export.NewExporter(portaClient, export.Options{
+		Namespace:          exportNamespace,
+		ProviderAccountRef: exportProviderAccountRef,
+	})
+
+	objects, err := exporter.Export()
+	if err != nil {
+		return err
+	}
+
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, nil, nil,
+		json.SerializerOptions{Yaml: true, Pretty: true, Strict: true})
+	for _, object := range objects {
+		if _, err := fmt.Fprintln(os.Stdout, "---"); err != nil {
+			return err
+		}
+		if err := serializer.Encode(object, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	exportCmd.PersistentFlags().StringVar(&exportAdminURL, "admin-url", "", "3scale tenant admin portal URL")
+	exportCmd.PersistentFlags().StringVar(&exportToken, "token", "", "3scale tenant access token")
+	exportCmd.PersistentFlags().StringVar(&exportNamespace, "namespace", "", "Namespace to be used in the exported custom resources")
+	exportCmd.PersistentFlags().StringVar(&exportProviderAccountRef, "provider-account-ref", "", "Provider account secret name to be referenced by the exported custom resources")
+	exportCmd.PersistentFlags().BoolVar(&exportInsecureSkipVerify, "insecure-skip-verify", false, "Skip 3scale admin portal TLS certificate verification")
+	exportCmd.MarkFlagRequired("admin-url")
+	exportCmd.MarkFlagRequired("token")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -16,13 +16,16 @@ import (
 const (
 	BackendRedisDeploymentName = "backend-redis"
 	SystemRedisDeploymentName  = "system-redis"
+	BackendRedisPVCName        = "backend-redis-storage"
+	SystemRedisPVCName         = "system-redis-storage"
+	RedisDataPath              = "/var/lib/redis/data"
 )
 
 const (
 	redisConfigVolumeName              = "redis-config"
 	backendRedisObjectMetaName         = "backend-redis"
 	backendRedisDeploymentSelectorName = backendRedisObjectMetaName
-	backendRedisStorageVolumeName      = "backend-redis-storage"
+	backendRedisStorageVolumeName      = BackendRedisPVCName
 	backendRedisConfigMapKey           = "redis.conf"
 	backendRedisContainerName          = "backend-redis"
 	backendRedisConfigPath             = "/etc/redis.d/"
@@ -189,7 +192,7 @@ func (redis *Redis) buildPodContainerVolumeMounts() []v1.VolumeMount {
 			Name: backendRedisStorageVolumeName,
 			// https://github.com/sclorg/redis-container/ images have
 			// redis data directory hardcoded on /var/lib/redis/data
-			MountPath: "/var/lib/redis/data",
+			MountPath: RedisDataPath,
 		},
 		{
 			Name:      redisConfigVolumeName,
@@ -338,7 +341,7 @@ func (redis *Redis) SystemDeployment() *k8sappsv1.Deployment {
 							Name: "system-redis-storage",
 							VolumeSource: v1.VolumeSource{
 								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "system-redis-storage",
+									ClaimName: SystemRedisPVCName,
 									ReadOnly:  false,
 								},
 							},
@@ -369,7 +372,7 @@ func (redis *Redis) SystemDeployment() *k8sappsv1.Deployment {
 								{
 									Name:      "system-redis-storage",
 									ReadOnly:  false,
-									MountPath: "/var/lib/redis/data",
+									MountPath: RedisDataPath,
 								},
 								{
 									Name:      "redis-config",
@@ -447,7 +450,7 @@ func (redis *Redis) SystemPVC() *v1.PersistentVolumeClaim {
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "system-redis-storage",
+			Name:   SystemRedisPVCName,
 			Labels: redis.Options.SystemRedisLabels,
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
@@ -0,0 +1,664 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// Options customizes the exported custom resources
+type Options struct {
+	// Namespace of the exported custom resources. Not set when empty
+	Namespace string
+
+	// ProviderAccountRef is the name of the provider account credentials secret.
+	// When empty, custom resources use the default provider account
+	ProviderAccountRef string
+}
+
+// Exporter reads the 3scale configuration of a tenant and converts it into capabilities custom resources
+type Exporter struct {
+	client  *threescaleapi.ThreeScaleClient
+	options Options
+
+	// backend ID -> backend
+	backends map[int64]threescaleapi.BackendApiItem
+	// backend ID -> metric ID -> metric or method system name
+	backendMetrics map[int64]map[int64]string
+	// product ID -> product custom resource name
+	productObjNames map[int64]string
+	// application plan ID -> application plan system name
+	planSystemNames map[int64]string
+}
+
+func NewExporter(client *threescaleapi.ThreeScaleClient, options Options) *Exporter {
+	return &Exporter{
+		client:          client,
+		options:         options,
+		backends:        map[int64]threescaleapi.BackendApiItem{},
+		backendMetrics:  map[int64]map[int64]string{},
+		productObjNames: map[int64]string{},
+		planSystemNames: map[int64]string{},
+	}
+}
+
+// Export returns the Backend, Product, DeveloperAccount, DeveloperUser and Application custom resources
+// of the tenant. Objects are sorted so referenced objects come first
+func (e *Exporter) Export() ([]client.Object, error) {
+	objects := []client.Object{}
+
+	backends, err := e.exportBackends()
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, backends...)
+
+	products, err := e.exportProducts()
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, products...)
+
+	accounts, err := e.exportDeveloperAccounts()
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, accounts...)
+
+	return objects, nil
+}
+
+func (e *Exporter) exportBackends() ([]client.Object, error) {
+	backendList, err := e.client.ListBackendApis()
+	if err != nil {
+		return nil, fmt.Errorf("export backends: %w", err)
+	}
+
+	objects := []client.Object{}
+	for _, backendAPI := range backendList.Backends {
+		backend, err := e.backend(backendAPI.Element)
+		if err != nil {
+			return nil, fmt.Errorf("export backend [%s]: %w", backendAPI.Element.SystemName, err)
+		}
+		objects = append(objects, backend)
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) backend(item threescaleapi.BackendApiItem) (*capabilitiesv1beta1.Backend, error) {
+	e.backends[item.ID] = item
+
+	metricList, err := e.client.ListBackendapiMetrics(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hitsID, err := hitsMetricID(metricList)
+	if err != nil {
+		return nil, err
+	}
+
+	methodList, err := e.client.ListBackendapiMethods(item.ID, hitsID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingRuleList, err := e.client.ListBackendapiMappingRules(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, methods, metricSystemNames := convertMetrics(metricList, methodList)
+	e.backendMetrics[item.ID] = metricSystemNames
+
+	return &capabilitiesv1beta1.Backend{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.BackendKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: e.adoptingObjectMeta(objName("backend", item.SystemName, item.ID)),
+		Spec: capabilitiesv1beta1.BackendSpec{
+			Name:               item.Name,
+			SystemName:         item.SystemName,
+			PrivateBaseURL:     item.PrivateEndpoint,
+			Description:        item.Description,
+			Metrics:            metrics,
+			Methods:            methods,
+			MappingRules:       convertMappingRules(mappingRuleList, metricSystemNames),
+			ProviderAccountRef: e.providerAccountRef(),
+		},
+	}, nil
+}
+
+func (e *Exporter) exportProducts() ([]client.Object, error) {
+	productList, err := e.client.ListProducts()
+	if err != nil {
+		return nil, fmt.Errorf("export products: %w", err)
+	}
+
+	objects := []client.Object{}
+	for _, productObj := range productList.Products {
+		product, err := e.product(productObj.Element)
+		if err != nil {
+			return nil, fmt.Errorf("export product [%s]: %w", productObj.Element.SystemName, err)
+		}
+		objects = append(objects, product)
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) product(item threescaleapi.ProductItem) (*capabilitiesv1beta1.Product, error) {
+	name := objName("product", item.SystemName, item.ID)
+	e.productObjNames[item.ID] = name
+
+	metricList, err := e.client.ListProductMetrics(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hitsID, err := hitsMetricID(metricList)
+	if err != nil {
+		return nil, err
+	}
+
+	methodList, err := e.client.ListProductMethods(item.ID, hitsID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingRuleList, err := e.client.ListProductMappingRules(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := e.client.ProductProxy(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	backendUsageList, err := e.client.ListBackendapiUsages(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := e.client.Policies(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, methods, metricSystemNames := convertMetrics(metricList, methodList)
+
+	// metric ID -> metric reference of the product metrics and the metrics of the backends used by the product
+	metricRefs := map[int64]capabilitiesv1beta1.MetricMethodRefSpec{}
+	for id, systemName := range metricSystemNames {
+		metricRefs[id] = capabilitiesv1beta1.MetricMethodRefSpec{SystemName: systemName}
+	}
+
+	backendUsages := map[string]capabilitiesv1beta1.BackendUsageSpec{}
+	for _, usage := range backendUsageList {
+		backendAPI, ok := e.backends[usage.Element.BackendAPIID]
+		if !ok {
+			return nil, fmt.Errorf("backend ID %d not found", usage.Element.BackendAPIID)
+		}
+		backendUsages[backendAPI.SystemName] = capabilitiesv1beta1.BackendUsageSpec{Path: usage.Element.Path}
+
+		backendSystemName := backendAPI.SystemName
+		for id, systemName := range e.backendMetrics[backendAPI.ID] {
+			metricRefs[id] = capabilitiesv1beta1.MetricMethodRefSpec{SystemName: systemName, BackendSystemName: &backendSystemName}
+		}
+	}
+
+	applicationPlans, err := e.applicationPlans(item.ID, metricRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	productPolicies, err := convertPolicies(policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &capabilitiesv1beta1.Product{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       capabilitiesv1beta1.ProductKind,
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: e.adoptingObjectMeta(name),
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Name:               item.Name,
+			SystemName:         item.SystemName,
+			Description:        item.Description,
+			Deployment:         convertDeployment(item, proxy.Element),
+			Metrics:            metrics,
+			Methods:            methods,
+			MappingRules:       convertMappingRules(mappingRuleList, metricSystemNames),
+			BackendUsages:      backendUsages,
+			ApplicationPlans:   applicationPlans,
+			Policies:           productPolicies,
+			ProviderAccountRef: e.providerAccountRef(),
+		},
+	}, nil
+}
+
+func (e *Exporter) applicationPlans(productID int64, metricRefs map[int64]capabilitiesv1beta1.MetricMethodRefSpec) (map[string]capabilitiesv1beta1.ApplicationPlanSpec, error) {
+	planList, err := e.client.ListApplicationPlansByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := map[string]capabilitiesv1beta1.ApplicationPlanSpec{}
+	for _, plan := range planList.Plans {
+		// Custom plans are customizations of a plan for a single application
+		if plan.Element.Custom {
+			continue
+		}
+
+		limitList, err := e.client.ListApplicationPlansLimits(plan.Element.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		limits := []capabilitiesv1beta1.LimitSpec{}
+		for _, limit := range limitList.Limits {
+			ref, ok := metricRefs[limit.Element.MetricID]
+			if !ok {
+				return nil, fmt.Errorf("plan [%s] limit metric ID %d not found", plan.Element.SystemName, limit.Element.MetricID)
+			}
+			limits = append(limits, capabilitiesv1beta1.LimitSpec{
+				Period:          limit.Element.Period,
+				Value:           limit.Element.Value,
+				MetricMethodRef: ref,
+			})
+		}
+
+		ruleList, err := e.client.ListApplicationPlansPricingRules(plan.Element.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		pricingRules := []capabilitiesv1beta1.PricingRuleSpec{}
+		for _, rule := range ruleList.Rules {
+			ref, ok := metricRefs[rule.Element.MetricID]
+			if !ok {
+				return nil, fmt.Errorf("plan [%s] pricing rule metric ID %d not found", plan.Element.SystemName, rule.Element.MetricID)
+			}
+			pricingRules = append(pricingRules, capabilitiesv1beta1.PricingRuleSpec{
+				From:            rule.Element.Min,
+				To:              rule.Element.Max,
+				MetricMethodRef: ref,
+				PricePerUnit:    price(rule.Element.CostPerUnit),
+			})
+		}
+
+		name := plan.Element.Name
+		approvalRequired := plan.Element.ApprovalRequired
+		trialPeriod := plan.Element.TrialPeriodDays
+		setupFee := fmt.Sprintf("%.2f", plan.Element.SetupFee)
+		costMonth := fmt.Sprintf("%.2f", plan.Element.CostPerMonth)
+		published := plan.Element.State == "published"
+
+		plans[plan.Element.SystemName] = capabilitiesv1beta1.ApplicationPlanSpec{
+			Name:                &name,
+			AppsRequireApproval: &approvalRequired,
+			TrialPeriod:         &trialPeriod,
+			SetupFee:            &setupFee,
+			CostMonth:           &costMonth,
+			PricingRules:        pricingRules,
+			Limits:              limits,
+			Published:           &published,
+		}
+		e.planSystemNames[plan.Element.ID] = plan.Element.SystemName
+	}
+
+	return plans, nil
+}
+
+func (e *Exporter) exportDeveloperAccounts() ([]client.Object, error) {
+	accountList, err := e.client.ListDeveloperAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("export developer accounts: %w", err)
+	}
+
+	accounts := []client.Object{}
+	users := []client.Object{}
+	applications := []client.Object{}
+	for _, account := range accountList.Items {
+		if account.Element.ID == nil {
+			continue
+		}
+		accountID := *account.Element.ID
+		orgName := stringOrDefault(account.Element.OrgName, "")
+		name := objName("account", fmt.Sprintf("%s-%d", orgName, accountID), accountID)
+
+		accounts = append(accounts, &capabilitiesv1beta1.DeveloperAccount{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DeveloperAccount",
+				APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+			},
+			ObjectMeta: e.idObjectMeta(name, controllerhelper.AccountIDAnnotation, accountID),
+			Spec: capabilitiesv1beta1.DeveloperAccountSpec{
+				OrgName:                orgName,
+				MonthlyBillingEnabled:  account.Element.MonthlyBillingEnabled,
+				MonthlyChargingEnabled: account.Element.MonthlyChargingEnabled,
+				ProviderAccountRef:     e.providerAccountRef(),
+			},
+		})
+
+		accountUsers, err := e.developerAdminUsers(accountID, name)
+		if err != nil {
+			return nil, fmt.Errorf("export developer account [%s]: %w", orgName, err)
+		}
+		users = append(users, accountUsers...)
+
+		accountApplications, err := e.applications(accountID, name)
+		if err != nil {
+			return nil, fmt.Errorf("export developer account [%s]: %w", orgName, err)
+		}
+		applications = append(applications, accountApplications...)
+	}
+
+	objects := append(accounts, users...)
+	return append(objects, applications...), nil
+}
+
+// developerAdminUsers exports the admin users of the developer account.
+// Passwords cannot be read from 3scale, the password secrets have to be created before applying
+func (e *Exporter) developerAdminUsers(accountID int64, accountObjName string) ([]client.Object, error) {
+	userList, err := e.client.ListDeveloperUsers(accountID, threescaleapi.Params{"role": "admin"})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []client.Object{}
+	for _, user := range userList.Items {
+		if user.Element.ID == nil || stringOrDefault(user.Element.Role, "") != "admin" {
+			continue
+		}
+
+		username := stringOrDefault(user.Element.Username, "")
+		name := objName("user", fmt.Sprintf("%s-%s", accountObjName, username), *user.Element.ID)
+		role := "admin"
+
+		objects = append(objects, &capabilitiesv1beta1.DeveloperUser{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DeveloperUser",
+				APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+			},
+			ObjectMeta: e.idObjectMeta(name, controllerhelper.UserIDAnnotation, *user.Element.ID),
+			Spec: capabilitiesv1beta1.DeveloperUserSpec{
+				Username: username,
+				Email:    stringOrDefault(user.Element.Email, ""),
+				PasswordCredentialsRef: corev1.SecretReference{
+					Name:      fmt.Sprintf("%s-password", name),
+					Namespace: e.options.Namespace,
+				},
+				DeveloperAccountRef: corev1.LocalObjectReference{Name: accountObjName},
+				Suspended:           stringOrDefault(user.Element.State, "") == "suspended",
+				Role:                &role,
+				ProviderAccountRef:  e.providerAccountRef(),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) applications(accountID int64, accountObjName string) ([]client.Object, error) {
+	applicationList, err := e.client.ListApplications(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []client.Object{}
+	for _, application := range applicationList.Applications {
+		productObjName, ok := e.productObjNames[application.Application.ServiceID]
+		if !ok {
+			continue
+		}
+
+		// Applications subscribed to custom plans are not exported
+		planSystemName, ok := e.planSystemNames[application.Application.PlanID]
+		if !ok {
+			continue
+		}
+
+		objects = append(objects, &capabilitiesv1beta1.Application{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Application",
+				APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+			},
+			ObjectMeta: e.idObjectMeta(objName("application", fmt.Sprintf("%s-%d", application.Application.AppName, application.Application.ID), application.Application.ID), controllerhelper.ApplicationIDAnnotation, application.Application.ID),
+			Spec: capabilitiesv1beta1.ApplicationSpec{
+				AccountCR:           &corev1.LocalObjectReference{Name: accountObjName},
+				ProductCR:           &corev1.LocalObjectReference{Name: productObjName},
+				ApplicationPlanName: planSystemName,
+				Name:                application.Application.AppName,
+				Description:         application.Application.Description,
+				Suspend:             application.Application.State == "suspended",
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: e.options.Namespace,
+	}
+}
+
+// adoptingObjectMeta returns the object meta of custom resources taking over the existing 3scale objects
+func (e *Exporter) adoptingObjectMeta(name string) metav1.ObjectMeta {
+	objectMeta := e.objectMeta(name)
+	objectMeta.Annotations = map[string]string{controllerhelper.AdoptAnnotation: "true"}
+	return objectMeta
+}
+
+// idObjectMeta returns the object meta of custom resources linked to the existing 3scale objects by ID annotation
+func (e *Exporter) idObjectMeta(name, idAnnotation string, id int64) metav1.ObjectMeta {
+	objectMeta := e.objectMeta(name)
+	objectMeta.Annotations = map[string]string{idAnnotation: strconv.FormatInt(id, 10)}
+	return objectMeta
+}
+
+func (e *Exporter) providerAccountRef() *corev1.LocalObjectReference {
+	if e.options.ProviderAccountRef == "" {
+		return nil
+	}
+
+	return &corev1.LocalObjectReference{Name: e.options.ProviderAccountRef}
+}
+
+// objName converts a 3scale name into a valid custom resource name
+func objName(prefix, name string, id int64) string {
+	tmp := strings.NewReplacer("_", "-", " ", "-", ".", "-").Replace(name)
+	tmp = strings.Trim(helper.DNS1123Name(tmp), "-")
+	if tmp == "" {
+		return fmt.Sprintf("%s-%d", prefix, id)
+	}
+
+	return tmp
+}
+
+func hitsMetricID(metricList *threescaleapi.MetricJSONList) (int64, error) {
+	for _, metric := range metricList.Metrics {
+		if metric.Element.SystemName == "hits" {
+			return metric.Element.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("hits metric not found")
+}
+
+// convertMetrics splits the metric list into metrics and methods.
+// Returns the system names of metrics and methods by ID as well
+func convertMetrics(metricList *threescaleapi.MetricJSONList, methodList *threescaleapi.MethodList) (map[string]capabilitiesv1beta1.MetricSpec, map[string]capabilitiesv1beta1.MethodSpec, map[int64]string) {
+	systemNames := map[int64]string{}
+
+	methods := map[string]capabilitiesv1beta1.MethodSpec{}
+	for _, method := range methodList.Methods {
+		methods[method.Element.SystemName] = capabilitiesv1beta1.MethodSpec{
+			Name:        method.Element.Name,
+			Description: method.Element.Description,
+		}
+		systemNames[method.Element.ID] = method.Element.SystemName
+	}
+
+	metrics := map[string]capabilitiesv1beta1.MetricSpec{}
+	for _, metric := range metricList.Metrics {
+		systemNames[metric.Element.ID] = metric.Element.SystemName
+		// metric list includes methods
+		if _, ok := methods[metric.Element.SystemName]; ok {
+			continue
+		}
+
+		metrics[metric.Element.SystemName] = capabilitiesv1beta1.MetricSpec{
+			Name:        metric.Element.Name,
+			Unit:        metric.Element.Unit,
+			Description: metric.Element.Description,
+		}
+	}
+
+	return metrics, methods, systemNames
+}
+
+// convertMappingRules returns the mapping rules sorted by position
+func convertMappingRules(mappingRuleList *threescaleapi.MappingRuleJSONList, metricSystemNames map[int64]string) []capabilitiesv1beta1.MappingRuleSpec {
+	items := make([]threescaleapi.MappingRuleItem, 0, len(mappingRuleList.MappingRules))
+	for _, rule := range mappingRuleList.MappingRules {
+		items = append(items, rule.Element)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+
+	mappingRules := make([]capabilitiesv1beta1.MappingRuleSpec, 0, len(items))
+	for _, item := range items {
+		rule := capabilitiesv1beta1.MappingRuleSpec{
+			HTTPMethod:      item.HTTPMethod,
+			Pattern:         item.Pattern,
+			MetricMethodRef: metricSystemNames[item.MetricID],
+			Increment:       item.Delta,
+		}
+		if item.Last {
+			last := true
+			rule.Last = &last
+		}
+		mappingRules = append(mappingRules, rule)
+	}
+
+	return mappingRules
+}
+
+func convertDeployment(item threescaleapi.ProductItem, proxy threescaleapi.ProxyItem) *capabilitiesv1beta1.ProductDeploymentSpec {
+	authentication := convertAuthentication(item.BackendVersion, proxy)
+
+	if item.DeploymentOption == "hosted" {
+		return &capabilitiesv1beta1.ProductDeploymentSpec{
+			ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{Authentication: authentication},
+		}
+	}
+
+	selfManaged := &capabilitiesv1beta1.ApicastSelfManagedSpec{Authentication: authentication}
+	if proxy.SandboxEndpoint != "" {
+		selfManaged.StagingPublicBaseURL = &proxy.SandboxEndpoint
+	}
+	if proxy.Endpoint != "" {
+		selfManaged.ProductionPublicBaseURL = &proxy.Endpoint
+	}
+
+	return &capabilitiesv1beta1.ProductDeploymentSpec{ApicastSelfManaged: selfManaged}
+}
+
+func convertAuthentication(backendVersion string, proxy threescaleapi.ProxyItem) *capabilitiesv1beta1.AuthenticationSpec {
+	credentialsLocation := optionalString(proxy.CredentialsLocation)
+
+	switch backendVersion {
+	case "1":
+		return &capabilitiesv1beta1.AuthenticationSpec{
+			UserKeyAuthentication: &capabilitiesv1beta1.UserKeyAuthenticationSpec{
+				Key:            optionalString(proxy.AuthUserKey),
+				CredentialsLoc: credentialsLocation,
+			},
+		}
+	case "2":
+		return &capabilitiesv1beta1.AuthenticationSpec{
+			AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+				AppID:          optionalString(proxy.AuthAppID),
+				AppKey:         optionalString(proxy.AuthAppKey),
+				CredentialsLoc: credentialsLocation,
+			},
+		}
+	case "oidc":
+		return &capabilitiesv1beta1.AuthenticationSpec{
+			OIDC: &capabilitiesv1beta1.OIDCSpec{
+				IssuerType:               proxy.OidcIssuerType,
+				IssuerEndpoint:           proxy.OidcIssuerEndpoint,
+				JwtClaimWithClientID:     optionalString(proxy.JwtClaimWithClientID),
+				JwtClaimWithClientIDType: optionalString(proxy.JwtClaimWithClientIDType),
+				CredentialsLoc:           credentialsLocation,
+			},
+		}
+	}
+
+	return nil
+}
+
+func convertPolicies(policies *threescaleapi.PoliciesConfigList) ([]capabilitiesv1beta1.PolicyConfig, error) {
+	productPolicies := make([]capabilitiesv1beta1.PolicyConfig, 0, len(policies.Policies))
+	for _, policy := range policies.Policies {
+		configuration, err := json.Marshal(policy.Configuration)
+		if err != nil {
+			return nil, err
+		}
+
+		productPolicies = append(productPolicies, capabilitiesv1beta1.PolicyConfig{
+			Name:          policy.Name,
+			Version:       policy.Version,
+			Enabled:       policy.Enabled,
+			Configuration: runtime.RawExtension{Raw: configuration},
+		})
+	}
+
+	return productPolicies, nil
+}
+
+// price formats 3scale prices with two decimals as required by the CRDs
+func price(value string) string {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+
+	return fmt.Sprintf("%.2f", parsed)
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func stringOrDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
@@ -0,0 +1,210 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/google/go-cmp/cmp"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+type RoundTripFunc func(req *http.Request) *http.Response
+
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func strPtr(s string) *string { return &s }
+
+func testResponses() map[string]interface{} {
+	return map[string]interface{}{
+		"/admin/api/backend_apis.json": &threescaleapi.BackendApiList{Backends: []threescaleapi.BackendApi{
+			{Element: threescaleapi.BackendApiItem{ID: 10, Name: "Pets API", SystemName: "pets_api", PrivateEndpoint: "https://pets.example.com"}},
+		}},
+		"/admin/api/backend_apis/10/metrics.json": &threescaleapi.MetricJSONList{Metrics: []threescaleapi.MetricJSON{
+			{Element: threescaleapi.MetricItem{ID: 11, Name: "Hits", SystemName: "hits", Unit: "hit"}},
+			{Element: threescaleapi.MetricItem{ID: 12, Name: "List pets", SystemName: "list_pets", Unit: "hit"}},
+		}},
+		"/admin/api/backend_apis/10/metrics/11/methods.json": &threescaleapi.MethodList{Methods: []threescaleapi.Method{
+			{Element: threescaleapi.MethodItem{ID: 12, Name: "List pets", SystemName: "list_pets"}},
+		}},
+		"/admin/api/backend_apis/10/mapping_rules.json": &threescaleapi.MappingRuleJSONList{MappingRules: []threescaleapi.MappingRuleJSON{
+			{Element: threescaleapi.MappingRuleItem{MetricID: 11, Pattern: "/", HTTPMethod: "GET", Delta: 1, Position: 2}},
+			{Element: threescaleapi.MappingRuleItem{MetricID: 12, Pattern: "/pets$", HTTPMethod: "GET", Delta: 1, Position: 1, Last: true}},
+		}},
+		"/admin/api/services.json": &threescaleapi.ProductList{Products: []threescaleapi.Product{
+			{Element: threescaleapi.ProductItem{ID: 20, Name: "Pet Store", SystemName: "pet_store", DeploymentOption: "hosted", BackendVersion: "1"}},
+		}},
+		"/admin/api/services/20/metrics.json": &threescaleapi.MetricJSONList{Metrics: []threescaleapi.MetricJSON{
+			{Element: threescaleapi.MetricItem{ID: 21, Name: "Hits", SystemName: "hits", Unit: "hit"}},
+		}},
+		"/admin/api/services/20/metrics/21/methods.json":  &threescaleapi.MethodList{},
+		"/admin/api/services/20/proxy/mapping_rules.json": &threescaleapi.MappingRuleJSONList{},
+		"/admin/api/services/20/proxy.json": &threescaleapi.ProxyJSON{Element: threescaleapi.ProxyItem{
+			CredentialsLocation: "headers", AuthUserKey: "api-key",
+		}},
+		"/admin/api/services/20/backend_usages.json": threescaleapi.BackendAPIUsageList{
+			{Element: threescaleapi.BackendAPIUsageItem{Path: "/v1", BackendAPIID: 10}},
+		},
+		"/admin/api/services/20/proxy/policies.json": &threescaleapi.PoliciesConfigList{Policies: []threescaleapi.PolicyConfig{
+			{Name: "apicast", Version: "builtin", Enabled: true, Configuration: map[string]interface{}{}},
+		}},
+		"/admin/api/services/20/application_plans.json": &threescaleapi.ApplicationPlanJSONList{Plans: []threescaleapi.ApplicationPlan{
+			{Element: threescaleapi.ApplicationPlanItem{ID: 30, Name: "Basic", SystemName: "basic", State: "published", CostPerMonth: 10}},
+			{Element: threescaleapi.ApplicationPlanItem{ID: 31, Name: "Custom", SystemName: "custom", Custom: true}},
+		}},
+		"/admin/api/application_plans/30/limits.json": &threescaleapi.ApplicationPlanLimitList{Limits: []threescaleapi.ApplicationPlanLimit{
+			{Element: threescaleapi.ApplicationPlanLimitItem{Period: "day", Value: 100, MetricID: 12}},
+		}},
+		"/admin/api/application_plans/30/pricing_rules.json": &threescaleapi.ApplicationPlanPricingRuleList{Rules: []threescaleapi.ApplicationPlanPricingRule{
+			{Element: threescaleapi.ApplicationPlanPricingRuleItem{MetricID: 21, CostPerUnit: "0.5", Min: 1, Max: 10}},
+		}},
+		"/admin/api/accounts.json": &threescaleapi.DeveloperAccountList{Items: []threescaleapi.DeveloperAccount{
+			{Element: threescaleapi.DeveloperAccountItem{ID: int64Ptr(40), OrgName: strPtr("ACME Corp")}},
+		}},
+		"/admin/api/accounts/40/users.json": &threescaleapi.DeveloperUserList{Items: []threescaleapi.DeveloperUser{
+			{Element: threescaleapi.DeveloperUserItem{ID: int64Ptr(41), Username: strPtr("john"), Email: strPtr("john@example.com"), Role: strPtr("admin"), State: strPtr("active")}},
+		}},
+		"/admin/api/accounts/40/applications.json": &threescaleapi.ApplicationList{Applications: []threescaleapi.ApplicationElem{
+			{Application: threescaleapi.Application{ID: 50, AppName: "Mobile", ServiceID: 20, PlanID: 30, State: "live"}},
+			{Application: threescaleapi.Application{ID: 51, AppName: "Custom", ServiceID: 20, PlanID: 31, State: "live"}},
+		}},
+	}
+}
+
+func TestExporterExport(t *testing.T) {
+	responses := testResponses()
+	httpClient := &http.Client{Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+		respObject, ok := responses[req.URL.Path]
+		if !ok {
+			t.Fatalf("unexpected request %s", req.URL.Path)
+		}
+
+		responseBodyBytes, err := json.Marshal(respObject)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBuffer(responseBodyBytes)),
+		}
+	})}
+
+	ap, err := threescaleapi.NewAdminPortalFromStr("https://www.test.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := NewExporter(threescaleapi.NewThreeScale(ap, "12345", httpClient), Options{
+		Namespace:          "operator-test",
+		ProviderAccountRef: "mytenant",
+	})
+
+	objects, err := exporter.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 5 {
+		t.Fatalf("exported %d objects, want 5", len(objects))
+	}
+
+	backend, ok := objects[0].(*capabilitiesv1beta1.Backend)
+	if !ok {
+		t.Fatalf("first object is not a backend: %T", objects[0])
+	}
+	if backend.Name != "pets-api" || backend.Namespace != "operator-test" {
+		t.Errorf("unexpected backend name %s/%s", backend.Namespace, backend.Name)
+	}
+	if backend.Annotations[controllerhelper.AdoptAnnotation] != "true" {
+		t.Errorf("backend does not adopt the existing 3scale backend")
+	}
+	if _, ok := backend.Spec.Metrics["list_pets"]; ok {
+		t.Errorf("backend method exported as metric")
+	}
+	expectedMappingRules := []capabilitiesv1beta1.MappingRuleSpec{
+		{HTTPMethod: "GET", Pattern: "/pets$", MetricMethodRef: "list_pets", Increment: 1, Last: &[]bool{true}[0]},
+		{HTTPMethod: "GET", Pattern: "/", MetricMethodRef: "hits", Increment: 1},
+	}
+	if diff := cmp.Diff(expectedMappingRules, backend.Spec.MappingRules); diff != "" {
+		t.Errorf("unexpected backend mapping rules (-want +got):\n%s", diff)
+	}
+
+	product, ok := objects[1].(*capabilitiesv1beta1.Product)
+	if !ok {
+		t.Fatalf("second object is not a product: %T", objects[1])
+	}
+	if product.Name != "pet-store" || product.Spec.ProviderAccountRef.Name != "mytenant" {
+		t.Errorf("unexpected product %s with provider account %v", product.Name, product.Spec.ProviderAccountRef)
+	}
+	if product.Spec.BackendUsages["pets_api"].Path != "/v1" {
+		t.Errorf("unexpected backend usages %v", product.Spec.BackendUsages)
+	}
+	if product.Spec.Deployment.ApicastHosted.Authentication.UserKeyAuthentication.Key == nil {
+		t.Errorf("user key authentication not exported")
+	}
+	if _, ok := product.Spec.ApplicationPlans["custom"]; ok {
+		t.Errorf("custom plan exported")
+	}
+
+	plan := product.Spec.ApplicationPlans["basic"]
+	if *plan.CostMonth != "10.00" || !*plan.Published {
+		t.Errorf("unexpected plan cost %s published %t", *plan.CostMonth, *plan.Published)
+	}
+	backendSystemName := "pets_api"
+	expectedLimits := []capabilitiesv1beta1.LimitSpec{
+		{Period: "day", Value: 100, MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "list_pets", BackendSystemName: &backendSystemName}},
+	}
+	if diff := cmp.Diff(expectedLimits, plan.Limits); diff != "" {
+		t.Errorf("unexpected plan limits (-want +got):\n%s", diff)
+	}
+	expectedPricingRules := []capabilitiesv1beta1.PricingRuleSpec{
+		{From: 1, To: 10, MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "hits"}, PricePerUnit: "0.50"},
+	}
+	if diff := cmp.Diff(expectedPricingRules, plan.PricingRules); diff != "" {
+		t.Errorf("unexpected plan pricing rules (-want +got):\n%s", diff)
+	}
+
+	account, ok := objects[2].(*capabilitiesv1beta1.DeveloperAccount)
+	if !ok {
+		t.Fatalf("third object is not a developer account: %T", objects[2])
+	}
+	if account.Name != "acme-corp-40" {
+		t.Errorf("unexpected account name %s", account.Name)
+	}
+	if account.Annotations[controllerhelper.AccountIDAnnotation] != "40" {
+		t.Errorf("developer account not linked to the existing 3scale account: %v", account.Annotations)
+	}
+
+	user, ok := objects[3].(*capabilitiesv1beta1.DeveloperUser)
+	if !ok {
+		t.Fatalf("fourth object is not a developer user: %T", objects[3])
+	}
+	if user.Spec.DeveloperAccountRef.Name != account.Name || user.Spec.PasswordCredentialsRef.Name != "acme-corp-40-john-password" {
+		t.Errorf("unexpected developer user references %v %v", user.Spec.DeveloperAccountRef, user.Spec.PasswordCredentialsRef)
+	}
+	if user.Annotations[controllerhelper.UserIDAnnotation] != "41" {
+		t.Errorf("developer user not linked to the existing 3scale user: %v", user.Annotations)
+	}
+
+	application, ok := objects[4].(*capabilitiesv1beta1.Application)
+	if !ok {
+		t.Fatalf("fifth object is not an application: %T", objects[4])
+	}
+	if application.Spec.AccountCR.Name != account.Name || application.Spec.ProductCR.Name != product.Name || application.Spec.ApplicationPlanName != "basic" {
+		t.Errorf("unexpected application references %v %v %s", application.Spec.AccountCR, application.Spec.ProductCR, application.Spec.ApplicationPlanName)
+	}
+	if application.Annotations[controllerhelper.ApplicationIDAnnotation] != "50" {
+		t.Errorf("application not linked to the existing 3scale application: %v", application.Annotations)
+	}
+}
@@ -9,7 +9,9 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	apps "github.com/3scale/3scale-operator/apis/apps"
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
 )
 
@@ -17,6 +19,7 @@ const BackupPVCMountPath = "/backup"
 const SystemFileStoragePVCMountPath = "/system-filestorage-pvc"
 const APIManagerSerializedBackupFileName = "apimanager-backup.json"
 const ServiceAccountName = "apimanager-backup"
+const backupDataVolumeName = "backup-data"
 
 var secretsToBackup map[string]string = map[string]string{
 	"SystemSMTP":          "system-smtp",
@@ -264,6 +267,346 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 	}
 }
 
+// BackupS3Location returns the location of the backup data in the S3
+// API-compatible object storage, in s3://<bucket>/<path> form
+func (b *APIManagerBackup) BackupS3Location() string {
+	if b.options.APIManagerBackupS3Options == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("s3://%s/%s", b.options.APIManagerBackupS3Options.Bucket, b.options.APIManagerBackupS3Options.Path)
+}
+
+func (b *APIManagerBackup) BackupSecretsAndConfigMapsToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-cfgmaps-secrets-s3",
+		b.ocCLIBackupContainer("backup-cfgmaps-secrets", b.backupSecretsAndConfigMapsContainerArgs(), nil),
+		nil,
+	)
+}
+
+func (b *APIManagerBackup) BackupAPIManagerCustomResourceToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-apimanager-cr-s3",
+		b.ocCLIBackupContainer("backup-apimanager-cr", b.backupAPIManagerCustomResourceContainerArgs(), nil),
+		nil,
+	)
+}
+
+func (b *APIManagerBackup) BackupSystemFileStoragePVCToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-system-fs-pvc-s3",
+		b.ocCLIBackupContainer("backup-system-filestorage-pvc", b.backupSystemFilestoragePVCContainerArgs(),
+			[]v1.VolumeMount{b.systemFileStorageContainerVolumeMount()},
+		),
+		[]v1.Volume{b.systemFileStoragePodVolume()},
+	)
+}
+
+func (b *APIManagerBackup) BackupSystemDatabaseToPVCJob() *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil || b.options.SystemDatabaseDumpOptions == nil {
+		return nil
+	}
+
+	return b.pvcBackupJob("backup-system-db-pvc", b.databaseDumpContainer(b.options.SystemDatabaseDumpOptions))
+}
+
+func (b *APIManagerBackup) BackupZyncDatabaseToPVCJob() *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil || b.options.ZyncDatabaseDumpOptions == nil {
+		return nil
+	}
+
+	return b.pvcBackupJob("backup-zync-db-pvc", b.databaseDumpContainer(b.options.ZyncDatabaseDumpOptions))
+}
+
+func (b *APIManagerBackup) BackupSystemDatabaseToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil || b.options.SystemDatabaseDumpOptions == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-system-db-s3", b.databaseDumpContainer(b.options.SystemDatabaseDumpOptions), nil)
+}
+
+func (b *APIManagerBackup) BackupZyncDatabaseToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil || b.options.ZyncDatabaseDumpOptions == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-zync-db-s3", b.databaseDumpContainer(b.options.ZyncDatabaseDumpOptions), nil)
+}
+
+func (b *APIManagerBackup) BackupBackendRedisToPVCJob() *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil || b.options.BackendRedisSnapshotOptions == nil {
+		return nil
+	}
+
+	return b.pvcBackupJob("backup-backend-redis-pvc", b.redisSnapshotContainer(b.options.BackendRedisSnapshotOptions))
+}
+
+func (b *APIManagerBackup) BackupSystemRedisToPVCJob() *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil || b.options.SystemRedisSnapshotOptions == nil {
+		return nil
+	}
+
+	return b.pvcBackupJob("backup-system-redis-pvc", b.redisSnapshotContainer(b.options.SystemRedisSnapshotOptions))
+}
+
+func (b *APIManagerBackup) BackupBackendRedisToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil || b.options.BackendRedisSnapshotOptions == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-backend-redis-s3", b.redisSnapshotContainer(b.options.BackendRedisSnapshotOptions), nil)
+}
+
+func (b *APIManagerBackup) BackupSystemRedisToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil || b.options.SystemRedisSnapshotOptions == nil {
+		return nil
+	}
+
+	return b.s3BackupJob("backup-system-redis-s3", b.redisSnapshotContainer(b.options.SystemRedisSnapshotOptions), nil)
+}
+
+// pvcBackupJob returns a Job that runs the given backup container with the
+// backup data PVC mounted
+func (b *APIManagerBackup) pvcBackupJob(jobNamePrefix string, backupContainer v1.Container) *batchv1.Job {
+	jobName, err := helper.UIDBasedJobName(jobNamePrefix, b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	backupContainer.VolumeMounts = append([]v1.VolumeMount{b.pvcBackupDestinationContainerVolumeMount()}, backupContainer.VolumeMounts...)
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.pvcBackupDestinationPodVolume(),
+					},
+					Containers: []v1.Container{
+						backupContainer,
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+// s3BackupJob returns a Job that runs the given backup container as an init
+// container, storing the result in a temporary volume mounted at the same
+// path the backup data PVC would be mounted, and then uploads the content of
+// that volume to the S3 backup data destination
+func (b *APIManagerBackup) s3BackupJob(jobNamePrefix string, backupContainer v1.Container, volumes []v1.Volume) *batchv1.Job {
+	jobName, err := helper.UIDBasedJobName(jobNamePrefix, b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	backupContainer.VolumeMounts = append([]v1.VolumeMount{b.backupDataContainerVolumeMount()}, backupContainer.VolumeMounts...)
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: append([]v1.Volume{b.backupDataPodVolume()}, volumes...),
+					InitContainers: []v1.Container{
+						backupContainer,
+					},
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "upload-to-s3",
+							Image: b.options.AWSCLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								b.uploadToS3ContainerArgs(),
+							},
+							Env: b.s3ContainerEnv(),
+							VolumeMounts: []v1.VolumeMount{
+								b.backupDataContainerVolumeMount(),
+							},
+						},
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+func (b *APIManagerBackup) ocCLIBackupContainer(name, args string, volumeMounts []v1.VolumeMount) v1.Container {
+	return v1.Container{
+		Name:  name,
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			args,
+		},
+		VolumeMounts: volumeMounts,
+	}
+}
+
+// databaseDumpContainer returns a container that dumps the database into
+// the backup data. It uses the database image so the client tools match
+// the server version
+func (b *APIManagerBackup) databaseDumpContainer(dumpOptions *DatabaseDumpOptions) v1.Container {
+	return v1.Container{
+		Name:  fmt.Sprintf("backup-%s", dumpOptions.Name),
+		Image: dumpOptions.ImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.databaseDumpContainerArgs(dumpOptions),
+		},
+		Env: []v1.EnvVar{
+			dumpOptions.DatabaseURLEnvVar(),
+		},
+	}
+}
+
+func (b *APIManagerBackup) databaseDumpContainerArgs(dumpOptions *DatabaseDumpOptions) string {
+	dumpCommand := `pg_dump --clean --if-exists --no-owner --no-privileges -d "${DATABASE_URL}" -f "${DUMP_FILE}";`
+	if dumpOptions.Engine == DatabaseEngineMySQL {
+		dumpCommand = `mysqldump -h "${DB_HOST}" -P "${DB_PORT}" -u "${DB_USER}" --single-transaction --routines --triggers --databases "${DB_NAME}" > "${DUMP_FILE}";`
+	}
+
+	return fmt.Sprintf(`
+DUMP_FILE='%s';
+mkdir -p $(dirname ${DUMP_FILE});
+%s
+%s
+`,
+		dumpOptions.DumpFilePath(BackupPVCMountPath),
+		dumpOptions.ConnectionEnvScript(),
+		dumpCommand,
+	) + checksumsScript(dumpOptions.Name, dumpOptions.DumpFilePath("."))
+}
+
+// redisSnapshotContainer returns a container that triggers a BGSAVE in the
+// running Redis pod and copies the resulting RDB file into the backup data
+func (b *APIManagerBackup) redisSnapshotContainer(snapshotOptions *RedisSnapshotOptions) v1.Container {
+	return b.ocCLIBackupContainer(fmt.Sprintf("backup-%s", snapshotOptions.Name), b.redisSnapshotContainerArgs(snapshotOptions), nil)
+}
+
+func (b *APIManagerBackup) redisSnapshotContainerArgs(snapshotOptions *RedisSnapshotOptions) string {
+	return fmt.Sprintf(`
+DNAME='%s';
+SNAPSHOT_FILE='%s';
+REDIS_RDB_FILE='%s/dump.rdb';
+PODNAME=$(oc get pods --ignore-not-found=true -l deployment=${DNAME} --field-selector=status.phase=Running --no-headers=true -o custom-columns=:metadata.name | head -n 1);
+if [ -z "${PODNAME}" ]; then
+	echo "No running pods found for Deployment ${DNAME}";
+	exit 1;
+fi;
+LASTSAVE=$(oc exec ${PODNAME} -- redis-cli LASTSAVE);
+oc exec ${PODNAME} -- redis-cli BGSAVE SCHEDULE;
+until [ "$(oc exec ${PODNAME} -- redis-cli LASTSAVE)" != "${LASTSAVE}" ]; do
+	sleep 1;
+done;
+if ! oc exec ${PODNAME} -- redis-cli INFO persistence | grep -q 'rdb_last_bgsave_status:ok'; then
+	echo "BGSAVE failed in Deployment ${DNAME}";
+	exit 1;
+fi;
+mkdir -p $(dirname ${SNAPSHOT_FILE});
+oc exec ${PODNAME} -- cat ${REDIS_RDB_FILE} > ${SNAPSHOT_FILE};
+`,
+		snapshotOptions.DeploymentName,
+		snapshotOptions.SnapshotFilePath(BackupPVCMountPath),
+		component.RedisDataPath,
+	) + checksumsScript(snapshotOptions.Name, snapshotOptions.SnapshotFilePath("."))
+}
+
+func (b *APIManagerBackup) backupDataPodVolume() v1.Volume {
+	return v1.Volume{
+		Name: backupDataVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (b *APIManagerBackup) backupDataContainerVolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      backupDataVolumeName,
+		MountPath: BackupPVCMountPath,
+	}
+}
+
+func (b *APIManagerBackup) s3ContainerEnv() []v1.EnvVar {
+	s3Options := b.options.APIManagerBackupS3Options
+	res := []v1.EnvVar{
+		helper.EnvVarFromSecret(apps.AwsAccessKeyID, s3Options.CredentialsSecretName, apps.AwsAccessKeyID),
+		helper.EnvVarFromSecret(apps.AwsSecretAccessKey, s3Options.CredentialsSecretName, apps.AwsSecretAccessKey),
+	}
+	if s3Options.Region != nil {
+		res = append(res, helper.EnvVarFromValue("AWS_DEFAULT_REGION", *s3Options.Region))
+	}
+	return res
+}
+
+func (b *APIManagerBackup) uploadToS3ContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+S3_DESTINATION='%s';
+aws %s s3 cp --recursive ${BASEPATH}/ ${S3_DESTINATION}/;
+`,
+		BackupPVCMountPath,
+		b.BackupS3Location(),
+		b.s3EndpointArgs(),
+	)
+}
+
+func (b *APIManagerBackup) s3EndpointArgs() string {
+	if b.options.APIManagerBackupS3Options.Endpoint == nil {
+		return ""
+	}
+	return fmt.Sprintf("--endpoint-url %s", *b.options.APIManagerBackupS3Options.Endpoint)
+}
+
 func (b *APIManagerBackup) systemFileStoragePodVolume() v1.Volume {
 	return v1.Volume{
 		Name: "system-storage",
@@ -317,7 +660,7 @@ for i in $(echo -n $CONFIGMAPS); do oc get configmap -o json $i | python -c "${P
 		strings.Join(helper.SortedMapStringStringValues(configMapsToBackup), " "),
 		BackupPVCMountPath,
 		pythonCleanupSubscriptContent,
-	)
+	) + checksumsScript("secrets-configmaps", "secrets", "configmaps")
 }
 
 func (b *APIManagerBackup) backupAPIManagerCustomResourceContainerArgs() string {
@@ -335,7 +678,7 @@ oc get apimanager -o json ${APIMANAGER_NAME} | python -c "${PYTHON_CLEANUP_SUBSC
 		pythonCleanupSubscriptContent,
 		b.options.APIManagerName,
 		APIManagerSerializedBackupFileName,
-	)
+	) + checksumsScript("apimanager", "apimanager")
 }
 
 func (b *APIManagerBackup) pythonCleanupK8sObjectScript() string {
@@ -366,7 +709,7 @@ rsync -av ${SYSTEM_FILESTORAGE_PVC_DIR}/ ${PVC_BACKUP_FILESTORAGE_SUBDIR};
 `,
 		BackupPVCMountPath,
 		SystemFileStoragePVCMountPath,
-	)
+	) + checksumsScript("system-filestorage-pvc", "system-filestorage-pvc")
 }
 
 func (b *APIManagerBackup) ServiceAccount() *v1.ServiceAccount {
@@ -410,6 +753,25 @@ func (b *APIManagerBackup) Role() *rbacv1.Role {
 					"list",
 				},
 			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{
+					"pods",
+				},
+				Verbs: []string{
+					"get",
+					"list",
+				},
+			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{
+					"pods/exec",
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
 			rbacv1.PolicyRule{
 				APIGroups: []string{appsv1alpha1.GroupVersion.Group},
 				Resources: []string{
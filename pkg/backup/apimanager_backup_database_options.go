@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"fmt"
+
+	validator "github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	DatabaseEngineMySQL      = "mysql"
+	DatabaseEnginePostgreSQL = "postgresql"
+
+	SystemDatabaseDumpName = "system-database"
+	ZyncDatabaseDumpName   = "zync-database"
+
+	databaseDumpsSubdir = "databases"
+)
+
+// DatabaseDumpOptions defines an operator-managed database that is dumped
+// into the backup data and loaded back from it on restore
+type DatabaseDumpOptions struct {
+	Name           string `validate:"required"` // Name of the dump file in the backup data, without extension
+	Engine         string `validate:"required,oneof=mysql postgresql"`
+	ImageURL       string `validate:"required"` // Image of the database. Provides client tools matching the server version
+	DeploymentName string `validate:"required"` // Name of the Deployment running the database
+	URLSecretName  string `validate:"required"` // Name of the secret containing the database URL
+	URLSecretKey   string `validate:"required"`
+}
+
+func NewDatabaseDumpOptions() *DatabaseDumpOptions {
+	return &DatabaseDumpOptions{}
+}
+
+func (d *DatabaseDumpOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(d)
+}
+
+// SystemDatabaseDumpOptions returns the dump options of the system database
+// of the APIManager. It returns nil when the system database is external
+func SystemDatabaseDumpOptions(apiManager *appsv1alpha1.APIManager) (*DatabaseDumpOptions, error) {
+	if apiManager.IsExternal(appsv1alpha1.SystemDatabase) {
+		return nil, nil
+	}
+
+	res := NewDatabaseDumpOptions()
+	res.Name = SystemDatabaseDumpName
+	res.URLSecretName = component.SystemSecretSystemDatabaseSecretName
+	res.URLSecretKey = component.SystemSecretSystemDatabaseURLFieldName
+
+	var databaseSpec *appsv1alpha1.SystemDatabaseSpec
+	if apiManager.Spec.System != nil {
+		databaseSpec = apiManager.Spec.System.DatabaseSpec
+	}
+
+	if databaseSpec != nil && databaseSpec.PostgreSQL != nil {
+		res.Engine = DatabaseEnginePostgreSQL
+		res.DeploymentName = component.SystemPostgreSQLDeploymentName
+		res.ImageURL = operator.SystemPostgreSQLImageURL()
+		if databaseSpec.PostgreSQL.Image != nil {
+			res.ImageURL = *databaseSpec.PostgreSQL.Image
+		}
+	} else {
+		res.Engine = DatabaseEngineMySQL
+		res.DeploymentName = component.SystemMySQLDeploymentName
+		res.ImageURL = operator.SystemMySQLImageURL()
+		if databaseSpec != nil && databaseSpec.MySQL != nil && databaseSpec.MySQL.Image != nil {
+			res.ImageURL = *databaseSpec.MySQL.Image
+		}
+	}
+
+	return res, res.Validate()
+}
+
+// ZyncDatabaseDumpOptions returns the dump options of the zync database
+// of the APIManager. It returns nil when the zync database is external
+func ZyncDatabaseDumpOptions(apiManager *appsv1alpha1.APIManager) (*DatabaseDumpOptions, error) {
+	if apiManager.IsExternal(appsv1alpha1.ZyncDatabase) {
+		return nil, nil
+	}
+
+	res := NewDatabaseDumpOptions()
+	res.Name = ZyncDatabaseDumpName
+	res.Engine = DatabaseEnginePostgreSQL
+	res.DeploymentName = component.ZyncDatabaseDeploymentName
+	res.URLSecretName = component.ZyncSecretName
+	res.URLSecretKey = component.ZyncSecretDatabaseURLFieldName
+	res.ImageURL = operator.ZyncPostgreSQLImageURL()
+	if apiManager.Spec.Zync != nil && apiManager.Spec.Zync.PostgreSQLImage != nil {
+		res.ImageURL = *apiManager.Spec.Zync.PostgreSQLImage
+	}
+
+	return res, res.Validate()
+}
+
+// DumpFilePath returns the path of the dump file inside the backup data
+// mounted at basePath
+func (d *DatabaseDumpOptions) DumpFilePath(basePath string) string {
+	return fmt.Sprintf("%s/%s/%s.sql", basePath, databaseDumpsSubdir, d.Name)
+}
+
+// ConnectionEnvScript returns a shell script that sets the DB_HOST, DB_PORT,
+// DB_USER and DB_NAME variables and the client password variable from the
+// DATABASE_URL environment variable. PostgreSQL clients accept the URL as is,
+// so nothing is set for them
+func (d *DatabaseDumpOptions) ConnectionEnvScript() string {
+	if d.Engine != DatabaseEngineMySQL {
+		return ""
+	}
+
+	return `
+DB_URL_NO_SCHEME="${DATABASE_URL#*://}";
+DB_CREDENTIALS="${DB_URL_NO_SCHEME%%@*}";
+DB_HOST_AND_NAME="${DB_URL_NO_SCHEME#*@}";
+DB_USER="${DB_CREDENTIALS%%:*}";
+export MYSQL_PWD="${DB_CREDENTIALS#*:}";
+DB_HOST="${DB_HOST_AND_NAME%%/*}";
+DB_PORT=3306;
+if [[ "${DB_HOST}" == *:* ]]; then DB_PORT="${DB_HOST##*:}"; DB_HOST="${DB_HOST%%:*}"; fi;
+DB_NAME="${DB_HOST_AND_NAME#*/}";
+DB_NAME="${DB_NAME%%\?*}";
+`
+}
+
+// DatabaseURLEnvVar returns the DATABASE_URL environment variable used by
+// the dump and restore scripts
+func (d *DatabaseDumpOptions) DatabaseURLEnvVar() v1.EnvVar {
+	return helper.EnvVarFromSecret("DATABASE_URL", d.URLSecretName, d.URLSecretKey)
+}
@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func TestSystemDatabaseDumpOptions(t *testing.T) {
+	cases := []struct {
+		name               string
+		systemSpec         *appsv1alpha1.SystemSpec
+		expectedEngine     string
+		expectedImage      string
+		expectedDeployment string
+	}{
+		{"defaultMySQL", nil, DatabaseEngineMySQL, "", component.SystemMySQLDeploymentName},
+		{"mySQLImage",
+			&appsv1alpha1.SystemSpec{DatabaseSpec: &appsv1alpha1.SystemDatabaseSpec{
+				MySQL: &appsv1alpha1.SystemMySQLSpec{Image: ptr.To("mysql:custom")},
+			}},
+			DatabaseEngineMySQL, "mysql:custom", component.SystemMySQLDeploymentName,
+		},
+		{"postgreSQLImage",
+			&appsv1alpha1.SystemSpec{DatabaseSpec: &appsv1alpha1.SystemDatabaseSpec{
+				PostgreSQL: &appsv1alpha1.SystemPostgreSQLSpec{Image: ptr.To("postgresql:custom")},
+			}},
+			DatabaseEnginePostgreSQL, "postgresql:custom", component.SystemPostgreSQLDeploymentName,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			apiManager := &appsv1alpha1.APIManager{}
+			apiManager.Spec.System = tc.systemSpec
+			res, err := SystemDatabaseDumpOptions(apiManager)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if res.Engine != tc.expectedEngine {
+				subT.Errorf("expected engine %s got %s", tc.expectedEngine, res.Engine)
+			}
+			if tc.expectedImage != "" && res.ImageURL != tc.expectedImage {
+				subT.Errorf("expected image %s got %s", tc.expectedImage, res.ImageURL)
+			}
+			if res.DeploymentName != tc.expectedDeployment {
+				subT.Errorf("expected deployment %s got %s", tc.expectedDeployment, res.DeploymentName)
+			}
+		})
+	}
+}
+
+func TestDatabaseDumpOptionsExternalDatabases(t *testing.T) {
+	apiManager := &appsv1alpha1.APIManager{}
+	apiManager.Spec.ExternalComponents = &appsv1alpha1.ExternalComponentsSpec{
+		System: &appsv1alpha1.ExternalSystemComponents{Database: ptr.To(true)},
+		Zync:   &appsv1alpha1.ExternalZyncComponents{Database: ptr.To(true)},
+	}
+
+	systemRes, err := SystemDatabaseDumpOptions(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if systemRes != nil {
+		t.Errorf("expected no system database dump options for external database, got %v", systemRes)
+	}
+
+	zyncRes, err := ZyncDatabaseDumpOptions(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zyncRes != nil {
+		t.Errorf("expected no zync database dump options for external database, got %v", zyncRes)
+	}
+}
+
+func TestDatabaseDumpOptionsDumpFilePath(t *testing.T) {
+	dumpOptions := &DatabaseDumpOptions{Name: ZyncDatabaseDumpName}
+	if res := dumpOptions.DumpFilePath(BackupPVCMountPath); res != "/backup/databases/zync-database.sql" {
+		t.Errorf("unexpected dump file path %s", res)
+	}
+}
@@ -0,0 +1,206 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/version"
+)
+
+const (
+	// ManifestFileName is the name of the backup integrity manifest in the
+	// root of the backup data
+	ManifestFileName = "manifest.json"
+
+	// ManifestChecksumsSubdir is the subdirectory of the backup data where
+	// each backup Job writes the SHA-256 checksums of the files it produced
+	ManifestChecksumsSubdir = "manifests"
+)
+
+// APIManagerSpecHash returns the SHA-256 hash of the JSON representation of
+// the APIManager spec. It is stored in the backup integrity manifest so the
+// backed up APIManager can be checked on restore
+func APIManagerSpecHash(apiManager *appsv1alpha1.APIManager) (string, error) {
+	specJSON, err := json.Marshal(apiManager.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(specJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// checksumsScript returns a shell script that writes the SHA-256 checksums of
+// the files under the given paths of the backup data. The checksums are
+// gathered into the backup integrity manifest by the manifest Job
+func checksumsScript(part string, paths ...string) string {
+	return fmt.Sprintf(`
+cd '%s';
+mkdir -p %s;
+find %s -type f -print0 | sort -z | xargs -0 -r sha256sum > %s/%s.sha256;
+`,
+		BackupPVCMountPath,
+		ManifestChecksumsSubdir,
+		strings.Join(paths, " "),
+		ManifestChecksumsSubdir,
+		part,
+	)
+}
+
+// BackupManifestToPVCJob returns a Job that verifies the backup data stored
+// in the backup data PVC against the checksums written by the backup Jobs and
+// writes the backup integrity manifest
+func (b *APIManagerBackup) BackupManifestToPVCJob() *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil {
+		return nil
+	}
+
+	return b.pvcBackupJob("backup-manifest-pvc",
+		b.ocCLIBackupContainer("backup-manifest", b.backupManifestContainerArgs(), nil),
+	)
+}
+
+// BackupManifestToS3Job returns a Job that downloads the backup data stored
+// in the S3 backup data destination, verifies it against the checksums
+// written by the backup Jobs and uploads the backup integrity manifest
+func (b *APIManagerBackup) BackupManifestToS3Job() *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-manifest-s3", b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	manifestContainer := b.ocCLIBackupContainer("backup-manifest", b.backupManifestContainerArgs(),
+		[]v1.VolumeMount{b.backupDataContainerVolumeMount()},
+	)
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.backupDataPodVolume(),
+					},
+					InitContainers: []v1.Container{
+						b.awsCLIBackupContainer("download-from-s3", b.downloadFromS3ContainerArgs()),
+						manifestContainer,
+					},
+					Containers: []v1.Container{
+						b.awsCLIBackupContainer("upload-to-s3", b.uploadManifestToS3ContainerArgs()),
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+func (b *APIManagerBackup) awsCLIBackupContainer(name, args string) v1.Container {
+	return v1.Container{
+		Name:  name,
+		Image: b.options.AWSCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			args,
+		},
+		Env: b.s3ContainerEnv(),
+		VolumeMounts: []v1.VolumeMount{
+			b.backupDataContainerVolumeMount(),
+		},
+	}
+}
+
+// backupManifestContainerArgs returns the script that verifies the backup
+// data against the checksums written by the backup Jobs and writes them into
+// the manifest. Each file entry is written in its own line to keep the
+// manifest readable
+func (b *APIManagerBackup) backupManifestContainerArgs() string {
+	specHash, err := APIManagerSpecHash(b.options.APIManager)
+	if err != nil {
+		panic(err)
+	}
+
+	return fmt.Sprintf(`
+BASEPATH='%s';
+CHECKSUMS_SUBDIR='%s';
+MANIFEST_FILE='%s';
+cd ${BASEPATH};
+cat ${CHECKSUMS_SUBDIR}/*.sha256 | sort -k 2 > /tmp/checksums;
+sha256sum -c --quiet /tmp/checksums;
+{
+	echo '{';
+	echo '  "operatorVersion": "%s",';
+	echo '  "apiManagerName": "%s",';
+	echo '  "apiManagerSpecHash": "%s",';
+	echo '  "files": [';
+	SEPARATOR='';
+	while read -r SUM FILEPATH; do
+		FILEPATH=$(printf '%%s' "${FILEPATH}" | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g');
+		printf '%%s    {"path": "%%s", "sha256": "%%s"}' "${SEPARATOR}" "${FILEPATH}" "${SUM}";
+		SEPARATOR=$',\n';
+	done < /tmp/checksums;
+	printf '\n  ]\n}\n';
+} > ${BASEPATH}/${MANIFEST_FILE};
+`,
+		BackupPVCMountPath,
+		ManifestChecksumsSubdir,
+		ManifestFileName,
+		version.Version,
+		b.options.APIManagerName,
+		specHash,
+	)
+}
+
+func (b *APIManagerBackup) downloadFromS3ContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+S3_SOURCE='%s';
+aws %s s3 cp --recursive ${S3_SOURCE}/ ${BASEPATH}/;
+`,
+		BackupPVCMountPath,
+		b.BackupS3Location(),
+		b.s3EndpointArgs(),
+	)
+}
+
+func (b *APIManagerBackup) uploadManifestToS3ContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+S3_DESTINATION='%s';
+MANIFEST_FILE='%s';
+aws %s s3 cp ${BASEPATH}/${MANIFEST_FILE} ${S3_DESTINATION}/${MANIFEST_FILE};
+`,
+		BackupPVCMountPath,
+		b.BackupS3Location(),
+		ManifestFileName,
+		b.s3EndpointArgs(),
+	)
+}
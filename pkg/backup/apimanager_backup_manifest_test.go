@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestAPIManagerSpecHash(t *testing.T) {
+	apiManager := &appsv1alpha1.APIManager{}
+	apiManager.Spec.WildcardDomain = "example.com"
+
+	hash, err := APIManagerSpecHash(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("expected a hex encoded SHA-256 hash, got %s", hash)
+	}
+
+	// Metadata is not part of the hash
+	apiManager.Name = "other"
+	sameHash, err := APIManagerSpecHash(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sameHash != hash {
+		t.Errorf("expected hash %s got %s", hash, sameHash)
+	}
+
+	apiManager.Spec.ResourceRequirementsEnabled = ptr.To(false)
+	otherHash, err := APIManagerSpecHash(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherHash == hash {
+		t.Error("expected a different hash for a different spec")
+	}
+}
+
+func TestChecksumsScript(t *testing.T) {
+	script := checksumsScript("apimanager", "apimanager")
+	if !strings.Contains(script, "find apimanager -type f") {
+		t.Errorf("expected checksums of the apimanager directory, got %s", script)
+	}
+	if !strings.Contains(script, "> manifests/apimanager.sha256") {
+		t.Errorf("expected checksums written into the manifests directory, got %s", script)
+	}
+}
@@ -7,13 +7,19 @@ import (
 )
 
 type APIManagerBackupOptions struct {
-	Namespace                  string                      `validate:"required"` // Namespace where the K8s related objects to the backup will be created/looked
-	APIManagerBackupName       string                      `validate:"required"` // Name of the APIManagerBackup CR. NOT the APIManager cr name
-	APIManagerBackupUID        types.UID                   `validate:"required"` // UID of the APIManagerBackup CR
-	APIManagerName             string                      `validate:"required"` // Name of the APIManager CR. NOT the APIManagerBackup cr name
-	APIManager                 *appsv1alpha1.APIManager    `validate:"required"`
-	APIManagerBackupPVCOptions *APIManagerBackupPVCOptions `validate:"required"`
-	OCCLIImageURL              string                      `validate:"required"`
+	Namespace                   string                      `validate:"required"` // Namespace where the K8s related objects to the backup will be created/looked
+	APIManagerBackupName        string                      `validate:"required"` // Name of the APIManagerBackup CR. NOT the APIManager cr name
+	APIManagerBackupUID         types.UID                   `validate:"required"` // UID of the APIManagerBackup CR
+	APIManagerName              string                      `validate:"required"` // Name of the APIManager CR. NOT the APIManagerBackup cr name
+	APIManager                  *appsv1alpha1.APIManager    `validate:"required"`
+	APIManagerBackupPVCOptions  *APIManagerBackupPVCOptions // Union type with APIManagerBackupS3Options. Only one of them is set
+	APIManagerBackupS3Options   *APIManagerBackupS3Options
+	SystemDatabaseDumpOptions   *DatabaseDumpOptions  // Only set when the system database is managed by the operator
+	ZyncDatabaseDumpOptions     *DatabaseDumpOptions  // Only set when the zync database is managed by the operator
+	BackendRedisSnapshotOptions *RedisSnapshotOptions // Only set when the backend Redis snapshot is requested
+	SystemRedisSnapshotOptions  *RedisSnapshotOptions // Only set when the system Redis snapshot is requested
+	OCCLIImageURL               string                `validate:"required"`
+	AWSCLIImageURL              string                `validate:"required"`
 }
 
 func NewAPIManagerBackupOptions() *APIManagerBackupOptions {
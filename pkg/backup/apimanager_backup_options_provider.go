@@ -3,6 +3,7 @@ package backup
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
@@ -39,18 +40,43 @@ func (a *APIManagerBackupOptionsProvider) Options() (*APIManagerBackupOptions, e
 	res.APIManager = apiManager
 	res.APIManagerName = apiManager.Name
 	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.AWSCLIImageURL = a.awsCLIImageURL()
 
 	pvcOptions, err := a.pvcBackupOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3BackupOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerBackup struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one backup destination has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one backup destination can be specified")
+	}
 
 	res.APIManagerBackupPVCOptions = pvcOptions
+	res.APIManagerBackupS3Options = s3Options
+
+	res.SystemDatabaseDumpOptions, err = SystemDatabaseDumpOptions(apiManager)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ZyncDatabaseDumpOptions, err = ZyncDatabaseDumpOptions(apiManager)
+	if err != nil {
+		return nil, err
+	}
+
+	res.BackendRedisSnapshotOptions, res.SystemRedisSnapshotOptions, err = a.redisSnapshotOptions(apiManager)
+	if err != nil {
+		return nil, err
+	}
 
 	return res, res.Validate()
 }
@@ -71,6 +97,57 @@ func (a *APIManagerBackupOptionsProvider) pvcBackupOptions() (*APIManagerBackupP
 	return res, res.Validate()
 }
 
+func (a *APIManagerBackupOptionsProvider) s3BackupOptions() (*APIManagerBackupS3Options, error) {
+	s3Spec := a.APIManagerBackupCR.Spec.BackupDestination.S3
+	if s3Spec == nil {
+		return nil, nil
+	}
+
+	res := NewAPIManagerBackupS3Options()
+	res.Bucket = s3Spec.Bucket
+	res.Region = s3Spec.Region
+	res.Endpoint = s3Spec.Endpoint
+	res.CredentialsSecretName = s3Spec.CredentialsSecretRef.Name
+	res.Path = a.APIManagerBackupCR.Name
+	if s3Spec.Prefix != nil && strings.Trim(*s3Spec.Prefix, "/") != "" {
+		res.Path = fmt.Sprintf("%s/%s", strings.Trim(*s3Spec.Prefix, "/"), a.APIManagerBackupCR.Name)
+	}
+
+	return res, res.Validate()
+}
+
+func (a *APIManagerBackupOptionsProvider) redisSnapshotOptions(apiManager *appsv1alpha1.APIManager) (*RedisSnapshotOptions, *RedisSnapshotOptions, error) {
+	redisSpec := a.APIManagerBackupCR.Spec.Redis
+	if redisSpec == nil {
+		return nil, nil, nil
+	}
+
+	var backendRes, systemRes *RedisSnapshotOptions
+	var err error
+
+	if redisSpec.Backend != nil && *redisSpec.Backend {
+		backendRes, err = BackendRedisSnapshotOptions(apiManager)
+		if err != nil {
+			return nil, nil, err
+		}
+		if backendRes == nil {
+			return nil, nil, fmt.Errorf("Backend Redis snapshot requested but backend Redis is not managed by the operator")
+		}
+	}
+
+	if redisSpec.System != nil && *redisSpec.System {
+		systemRes, err = SystemRedisSnapshotOptions(apiManager)
+		if err != nil {
+			return nil, nil, err
+		}
+		if systemRes == nil {
+			return nil, nil, fmt.Errorf("System Redis snapshot requested but system Redis is not managed by the operator")
+		}
+	}
+
+	return backendRes, systemRes, nil
+}
+
 func (a *APIManagerBackupOptionsProvider) apiManager() (*appsv1alpha1.APIManager, error) {
 	return a.autodiscoveredAPIManager()
 }
@@ -98,3 +175,7 @@ func (a *APIManagerBackupOptionsProvider) autodiscoveredAPIManager() (*appsv1alp
 func (a *APIManagerBackupOptionsProvider) ocCLIImageURL() string {
 	return helper.GetEnvVar("RELATED_IMAGE_OC_CLI", component.OCCLIImageURL())
 }
+
+func (a *APIManagerBackupOptionsProvider) awsCLIImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_AWS_CLI", component.AWSCLIImageURL())
+}
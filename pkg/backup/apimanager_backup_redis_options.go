@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"fmt"
+
+	validator "github.com/go-playground/validator/v10"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+)
+
+const (
+	BackendRedisSnapshotName = "backend-redis"
+	SystemRedisSnapshotName  = "system-redis"
+
+	redisSnapshotsSubdir = "redis"
+)
+
+// RedisSnapshotOptions defines an operator-managed Redis database whose RDB
+// snapshot is stored into the backup data and loaded back from it on restore
+type RedisSnapshotOptions struct {
+	Name            string  `validate:"required"` // Name of the snapshot file in the backup data, without extension
+	ImageURL        string  `validate:"required"` // Image of the Redis database. Used to load the snapshot on restore
+	DeploymentName  string  `validate:"required"` // Name of the Deployment running the Redis database
+	PVCName         string  `validate:"required"` // Name of the PersistentVolumeClaim storing the Redis data
+	PVCStorageClass *string // Storage class of the PersistentVolumeClaim storing the Redis data
+}
+
+func NewRedisSnapshotOptions() *RedisSnapshotOptions {
+	return &RedisSnapshotOptions{}
+}
+
+func (r *RedisSnapshotOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// BackendRedisSnapshotOptions returns the snapshot options of the backend
+// Redis of the APIManager. It returns nil when the backend Redis is external
+func BackendRedisSnapshotOptions(apiManager *appsv1alpha1.APIManager) (*RedisSnapshotOptions, error) {
+	if apiManager.IsExternal(appsv1alpha1.BackendRedis) {
+		return nil, nil
+	}
+
+	res := NewRedisSnapshotOptions()
+	res.Name = BackendRedisSnapshotName
+	res.DeploymentName = component.BackendRedisDeploymentName
+	res.PVCName = component.BackendRedisPVCName
+	res.ImageURL = operator.BackendRedisImageURL()
+	if apiManager.Spec.Backend != nil {
+		if apiManager.Spec.Backend.RedisImage != nil {
+			res.ImageURL = *apiManager.Spec.Backend.RedisImage
+		}
+		if apiManager.Spec.Backend.RedisPersistentVolumeClaimSpec != nil {
+			res.PVCStorageClass = apiManager.Spec.Backend.RedisPersistentVolumeClaimSpec.StorageClassName
+		}
+	}
+
+	return res, res.Validate()
+}
+
+// SystemRedisSnapshotOptions returns the snapshot options of the system
+// Redis of the APIManager. It returns nil when the system Redis is external
+func SystemRedisSnapshotOptions(apiManager *appsv1alpha1.APIManager) (*RedisSnapshotOptions, error) {
+	if apiManager.IsExternal(appsv1alpha1.SystemRedis) {
+		return nil, nil
+	}
+
+	res := NewRedisSnapshotOptions()
+	res.Name = SystemRedisSnapshotName
+	res.DeploymentName = component.SystemRedisDeploymentName
+	res.PVCName = component.SystemRedisPVCName
+	res.ImageURL = operator.SystemRedisImageURL()
+	if apiManager.Spec.System != nil {
+		if apiManager.Spec.System.RedisImage != nil {
+			res.ImageURL = *apiManager.Spec.System.RedisImage
+		}
+		if apiManager.Spec.System.RedisPersistentVolumeClaimSpec != nil {
+			res.PVCStorageClass = apiManager.Spec.System.RedisPersistentVolumeClaimSpec.StorageClassName
+		}
+	}
+
+	return res, res.Validate()
+}
+
+// SnapshotFilePath returns the path of the RDB snapshot file inside the
+// backup data mounted at basePath
+func (r *RedisSnapshotOptions) SnapshotFilePath(basePath string) string {
+	return fmt.Sprintf("%s/%s/%s.rdb", basePath, redisSnapshotsSubdir, r.Name)
+}
@@ -0,0 +1,51 @@
+package backup
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func TestRedisSnapshotOptions(t *testing.T) {
+	apiManager := &appsv1alpha1.APIManager{}
+	apiManager.Spec.Backend = &appsv1alpha1.BackendSpec{
+		RedisImage: ptr.To("redis:custom"),
+		RedisPersistentVolumeClaimSpec: &appsv1alpha1.BackendRedisPersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("fast"),
+		},
+	}
+	apiManager.Spec.ExternalComponents = &appsv1alpha1.ExternalComponentsSpec{
+		System: &appsv1alpha1.ExternalSystemComponents{Redis: ptr.To(true)},
+	}
+
+	backendRes, err := BackendRedisSnapshotOptions(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backendRes == nil {
+		t.Fatal("expected backend Redis snapshot options")
+	}
+	if backendRes.ImageURL != "redis:custom" {
+		t.Errorf("expected image redis:custom got %s", backendRes.ImageURL)
+	}
+	if backendRes.PVCName != component.BackendRedisPVCName {
+		t.Errorf("expected PVC %s got %s", component.BackendRedisPVCName, backendRes.PVCName)
+	}
+	if backendRes.PVCStorageClass == nil || *backendRes.PVCStorageClass != "fast" {
+		t.Errorf("expected storage class fast got %v", backendRes.PVCStorageClass)
+	}
+	if res := backendRes.SnapshotFilePath(BackupPVCMountPath); res != "/backup/redis/backend-redis.rdb" {
+		t.Errorf("unexpected snapshot file path %s", res)
+	}
+
+	systemRes, err := SystemRedisSnapshotOptions(apiManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if systemRes != nil {
+		t.Errorf("expected no system Redis snapshot options for external Redis, got %v", systemRes)
+	}
+}
@@ -0,0 +1,22 @@
+package backup
+
+import (
+	validator "github.com/go-playground/validator/v10"
+)
+
+type APIManagerBackupS3Options struct {
+	Bucket                string  `validate:"required"`
+	Path                  string  `validate:"required"` // Path inside the bucket where the backup data is stored
+	Region                *string // TODO should we validate the region in case we define it?
+	Endpoint              *string `validate:"omitempty,url"`
+	CredentialsSecretName string  `validate:"required"`
+}
+
+func NewAPIManagerBackupS3Options() *APIManagerBackupS3Options {
+	return &APIManagerBackupS3Options{}
+}
+
+func (a *APIManagerBackupS3Options) Validate() error {
+	validate := validator.New()
+	return validate.Struct(a)
+}
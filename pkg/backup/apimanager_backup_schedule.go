@@ -0,0 +1,133 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// ParseBackupSchedule parses a standard 5 field cron expression
+func ParseBackupSchedule(schedule string) (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("unparseable schedule %q: %w", schedule, err)
+	}
+	return sched, nil
+}
+
+// maxMissedScheduleTimes bounds the schedule times looked up by
+// MostRecentScheduleTime, like the Kubernetes CronJob controller does
+const maxMissedScheduleTimes = 100
+
+// ErrTooManyMissedScheduleTimes is returned when more than
+// maxMissedScheduleTimes schedule times have been missed
+var ErrTooManyMissedScheduleTimes = errors.New("too many missed schedule times")
+
+// MostRecentScheduleTime returns the most recent time of the schedule
+// that is after earliestTime and not after now. It returns nil when
+// there is no such time, and ErrTooManyMissedScheduleTimes when more than
+// maxMissedScheduleTimes times have been missed
+func MostRecentScheduleTime(sched cron.Schedule, earliestTime, now time.Time) (*time.Time, error) {
+	var res *time.Time
+	missed := 0
+	for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
+		missed++
+		if missed > maxMissedScheduleTimes {
+			return nil, ErrTooManyMissedScheduleTimes
+		}
+		scheduledTime := t
+		res = &scheduledTime
+	}
+	return res, nil
+}
+
+// ScheduledBackupName returns the name of the APIManagerBackup created by
+// the schedule for the given scheduled time. The name is deterministic so
+// the same scheduled time never produces more than one backup
+func ScheduledBackupName(scheduleName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", scheduleName, scheduledTime.Unix()/60)
+}
+
+// BackupsToPrune returns the completed and failed backups that are not kept
+// by any of the retention rules. Retention rules apply separately to
+// completed and to failed backups, and failed backups older than the most
+// recent completed backup are always pruned. Backups that have not finished
+// are never pruned
+func BackupsToPrune(backups []appsv1alpha1.APIManagerBackup, retention *appsv1alpha1.APIManagerBackupScheduleRetention, now time.Time) []appsv1alpha1.APIManagerBackup {
+	if retention == nil || (retention.KeepLast == nil && retention.KeepDays == nil) {
+		return nil
+	}
+
+	completed := []appsv1alpha1.APIManagerBackup{}
+	failed := []appsv1alpha1.APIManagerBackup{}
+	for idx := range backups {
+		if backups[idx].BackupFailed() {
+			failed = append(failed, backups[idx])
+		} else if backups[idx].BackupCompleted() && backups[idx].Status.CompletionTime != nil {
+			completed = append(completed, backups[idx])
+		}
+	}
+
+	completionTime := func(b *appsv1alpha1.APIManagerBackup) time.Time { return b.Status.CompletionTime.Time }
+	// Failed backups may not have completion time
+	creationTime := func(b *appsv1alpha1.APIManagerBackup) time.Time { return b.CreationTimestamp.Time }
+
+	res := notRetainedBackups(completed, retention, now, completionTime)
+
+	var lastCompletionTime *time.Time
+	if len(completed) > 0 {
+		// completed is sorted most recent first
+		lastCompletionTime = &completed[0].Status.CompletionTime.Time
+	}
+
+	res = append(res, notRetainedBackups(failed, retention, now, creationTime)...)
+
+	for idx := range failed {
+		if lastCompletionTime != nil && failed[idx].CreationTimestamp.Time.Before(*lastCompletionTime) && !containsBackup(res, failed[idx].Name) {
+			res = append(res, failed[idx])
+		}
+	}
+
+	return res
+}
+
+// notRetainedBackups sorts the backups most recent first and returns the
+// ones not kept by any of the retention rules
+func notRetainedBackups(backups []appsv1alpha1.APIManagerBackup, retention *appsv1alpha1.APIManagerBackupScheduleRetention, now time.Time, backupTime func(*appsv1alpha1.APIManagerBackup) time.Time) []appsv1alpha1.APIManagerBackup {
+	// Most recent first
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backupTime(&backups[j]).Before(backupTime(&backups[i]))
+	})
+
+	res := []appsv1alpha1.APIManagerBackup{}
+	for idx := range backups {
+		if retention.KeepLast != nil && int32(idx) < *retention.KeepLast {
+			continue
+		}
+
+		if retention.KeepDays != nil {
+			maxAge := time.Duration(*retention.KeepDays) * 24 * time.Hour
+			if now.Sub(backupTime(&backups[idx])) < maxAge {
+				continue
+			}
+		}
+
+		res = append(res, backups[idx])
+	}
+
+	return res
+}
+
+func containsBackup(backups []appsv1alpha1.APIManagerBackup, name string) bool {
+	for idx := range backups {
+		if backups[idx].Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+)
+
+func TestMostRecentScheduleTime(t *testing.T) {
+	sched, err := ParseBackupSchedule("0 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	earliest := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	at := func(hour, min int) *time.Time {
+		res := time.Date(2024, 1, 1, hour, min, 0, 0, time.UTC)
+		return &res
+	}
+
+	cases := []struct {
+		name     string
+		now      time.Time
+		expected *time.Time
+	}{
+		{"noScheduleTimeYet", *at(10, 59), nil},
+		{"exactScheduleTime", *at(11, 0), at(11, 0)},
+		{"oneMissed", *at(11, 15), at(11, 0)},
+		{"severalMissed", *at(14, 15), at(14, 0)},
+		{"maxMissed", at(10, 0).Add(maxMissedScheduleTimes * time.Hour), ptr.To(at(10, 0).Add(maxMissedScheduleTimes * time.Hour))},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			res, err := MostRecentScheduleTime(sched, earliest, tc.now)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if !reflect.DeepEqual(res, tc.expected) {
+				subT.Errorf("expected %v got %v", tc.expected, res)
+			}
+		})
+	}
+
+	_, err = MostRecentScheduleTime(sched, earliest, at(10, 0).Add((maxMissedScheduleTimes+1)*time.Hour))
+	if err != ErrTooManyMissedScheduleTimes {
+		t.Errorf("expected too many missed schedule times error, got %v", err)
+	}
+}
+
+func TestParseBackupScheduleInvalid(t *testing.T) {
+	if _, err := ParseBackupSchedule("* * *"); err == nil {
+		t.Error("expected error parsing invalid schedule")
+	}
+}
+
+func TestBackupsToPrune(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	backup := func(name string, daysAgo int, completed bool) appsv1alpha1.APIManagerBackup {
+		res := appsv1alpha1.APIManagerBackup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		res.Status.Completed = &completed
+		if completed {
+			res.Status.CompletionTime = &metav1.Time{Time: now.Add(-time.Duration(daysAgo) * 24 * time.Hour)}
+		}
+		return res
+	}
+	backups := []appsv1alpha1.APIManagerBackup{
+		backup("b3", 3, true),
+		backup("b1", 1, true),
+		backup("running", 0, false),
+		backup("b5", 5, true),
+		backup("b2", 2, true),
+	}
+
+	cases := []struct {
+		name      string
+		retention *appsv1alpha1.APIManagerBackupScheduleRetention
+		expected  []string
+	}{
+		{"noRetention", nil, nil},
+		{"emptyRetention", &appsv1alpha1.APIManagerBackupScheduleRetention{}, nil},
+		{"keepLast", &appsv1alpha1.APIManagerBackupScheduleRetention{KeepLast: ptr.To(int32(2))}, []string{"b3", "b5"}},
+		{"keepLastZero", &appsv1alpha1.APIManagerBackupScheduleRetention{KeepLast: ptr.To(int32(0))}, []string{"b1", "b2", "b3", "b5"}},
+		{"keepDays", &appsv1alpha1.APIManagerBackupScheduleRetention{KeepDays: ptr.To(int32(3))}, []string{"b3", "b5"}},
+		{"keepLastOrKeepDays", &appsv1alpha1.APIManagerBackupScheduleRetention{KeepLast: ptr.To(int32(3)), KeepDays: ptr.To(int32(2))}, []string{"b5"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			var res []string
+			for _, b := range BackupsToPrune(backups, tc.retention, now) {
+				res = append(res, b.Name)
+			}
+			if !reflect.DeepEqual(res, tc.expected) {
+				subT.Errorf("diff %s", cmp.Diff(res, tc.expected))
+			}
+		})
+	}
+}
+
+func TestBackupsToPruneFailed(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) metav1.Time {
+		return metav1.Time{Time: now.Add(-time.Duration(days) * 24 * time.Hour)}
+	}
+	completedBackup := func(name string, days int) appsv1alpha1.APIManagerBackup {
+		res := appsv1alpha1.APIManagerBackup{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: daysAgo(days)}}
+		completionTime := daysAgo(days)
+		res.Status.Completed = ptr.To(true)
+		res.Status.CompletionTime = &completionTime
+		return res
+	}
+	failedBackup := func(name string, days int) appsv1alpha1.APIManagerBackup {
+		res := appsv1alpha1.APIManagerBackup{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: daysAgo(days)}}
+		res.Status.Conditions.SetCondition(common.Condition{Type: appsv1alpha1.APIManagerBackupFailedConditionType, Status: corev1.ConditionTrue})
+		return res
+	}
+
+	cases := []struct {
+		name      string
+		backups   []appsv1alpha1.APIManagerBackup
+		retention *appsv1alpha1.APIManagerBackupScheduleRetention
+		expected  []string
+	}{
+		{
+			"failedOlderThanCompleted",
+			[]appsv1alpha1.APIManagerBackup{completedBackup("c2", 2), failedBackup("f3", 3), failedBackup("f1", 1)},
+			&appsv1alpha1.APIManagerBackupScheduleRetention{KeepLast: ptr.To(int32(5))},
+			[]string{"f3"},
+		},
+		{
+			"failedKeepLast",
+			[]appsv1alpha1.APIManagerBackup{failedBackup("f3", 3), failedBackup("f1", 1), failedBackup("f2", 2)},
+			&appsv1alpha1.APIManagerBackupScheduleRetention{KeepLast: ptr.To(int32(1))},
+			[]string{"f2", "f3"},
+		},
+		{
+			"failedKeepDays",
+			[]appsv1alpha1.APIManagerBackup{failedBackup("f3", 3), failedBackup("f1", 1)},
+			&appsv1alpha1.APIManagerBackupScheduleRetention{KeepDays: ptr.To(int32(2))},
+			[]string{"f3"},
+		},
+		{
+			"failedNotCountedAsCompleted",
+			[]appsv1alpha1.APIManagerBackup{completedBackup("c3", 3), failedBackup("f1", 1)},
+			&appsv1alpha1.APIManagerBackupScheduleRetention{KeepLast: ptr.To(int32(1))},
+			nil,
+		},
+		{
+			"noRetention",
+			[]appsv1alpha1.APIManagerBackup{completedBackup("c2", 2), failedBackup("f3", 3)},
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			var res []string
+			for _, b := range BackupsToPrune(tc.backups, tc.retention, now) {
+				res = append(res, b.Name)
+			}
+			if !reflect.DeepEqual(res, tc.expected) {
+				subT.Errorf("diff %s", cmp.Diff(res, tc.expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package helper
+
+import "strings"
+
+// AdoptAnnotation allows a custom resource to take over an existing 3scale object
+// that was not created by the custom resource
+const AdoptAnnotation = "capabilities.3scale.net/adopt"
+
+const (
+	// AccountIDAnnotation links a DeveloperAccount custom resource to an existing 3scale developer account
+	AccountIDAnnotation = "accountID"
+	// UserIDAnnotation links a DeveloperUser custom resource to an existing 3scale developer user
+	UserIDAnnotation = "userID"
+	// ApplicationIDAnnotation links an Application custom resource to an existing 3scale application
+	ApplicationIDAnnotation = "applicationID"
+)
+
+// IsAdoptAnnotationTrue returns true when the adopt annotation of the object is "true"
+func IsAdoptAnnotationTrue(annotations map[string]string) bool {
+	return strings.EqualFold(annotations[AdoptAnnotation], "true")
+}
+
+// IsRemoteObjectOwned returns true when the 3scale object is the one created or adopted by the custom resource.
+// The custom resource status ID is the ownership marker
+func IsRemoteObjectOwned(statusID *int64, remoteID int64) bool {
+	return statusID != nil && *statusID == remoteID
+}
@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// ApplicationPlanTemplateGetter reads the application plan templates referenced by products, from the namespace of the product
+type ApplicationPlanTemplateGetter func(name string) (*capabilitiesv1beta1.ApplicationPlanTemplate, error)
+
+// ExpandApplicationPlanTemplates returns a copy of the product with the application plans of the referenced templates,
+// with the per product overrides applied.
+// Application plans of the product take precedence over template plans with the same system name.
+// The product is returned as is when no template is referenced
+func ExpandApplicationPlanTemplates(product *capabilitiesv1beta1.Product, getTemplate ApplicationPlanTemplateGetter) (*capabilitiesv1beta1.Product, error) {
+	if len(product.Spec.ApplicationPlanTemplateRefs) == 0 {
+		return product, nil
+	}
+
+	orphanErrors := field.ErrorList{}
+	invalidErrors := field.ErrorList{}
+	applicationPlans := map[string]capabilitiesv1beta1.ApplicationPlanSpec{}
+
+	templateRefsFldPath := field.NewPath("spec").Child("applicationPlanTemplateRefs")
+	for idx, ref := range product.Spec.ApplicationPlanTemplateRefs {
+		templateRefFldPath := templateRefsFldPath.Index(idx)
+
+		template, err := getTemplate(ref.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				orphanErrors = append(orphanErrors, field.Invalid(templateRefFldPath.Child("name"), ref.Name, "application plan template reference not found."))
+				continue
+			}
+			return nil, err
+		}
+
+		for systemName, planSpec := range template.Spec.ApplicationPlans {
+			applicationPlans[systemName] = *planSpec.DeepCopy()
+		}
+
+		for systemName, override := range ref.Overrides {
+			planSpec, ok := template.Spec.ApplicationPlans[systemName]
+			if !ok {
+				invalidErrors = append(invalidErrors, field.Invalid(templateRefFldPath.Child("overrides").Key(systemName), systemName, "application plan not found in the template."))
+				continue
+			}
+			applicationPlans[systemName] = planSpec.Override(&override)
+		}
+	}
+
+	// Missing templates may be created later
+	if len(orphanErrors) > 0 {
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.OrphanError,
+			FieldErrorList: orphanErrors,
+		}
+	}
+
+	if len(invalidErrors) > 0 {
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: invalidErrors,
+		}
+	}
+
+	for systemName, planSpec := range product.Spec.ApplicationPlans {
+		applicationPlans[systemName] = planSpec
+	}
+
+	expanded := product.DeepCopy()
+	expanded.Spec.ApplicationPlans = applicationPlans
+
+	return expanded, nil
+}
+
+// NewApplicationPlanTemplateGetter returns an ApplicationPlanTemplateGetter reading the application plan templates of the namespace from the cluster
+func NewApplicationPlanTemplateGetter(ctx context.Context, k8sClient client.Client, namespace string) ApplicationPlanTemplateGetter {
+	return func(name string) (*capabilitiesv1beta1.ApplicationPlanTemplate, error) {
+		template := &capabilitiesv1beta1.ApplicationPlanTemplate{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, template); err != nil {
+			return nil, err
+		}
+		return template, nil
+	}
+}
@@ -0,0 +1,159 @@
+package helper
+
+import (
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+func TestExpandApplicationPlanTemplates(t *testing.T) {
+	hitsLimit := func(value int) capabilitiesv1beta1.LimitSpec {
+		return capabilitiesv1beta1.LimitSpec{Period: "month", Value: value, MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "hits"}}
+	}
+
+	template := &capabilitiesv1beta1.ApplicationPlanTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "tiers", Namespace: "test"},
+		Spec: capabilitiesv1beta1.ApplicationPlanTemplateSpec{
+			ApplicationPlans: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"basic":      {Name: ptr.To("Basic"), Limits: []capabilitiesv1beta1.LimitSpec{hitsLimit(1000)}},
+				"pro":        {Name: ptr.To("Pro"), Limits: []capabilitiesv1beta1.LimitSpec{hitsLimit(10000)}},
+				"enterprise": {Name: ptr.To("Enterprise")},
+			},
+		},
+	}
+
+	getTemplate := func(name string) (*capabilitiesv1beta1.ApplicationPlanTemplate, error) {
+		if name != template.Name {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Group: capabilitiesv1beta1.GroupVersion.Group, Resource: "applicationplantemplates"}, name)
+		}
+		return template, nil
+	}
+
+	productFactory := func(refs ...capabilitiesv1beta1.ApplicationPlanTemplateRefSpec) *capabilitiesv1beta1.Product {
+		return &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "test"},
+			Spec: capabilitiesv1beta1.ProductSpec{
+				Name:       "product",
+				SystemName: "product",
+				Metrics: map[string]capabilitiesv1beta1.MetricSpec{
+					"hits": {Name: "Hits", Unit: "hit"},
+				},
+				ApplicationPlans: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+					"enterprise": {Name: ptr.To("Custom Enterprise")},
+				},
+				ApplicationPlanTemplateRefs: refs,
+			},
+		}
+	}
+
+	t.Run("templates expanded", func(subT *testing.T) {
+		product := productFactory(capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{
+			Name: "tiers",
+			Overrides: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"pro": {Limits: []capabilitiesv1beta1.LimitSpec{hitsLimit(50000)}},
+			},
+		})
+
+		expanded, err := ExpandApplicationPlanTemplates(product, getTemplate)
+		if err != nil {
+			subT.Fatalf("ExpandApplicationPlanTemplates() error = %v", err)
+		}
+
+		plans := expanded.Spec.ApplicationPlans
+		if len(plans) != 3 {
+			subT.Fatalf("application plans: %v", plans)
+		}
+		if plans["basic"].Limits[0].Value != 1000 {
+			subT.Errorf("basic plan limits: %v", plans["basic"].Limits)
+		}
+		if len(plans["pro"].Limits) != 1 || plans["pro"].Limits[0].Value != 50000 {
+			subT.Errorf("pro plan limits not overridden: %v", plans["pro"].Limits)
+		}
+		if *plans["enterprise"].Name != "Custom Enterprise" {
+			subT.Errorf("product application plan does not take precedence: %s", *plans["enterprise"].Name)
+		}
+		if len(product.Spec.ApplicationPlans) != 1 {
+			subT.Errorf("custom resource application plans changed: %v", product.Spec.ApplicationPlans)
+		}
+	})
+
+	t.Run("product plan retired to a template plan", func(subT *testing.T) {
+		product := productFactory(capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{Name: "tiers"})
+		product.Spec.ApplicationPlans["legacy"] = capabilitiesv1beta1.ApplicationPlanSpec{
+			Retire: &capabilitiesv1beta1.ApplicationPlanRetireSpec{MigrateTo: "basic"},
+		}
+
+		expanded, err := ExpandApplicationPlanTemplates(product, getTemplate)
+		if err != nil {
+			subT.Fatalf("ExpandApplicationPlanTemplates() error = %v", err)
+		}
+
+		if errors := expanded.Validate(); len(errors) > 0 {
+			subT.Errorf("expanded product not valid: %v", errors)
+		}
+	})
+
+	t.Run("template plan retired", func(subT *testing.T) {
+		product := productFactory(capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{
+			Name: "tiers",
+			Overrides: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"basic": {Retire: &capabilitiesv1beta1.ApplicationPlanRetireSpec{MigrateTo: "pro"}},
+			},
+		})
+
+		expanded, err := ExpandApplicationPlanTemplates(product, getTemplate)
+		if err != nil {
+			subT.Fatalf("ExpandApplicationPlanTemplates() error = %v", err)
+		}
+
+		if target := expanded.ApplicationPlanTarget("basic"); target != "pro" {
+			subT.Errorf("applications of the retired template plan subscribed to %s, want pro", target)
+		}
+	})
+
+	t.Run("template application plan not valid", func(subT *testing.T) {
+		product := productFactory(capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{
+			Name: "tiers",
+			Overrides: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"basic": {Limits: []capabilitiesv1beta1.LimitSpec{{Period: "month", Value: 10, MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "unknown"}}}},
+			},
+		})
+
+		expanded, err := ExpandApplicationPlanTemplates(product, getTemplate)
+		if err != nil {
+			subT.Fatalf("ExpandApplicationPlanTemplates() error = %v", err)
+		}
+
+		// Template plans are validated along with the product
+		if errors := expanded.Validate(); len(errors) == 0 {
+			subT.Error("expanded product with unknown limit metric is valid")
+		}
+	})
+
+	t.Run("template not found", func(subT *testing.T) {
+		product := productFactory(capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{Name: "unknown"})
+
+		_, err := ExpandApplicationPlanTemplates(product, getTemplate)
+		if !helper.IsOrphanSpecError(err) {
+			subT.Errorf("ExpandApplicationPlanTemplates() error = %v, want orphan error", err)
+		}
+	})
+
+	t.Run("override of unknown application plan", func(subT *testing.T) {
+		product := productFactory(capabilitiesv1beta1.ApplicationPlanTemplateRefSpec{
+			Name:      "tiers",
+			Overrides: map[string]capabilitiesv1beta1.ApplicationPlanSpec{"gold": {}},
+		})
+
+		_, err := ExpandApplicationPlanTemplates(product, getTemplate)
+		if !helper.IsInvalidSpecError(err) {
+			subT.Errorf("ExpandApplicationPlanTemplates() error = %v, want invalid error", err)
+		}
+	})
+}
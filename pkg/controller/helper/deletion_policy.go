@@ -0,0 +1,28 @@
+package helper
+
+import "strings"
+
+const (
+	// DeletionPolicyAnnotation sets what happens to the 3scale object when the custom resource is deleted
+	DeletionPolicyAnnotation = "capabilities.3scale.net/deletion-policy"
+
+	// DeletionPolicyDelete deletes the 3scale object along with the custom resource. Default policy
+	DeletionPolicyDelete = "Delete"
+
+	// DeletionPolicyOrphan keeps the 3scale object when the custom resource is deleted
+	DeletionPolicyOrphan = "Orphan"
+)
+
+// IsOrphanDeletionPolicy returns true when the deletion policy annotation of the object is Orphan
+func IsOrphanDeletionPolicy(annotations map[string]string) bool {
+	return strings.EqualFold(annotations[DeletionPolicyAnnotation], DeletionPolicyOrphan)
+}
+
+// GetDeletionPolicyAnnotation extracts the deletion policy annotation from an object.
+// Delete is returned when the annotation is missing or not valid
+func GetDeletionPolicyAnnotation(annotations map[string]string) string {
+	if IsOrphanDeletionPolicy(annotations) {
+		return DeletionPolicyOrphan
+	}
+	return DeletionPolicyDelete
+}
@@ -0,0 +1,25 @@
+package helper
+
+import "testing"
+
+func TestDeletionPolicyAnnotation(t *testing.T) {
+	cases := []struct {
+		testName    string
+		annotations map[string]string
+		expected    string
+	}{
+		{"nil annotations", nil, DeletionPolicyDelete},
+		{"missing annotation", map[string]string{"insecure_skip_verify": "true"}, DeletionPolicyDelete},
+		{"delete", map[string]string{DeletionPolicyAnnotation: "Delete"}, DeletionPolicyDelete},
+		{"orphan", map[string]string{DeletionPolicyAnnotation: "Orphan"}, DeletionPolicyOrphan},
+		{"orphan lowercase", map[string]string{DeletionPolicyAnnotation: "orphan"}, DeletionPolicyOrphan},
+		{"unknown", map[string]string{DeletionPolicyAnnotation: "Keep"}, DeletionPolicyDelete},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			equals(subT, tc.expected, GetDeletionPolicyAnnotation(tc.annotations))
+			equals(subT, tc.expected == DeletionPolicyOrphan, IsOrphanDeletionPolicy(tc.annotations))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+// SecretGetter reads the secrets referenced by custom resources.
+// An empty namespace refers to the namespace of the custom resource
+type SecretGetter func(key types.NamespacedName) (*corev1.Secret, error)
+
+// PolicyChainGetter reads the policy chains referenced by products, from the namespace of the product
+type PolicyChainGetter func(name string) (*capabilitiesv1beta1.PolicyChain, error)
+
+// ProductPolicies returns the product policy chain, the product policies combined with the referenced policy chains
+func ProductPolicies(product *capabilitiesv1beta1.Product, getPolicyChain PolicyChainGetter) ([]capabilitiesv1beta1.PolicyConfig, error) {
+	policyChains := map[string]*capabilitiesv1beta1.PolicyChain{}
+	for _, ref := range product.Spec.PolicyChainRefs {
+		policyChain, err := getPolicyChain(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("policy chain [%s]: %w", ref.Name, err)
+		}
+		policyChains[ref.Name] = policyChain
+	}
+
+	return product.PolicyChain(policyChains), nil
+}
+
+// PoliciesConfigList converts the product policy chain to the 3scale policy chain
+func PoliciesConfigList(policies []capabilitiesv1beta1.PolicyConfig, getSecret SecretGetter) (*threescaleapi.PoliciesConfigList, error) {
+	policyList := &threescaleapi.PoliciesConfigList{
+		Policies: []threescaleapi.PolicyConfig{},
+	}
+
+	for _, crdPolicy := range policies {
+		configuration, err := PolicyConfiguration(crdPolicy, getSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		policyList.Policies = append(policyList.Policies, threescaleapi.PolicyConfig{
+			Name:          crdPolicy.Name,
+			Version:       crdPolicy.Version,
+			Enabled:       crdPolicy.Enabled,
+			Configuration: configuration,
+		})
+	}
+
+	return policyList, nil
+}
+
+// PolicyConfiguration returns the policy configuration, from the plain value or from the referenced secret
+func PolicyConfiguration(crdPolicy capabilitiesv1beta1.PolicyConfig, getSecret SecretGetter) (map[string]interface{}, error) {
+	configuration := map[string]interface{}{}
+
+	// If plain value is not the default - use plain value as precedence over secret
+	if string(crdPolicy.Configuration.Raw) != capabilitiesv1beta1.ProductPolicyConfigurationDefault {
+		// CRD validation ensures no error happens
+		// "configuration` type is object
+		//properties:
+		//  configuration:
+		//    description: Configuration defines the policy configuration
+		//    type: object
+		//    x-kubernetes-preserve-unknown-fields: true
+		_ = json.Unmarshal(crdPolicy.Configuration.Raw, &configuration)
+
+		return configuration, nil
+	}
+
+	// If policy is defined in secretRef
+	if crdPolicy.ConfigurationRef.Name != "" {
+		// Get configuration from secret reference
+		secret, err := getSecret(types.NamespacedName{Name: crdPolicy.ConfigurationRef.Name, Namespace: crdPolicy.ConfigurationRef.Namespace})
+		if err != nil {
+			return nil, err
+		}
+
+		configurationByteArray, ok := secret.Data[capabilitiesv1beta1.ProductPolicyConfigurationPasswordSecretField]
+		if !ok {
+			return nil, fmt.Errorf("not found configuration field in secret (ns: %s, name: %s) field: %s",
+				secret.Namespace, crdPolicy.ConfigurationRef.Name, capabilitiesv1beta1.ProductPolicyConfigurationPasswordSecretField)
+		}
+
+		if err := json.Unmarshal(configurationByteArray, &configuration); err != nil {
+			return nil, err
+		}
+	}
+
+	return configuration, nil
+}
+
+// NewSecretGetter returns a SecretGetter reading the secrets from the cluster.
+// An empty namespace refers to the given namespace
+func NewSecretGetter(ctx context.Context, k8sClient client.Client, namespace string) SecretGetter {
+	return func(key types.NamespacedName) (*corev1.Secret, error) {
+		if key.Namespace == "" {
+			key.Namespace = namespace
+		}
+
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, key, secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+}
+
+// NewPolicyChainGetter returns a PolicyChainGetter reading the policy chains of the namespace from the cluster
+func NewPolicyChainGetter(ctx context.Context, k8sClient client.Client, namespace string) PolicyChainGetter {
+	return func(name string) (*capabilitiesv1beta1.PolicyChain, error) {
+		policyChain := &capabilitiesv1beta1.PolicyChain{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, policyChain); err != nil {
+			return nil, err
+		}
+		return policyChain, nil
+	}
+}
@@ -0,0 +1,137 @@
+package helper
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// BuiltinPolicyVersion is the version of the policies shipped with APIcast
+const BuiltinPolicyVersion = "builtin"
+
+// maxSchemaRefDepth bounds the resolution of nested schema references, recursive schemas are not supported
+const maxSchemaRefDepth = 10
+
+// Configuration JSON schemas of the built-in APIcast policies, one file per policy name.
+// Built-in policies without schema are not validated
+//
+//go:embed policyschemas/*.json
+var builtinPolicySchemas embed.FS
+
+// BuiltinPolicySchema returns the configuration JSON schema of a built-in APIcast policy, nil when not known
+func BuiltinPolicySchema(name, version string) ([]byte, error) {
+	if version != BuiltinPolicyVersion {
+		return nil, nil
+	}
+
+	schema, err := builtinPolicySchemas.ReadFile(fmt.Sprintf("policyschemas/%s.json", name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return schema, err
+}
+
+// ValidatePolicyConfiguration validates the policy configuration against the configuration JSON schema
+// the same way the API server validates custom resources. Schema violations are reported below fldPath
+func ValidatePolicyConfiguration(schema []byte, configuration map[string]interface{}, fldPath *field.Path) (field.ErrorList, error) {
+	var rawSchema map[string]interface{}
+	if err := json.Unmarshal(schema, &rawSchema); err != nil {
+		return nil, fmt.Errorf("policy configuration schema is not valid: %w", err)
+	}
+
+	// Only local references, like "#/definitions/commands", are supported
+	resolvedSchema, err := resolveSchemaRefs(rawSchema, rawSchema, 0)
+	if err != nil {
+		return nil, fmt.Errorf("policy configuration schema is not valid: %w", err)
+	}
+
+	resolvedSchemaBytes, err := json.Marshal(resolvedSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaObj := &spec.Schema{}
+	if err := json.Unmarshal(resolvedSchemaBytes, schemaObj); err != nil {
+		return nil, fmt.Errorf("policy configuration schema is not valid: %w", err)
+	}
+
+	validator := validate.NewSchemaValidator(schemaObj, nil, "", strfmt.Default)
+	return validation.ValidateCustomResource(fldPath, configuration, validator), nil
+}
+
+// resolveSchemaRefs returns a copy of the schema node with the "$ref" references replaced by the referenced schemas
+func resolveSchemaRefs(node interface{}, root map[string]interface{}, depth int) (interface{}, error) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			if depth >= maxSchemaRefDepth {
+				return nil, fmt.Errorf("schema reference %s nested too deep", ref)
+			}
+
+			referenced, err := lookupSchemaRef(ref, root)
+			if err != nil {
+				return nil, err
+			}
+			return resolveSchemaRefs(referenced, root, depth+1)
+		}
+
+		resolved := map[string]interface{}{}
+		for key, item := range value {
+			// Definitions are only used through references
+			if key == "definitions" || key == "$schema" {
+				continue
+			}
+
+			resolvedItem, err := resolveSchemaRefs(item, root, depth)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = resolvedItem
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			resolvedItem, err := resolveSchemaRefs(item, root, depth)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, resolvedItem)
+		}
+		return resolved, nil
+	default:
+		return node, nil
+	}
+}
+
+// lookupSchemaRef resolves a local JSON pointer reference, like "#/definitions/commands"
+func lookupSchemaRef(ref string, root map[string]interface{}) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("schema reference %s is not supported, only local references are", ref)
+	}
+
+	var current interface{} = root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema reference %s not found", ref)
+		}
+		current, ok = object[token]
+		if !ok {
+			return nil, fmt.Errorf("schema reference %s not found", ref)
+		}
+	}
+
+	return current, nil
+}
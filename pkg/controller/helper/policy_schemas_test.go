@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestBuiltinPolicySchema(t *testing.T) {
+	schema, err := BuiltinPolicySchema("headers", BuiltinPolicyVersion)
+	ok(t, err)
+	equals(t, true, json.Valid(schema))
+
+	schema, err = BuiltinPolicySchema("headers", "0.1")
+	ok(t, err)
+	equals(t, true, schema == nil)
+
+	schema, err = BuiltinPolicySchema("unknown", BuiltinPolicyVersion)
+	ok(t, err)
+	equals(t, true, schema == nil)
+}
+
+func TestBuiltinPolicySchemasAreValid(t *testing.T) {
+	entries, err := builtinPolicySchemas.ReadDir("policyschemas")
+	ok(t, err)
+
+	for _, entry := range entries {
+		t.Run(entry.Name(), func(subT *testing.T) {
+			schema, err := builtinPolicySchemas.ReadFile("policyschemas/" + entry.Name())
+			ok(subT, err)
+			_, err = ValidatePolicyConfiguration(schema, map[string]interface{}{}, field.NewPath("configuration"))
+			ok(subT, err)
+		})
+	}
+}
+
+func TestValidatePolicyConfiguration(t *testing.T) {
+	headersSchema, err := BuiltinPolicySchema("headers", BuiltinPolicyVersion)
+	ok(t, err)
+	fldPath := field.NewPath("spec").Child("policies").Index(0).Child("configuration")
+
+	cases := []struct {
+		testName      string
+		schema        []byte
+		configuration string
+		expected      []string
+	}{
+		{
+			"valid", headersSchema,
+			`{"request":[{"op":"set","header":"X-Custom","value":"1"}]}`,
+			[]string{},
+		},
+		{
+			"referenced schema violated", headersSchema,
+			`{"request":[{"op":"set","header":"X-Custom"},{"op":"replace"}]}`,
+			[]string{
+				`spec.policies[0].configuration.request[1].op: Unsupported value: "replace": supported values: "add", "set", "push", "delete"`,
+				`spec.policies[0].configuration.request[1].header: Required value`,
+			},
+		},
+		{
+			"custom policy schema", []byte(`{"type":"object","properties":{"limit":{"type":"integer","minimum":1}},"required":["limit"]}`),
+			`{"limit":0}`,
+			[]string{`spec.policies[0].configuration.limit: Invalid value: 0: limit in body should be greater than or equal to 1`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			configuration := map[string]interface{}{}
+			ok(subT, json.Unmarshal([]byte(tc.configuration), &configuration))
+
+			fieldErrors, err := ValidatePolicyConfiguration(tc.schema, configuration, fldPath)
+			ok(subT, err)
+
+			messages := []string{}
+			for _, fieldError := range fieldErrors {
+				messages = append(messages, fieldError.Error())
+			}
+			equals(subT, tc.expected, messages)
+		})
+	}
+}
+
+func TestValidatePolicyConfigurationNotSupportedSchema(t *testing.T) {
+	_, err := ValidatePolicyConfiguration([]byte(`{"properties":{"a":{"$ref":"http://example.com/schema.json"}}}`), map[string]interface{}{}, field.NewPath("configuration"))
+	equals(t, true, err != nil)
+
+	_, err = ValidatePolicyConfiguration([]byte(`{"properties":{"a":{"$ref":"#/definitions/missing"}}}`), map[string]interface{}{}, field.NewPath("configuration"))
+	equals(t, true, err != nil)
+}
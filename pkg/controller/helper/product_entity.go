@@ -3,6 +3,7 @@ package helper
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
@@ -341,6 +342,31 @@ func (b *ProductEntity) PromoteProxyToStaging() error {
 	return nil
 }
 
+// LatestProxyConfigVersion returns the latest proxy configuration version of the environment.
+// Zero when no proxy configuration has been promoted to the environment
+func (b *ProductEntity) LatestProxyConfigVersion(environment string) (int, error) {
+	b.logger.V(1).Info("LatestProxyConfigVersion", "environment", environment)
+	proxyConfig, err := b.client.GetLatestProxyConfig(strconv.FormatInt(b.productObj.Element.ID, 10), environment)
+	if err != nil {
+		if threescaleapi.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("product [%s] latest %s proxy config: %w", b.productObj.Element.SystemName, environment, err)
+	}
+
+	return proxyConfig.ProxyConfig.Version, nil
+}
+
+func (b *ProductEntity) PromoteProxyToProduction(stagingVersion int) error {
+	b.logger.V(1).Info("PromoteProxyToProduction", "version", stagingVersion)
+	_, err := b.client.PromoteProxyConfig(strconv.FormatInt(b.productObj.Element.ID, 10), "sandbox", strconv.Itoa(stagingVersion), "production")
+	if err != nil {
+		return fmt.Errorf("product [%s] promote proxy config version %d to production: %w", b.productObj.Element.SystemName, stagingVersion, err)
+	}
+
+	return nil
+}
+
 func (b *ProductEntity) Policies() (*threescaleapi.PoliciesConfigList, error) {
 	b.logger.V(1).Info("Policies")
 	if b.policies == nil {
@@ -0,0 +1,273 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// OIDCIssuerEndpointSecretField is the field of the secret referenced by the OIDC issuerEndpointRef
+const OIDCIssuerEndpointSecretField = "issuerEndpoint"
+
+// NewDefaultProxyItem returns the proxy settings of a product created in 3scale
+func NewDefaultProxyItem() threescaleapi.ProxyItem {
+	return threescaleapi.ProxyItem{
+		CredentialsLocation:        "query",
+		AuthUserKey:                "user_key",
+		AuthAppID:                  "app_id",
+		AuthAppKey:                 "app_key",
+		ErrorStatusAuthFailed:      403,
+		ErrorHeadersAuthFailed:     "text/plain; charset=us-ascii",
+		ErrorAuthFailed:            "Authentication failed",
+		ErrorStatusAuthMissing:     403,
+		ErrorHeadersAuthMissing:    "text/plain; charset=us-ascii",
+		ErrorAuthMissing:           "Authentication parameters missing",
+		ErrorStatusNoMatch:         404,
+		ErrorHeadersNoMatch:        "text/plain; charset=us-ascii",
+		ErrorNoMatch:               "No Mapping Rule matched",
+		ErrorStatusLimitsExceeded:  429,
+		ErrorHeadersLimitsExceeded: "text/plain; charset=us-ascii",
+		ErrorLimitsExceeded:        "Usage limit exceeded",
+	}
+}
+
+// DesiredProxyItem returns the existing proxy settings overridden by the ones set in the product.
+// Settings not set in the product keep the existing value, respecting 3scale defaults
+func DesiredProxyItem(product *capabilitiesv1beta1.Product, existing threescaleapi.ProxyItem, getSecret SecretGetter) (threescaleapi.ProxyItem, error) {
+	desired := existing
+
+	stringSettings := []struct {
+		desired  *string
+		existing *string
+	}{
+		{product.Spec.ProdPublicBaseURL(), &desired.Endpoint},
+		{product.Spec.StagingPublicBaseURL(), &desired.SandboxEndpoint},
+		{product.Spec.SecuritySecretToken(), &desired.SecretToken},
+		{product.Spec.HostRewrite(), &desired.HostnameRewrite},
+		{product.Spec.CredentialsLocation(), &desired.CredentialsLocation},
+		{product.Spec.AuthUserKey(), &desired.AuthUserKey},
+		{product.Spec.AuthAppID(), &desired.AuthAppID},
+		{product.Spec.AuthAppKey(), &desired.AuthAppKey},
+	}
+	for _, setting := range stringSettings {
+		if setting.desired != nil {
+			*setting.existing = *setting.desired
+		}
+	}
+
+	desiredGatewayResponse(product.Spec.GatewayResponse(), &desired)
+
+	err := desiredOIDC(product, &desired, getSecret)
+	if err != nil {
+		return desired, err
+	}
+
+	return desired, nil
+}
+
+func desiredGatewayResponse(gatewayResponse *capabilitiesv1beta1.GatewayResponseSpec, desired *threescaleapi.ProxyItem) {
+	if gatewayResponse == nil {
+		return
+	}
+
+	intSettings := []struct {
+		desired  *int32
+		existing *int
+	}{
+		{gatewayResponse.ErrorStatusAuthFailed, &desired.ErrorStatusAuthFailed},
+		{gatewayResponse.ErrorStatusAuthMissing, &desired.ErrorStatusAuthMissing},
+		{gatewayResponse.ErrorStatusNoMatch, &desired.ErrorStatusNoMatch},
+		{gatewayResponse.ErrorStatusLimitsExceeded, &desired.ErrorStatusLimitsExceeded},
+	}
+	for _, setting := range intSettings {
+		if setting.desired != nil {
+			*setting.existing = int(*setting.desired)
+		}
+	}
+
+	strSettings := []struct {
+		desired  *string
+		existing *string
+	}{
+		{gatewayResponse.ErrorHeadersAuthFailed, &desired.ErrorHeadersAuthFailed},
+		{gatewayResponse.ErrorAuthFailed, &desired.ErrorAuthFailed},
+		{gatewayResponse.ErrorHeadersAuthMissing, &desired.ErrorHeadersAuthMissing},
+		{gatewayResponse.ErrorAuthMissing, &desired.ErrorAuthMissing},
+		{gatewayResponse.ErrorHeadersNoMatch, &desired.ErrorHeadersNoMatch},
+		{gatewayResponse.ErrorNoMatch, &desired.ErrorNoMatch},
+		{gatewayResponse.ErrorHeadersLimitsExceeded, &desired.ErrorHeadersLimitsExceeded},
+		{gatewayResponse.ErrorLimitsExceeded, &desired.ErrorLimitsExceeded},
+	}
+	for _, setting := range strSettings {
+		if setting.desired != nil {
+			*setting.existing = *setting.desired
+		}
+	}
+}
+
+func desiredOIDC(product *capabilitiesv1beta1.Product, desired *threescaleapi.ProxyItem, getSecret SecretGetter) error {
+	oidcSpec := product.Spec.OIDCSpec()
+	if oidcSpec == nil {
+		return nil
+	}
+
+	// If plain value is not empty - use plain value as precedence over secret
+	issuerEndpoint := oidcSpec.IssuerEndpoint
+	if issuerEndpoint == "" {
+		if oidcSpec.IssuerEndpointRef == nil {
+			fieldErrors := field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("oidc").Child("IssuerEndpoint"), oidcSpec.IssuerEndpoint, "no IssuerEndpoint nor IssuerEndpointRef found in OIDC spec in CR. Product OpenID Connect Issuer will not be set"),
+			}
+			return &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: fieldErrors,
+			}
+		}
+
+		secret, err := getSecret(types.NamespacedName{Name: oidcSpec.IssuerEndpointRef.Name})
+		if err != nil {
+			return err
+		}
+
+		value := helper.GetSecretDataValue(secret.Data, OIDCIssuerEndpointSecretField)
+		if value == nil {
+			return fmt.Errorf("Secret field '%s' is required in secret '%s'", OIDCIssuerEndpointSecretField, secret.Name)
+		}
+		issuerEndpoint = *value
+	}
+
+	desired.OidcIssuerEndpoint = issuerEndpoint
+	desired.OidcIssuerType = oidcSpec.IssuerType
+	if oidcSpec.JwtClaimWithClientID != nil {
+		desired.JwtClaimWithClientID = *oidcSpec.JwtClaimWithClientID
+	}
+	if oidcSpec.JwtClaimWithClientIDType != nil {
+		desired.JwtClaimWithClientIDType = *oidcSpec.JwtClaimWithClientIDType
+	}
+
+	return nil
+}
+
+// ProxyParams returns the 3scale params updating the existing proxy settings to the desired ones
+func ProxyParams(existing, desired threescaleapi.ProxyItem) threescaleapi.Params {
+	params := threescaleapi.Params{}
+
+	// Public base URLs are compared with the default port
+	urlSettings := []struct {
+		existing string
+		desired  string
+		param    string
+	}{
+		{existing.Endpoint, desired.Endpoint, "endpoint"},
+		{existing.SandboxEndpoint, desired.SandboxEndpoint, "sandbox_endpoint"},
+	}
+	for _, setting := range urlSettings {
+		if helper.SetURLDefaultPort(setting.existing) != helper.SetURLDefaultPort(setting.desired) {
+			params[setting.param] = setting.desired
+		}
+	}
+
+	intSettings := []struct {
+		existing int
+		desired  int
+		param    string
+	}{
+		{existing.ErrorStatusAuthFailed, desired.ErrorStatusAuthFailed, "error_status_auth_failed"},
+		{existing.ErrorStatusAuthMissing, desired.ErrorStatusAuthMissing, "error_status_auth_missing"},
+		{existing.ErrorStatusNoMatch, desired.ErrorStatusNoMatch, "error_status_no_match"},
+		{existing.ErrorStatusLimitsExceeded, desired.ErrorStatusLimitsExceeded, "error_status_limits_exceeded"},
+	}
+	for _, setting := range intSettings {
+		if setting.existing != setting.desired {
+			params[setting.param] = strconv.Itoa(setting.desired)
+		}
+	}
+
+	strSettings := []struct {
+		existing string
+		desired  string
+		param    string
+	}{
+		{existing.SecretToken, desired.SecretToken, "secret_token"},
+		{existing.HostnameRewrite, desired.HostnameRewrite, "hostname_rewrite"},
+		{existing.CredentialsLocation, desired.CredentialsLocation, "credentials_location"},
+		{existing.AuthUserKey, desired.AuthUserKey, "auth_user_key"},
+		{existing.AuthAppID, desired.AuthAppID, "auth_app_id"},
+		{existing.AuthAppKey, desired.AuthAppKey, "auth_app_key"},
+		{existing.ErrorHeadersAuthFailed, desired.ErrorHeadersAuthFailed, "error_headers_auth_failed"},
+		{existing.ErrorAuthFailed, desired.ErrorAuthFailed, "error_auth_failed"},
+		{existing.ErrorHeadersAuthMissing, desired.ErrorHeadersAuthMissing, "error_headers_auth_missing"},
+		{existing.ErrorAuthMissing, desired.ErrorAuthMissing, "error_auth_missing"},
+		{existing.ErrorHeadersNoMatch, desired.ErrorHeadersNoMatch, "error_headers_no_match"},
+		{existing.ErrorNoMatch, desired.ErrorNoMatch, "error_no_match"},
+		{existing.ErrorHeadersLimitsExceeded, desired.ErrorHeadersLimitsExceeded, "error_headers_limits_exceeded"},
+		{existing.ErrorLimitsExceeded, desired.ErrorLimitsExceeded, "error_limits_exceeded"},
+		{existing.OidcIssuerEndpoint, desired.OidcIssuerEndpoint, "oidc_issuer_endpoint"},
+		{existing.OidcIssuerType, desired.OidcIssuerType, "oidc_issuer_type"},
+		{existing.JwtClaimWithClientID, desired.JwtClaimWithClientID, "jwt_claim_with_client_id"},
+		{existing.JwtClaimWithClientIDType, desired.JwtClaimWithClientIDType, "jwt_claim_with_client_id_type"},
+	}
+	for _, setting := range strSettings {
+		if setting.existing != setting.desired {
+			params[setting.param] = setting.desired
+		}
+	}
+
+	return params
+}
+
+// NewMappingRuleItem returns the 3scale mapping rule of the mapping rule spec at the given one-based position
+func NewMappingRuleItem(spec capabilitiesv1beta1.MappingRuleSpec, position int, metricID int64) threescaleapi.MappingRuleItem {
+	item := threescaleapi.MappingRuleItem{
+		MetricID:   metricID,
+		Pattern:    spec.Pattern,
+		HTTPMethod: spec.HTTPMethod,
+		Delta:      spec.Increment,
+		Position:   position,
+	}
+	if spec.Last != nil {
+		item.Last = *spec.Last
+	}
+	return item
+}
+
+// NewMappingRuleParams returns the 3scale params creating the desired mapping rule
+func NewMappingRuleParams(desired threescaleapi.MappingRuleItem) threescaleapi.Params {
+	return threescaleapi.Params{
+		"pattern":     desired.Pattern,
+		"http_method": desired.HTTPMethod,
+		"metric_id":   strconv.FormatInt(desired.MetricID, 10),
+		"delta":       strconv.Itoa(desired.Delta),
+		"last":        strconv.FormatBool(desired.Last),
+		"position":    strconv.Itoa(desired.Position),
+	}
+}
+
+// MappingRuleParams returns the 3scale params updating the existing mapping rule to the desired one
+func MappingRuleParams(existing, desired threescaleapi.MappingRuleItem) threescaleapi.Params {
+	params := threescaleapi.Params{}
+
+	if desired.MetricID != existing.MetricID {
+		params["metric_id"] = strconv.FormatInt(desired.MetricID, 10)
+	}
+
+	if desired.Delta != existing.Delta {
+		params["delta"] = strconv.Itoa(desired.Delta)
+	}
+
+	if desired.Last != existing.Last {
+		params["last"] = strconv.FormatBool(desired.Last)
+	}
+
+	if desired.Position != existing.Position {
+		params["position"] = strconv.Itoa(desired.Position)
+	}
+
+	return params
+}
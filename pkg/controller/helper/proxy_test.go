@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+func TestDesiredProxyItem(t *testing.T) {
+	getSecret := func(key types.NamespacedName) (*corev1.Secret, error) {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name},
+			Data:       map[string][]byte{OIDCIssuerEndpointSecretField: []byte("https://sso.example.com/auth/realms/petstore")},
+		}, nil
+	}
+
+	product := &capabilitiesv1beta1.Product{
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Deployment: &capabilitiesv1beta1.ProductDeploymentSpec{
+				ApicastSelfManaged: &capabilitiesv1beta1.ApicastSelfManagedSpec{
+					ProductionPublicBaseURL: ptr.To("https://petstore.example.com"),
+					Authentication: &capabilitiesv1beta1.AuthenticationSpec{
+						OIDC: &capabilitiesv1beta1.OIDCSpec{
+							IssuerType:        "keycloak",
+							IssuerEndpointRef: &corev1.SecretReference{Name: "oidc"},
+							Security:          &capabilitiesv1beta1.SecuritySpec{HostHeader: ptr.To("petstore.internal")},
+							GatewayResponse:   &capabilitiesv1beta1.GatewayResponseSpec{ErrorStatusNoMatch: ptr.To[int32](405)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	existing := NewDefaultProxyItem()
+	existing.Endpoint = "https://petstore.example.com:443"
+	existing.SandboxEndpoint = "https://staging.petstore.example.com"
+
+	desired, err := DesiredProxyItem(product, existing, getSecret)
+	if err != nil {
+		t.Fatalf("DesiredProxyItem() error = %v", err)
+	}
+
+	params := ProxyParams(existing, desired)
+	equals(t, threescaleapi.Params{
+		"hostname_rewrite":      "petstore.internal",
+		"error_status_no_match": "405",
+		"oidc_issuer_endpoint":  "https://sso.example.com/auth/realms/petstore",
+		"oidc_issuer_type":      "keycloak",
+	}, params)
+
+	// Nothing to update once synchronized
+	equals(t, threescaleapi.Params{}, ProxyParams(desired, desired))
+
+	t.Run("oidc issuer endpoint missing", func(subT *testing.T) {
+		product.Spec.Deployment.ApicastSelfManaged.Authentication.OIDC.IssuerEndpointRef = nil
+		_, err := DesiredProxyItem(product, existing, getSecret)
+		equals(subT, true, helper.IsInvalidSpecError(err))
+	})
+}
+
+func TestMappingRuleParams(t *testing.T) {
+	spec := capabilitiesv1beta1.MappingRuleSpec{HTTPMethod: "GET", Pattern: "/pets", MetricMethodRef: "hits", Increment: 2}
+	existing := threescaleapi.MappingRuleItem{ID: 1, MetricID: 10, Pattern: "/pets", HTTPMethod: "GET", Delta: 1, Position: 1, Last: true}
+
+	desired := NewMappingRuleItem(spec, 2, 10)
+	equals(t, threescaleapi.Params{"delta": "2", "last": "false", "position": "2"}, MappingRuleParams(existing, desired))
+
+	equals(t, threescaleapi.Params{
+		"pattern":     "/pets",
+		"http_method": "GET",
+		"metric_id":   "10",
+		"delta":       "2",
+		"last":        "false",
+		"position":    "2",
+	}, NewMappingRuleParams(desired))
+}
@@ -0,0 +1,129 @@
+package helper
+
+import (
+	"reflect"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	// ResyncPeriodAnnotation sets the period between synchronizations of the custom resource with 3scale.
+	// Overrides the operator wide resync period. "0" disables the periodic synchronization
+	ResyncPeriodAnnotation = "capabilities.3scale.net/resync-period"
+
+	// ResyncPeriodEnvVar sets the operator wide period between synchronizations of capabilities custom resources with 3scale.
+	// Periodic synchronization is disabled when not set
+	ResyncPeriodEnvVar = "CAPABILITIES_RESYNC_PERIOD"
+
+	// resyncJitterFactor spreads the synchronizations of custom resources reconciled at the same time
+	resyncJitterFactor = 0.1
+)
+
+// GetResyncPeriod returns the period between synchronizations of the custom resource with 3scale.
+// Values are go durations, for instance "10m" or "1h". Zero when the periodic synchronization is disabled
+func GetResyncPeriod(annotations map[string]string) time.Duration {
+	if value, ok := annotations[ResyncPeriodAnnotation]; ok {
+		if period, err := time.ParseDuration(value); err == nil && period >= 0 {
+			return period
+		}
+	}
+
+	period, err := time.ParseDuration(helper.GetEnvVar(ResyncPeriodEnvVar, "0"))
+	if err != nil || period < 0 {
+		return 0
+	}
+
+	return period
+}
+
+// WithResync schedules the next periodic synchronization of a successfully reconciled custom resource.
+// Sooner requeues already requested by the controller are kept
+func WithResync(result ctrl.Result, annotations map[string]string) ctrl.Result {
+	if result.Requeue && result.RequeueAfter == 0 {
+		return result
+	}
+
+	period := GetResyncPeriod(annotations)
+	if period == 0 {
+		return result
+	}
+
+	resync := wait.Jitter(period, resyncJitterFactor)
+	if result.RequeueAfter > 0 && result.RequeueAfter < resync {
+		return result
+	}
+
+	result.RequeueAfter = resync
+	return result
+}
+
+// LastSyncTime returns the current time when the custom resource has been synchronized successfully
+// and the resync period has passed since the previous synchronization time, the previous synchronization time otherwise.
+// Refreshing it on every reconciliation would write the status every time. Nil when the periodic synchronization is disabled
+func LastSyncTime(previous *metav1.Time, syncError error, annotations map[string]string) *metav1.Time {
+	if syncError != nil {
+		return previous
+	}
+
+	period := GetResyncPeriod(annotations)
+	if period == 0 {
+		return nil
+	}
+
+	now := metav1.Now()
+	if previous != nil && now.Sub(previous.Time) < period {
+		return previous
+	}
+
+	return &now
+}
+
+// IgnoreLastSyncTimeUpdates filters out the update events caused only by refreshing the last sync time in the status.
+// Otherwise, every successful synchronization would trigger a new one
+func IgnoreLastSyncTimeUpdates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !onlyLastSyncTimeChanged(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
+
+func onlyLastSyncTimeChanged(oldObj, newObj client.Object) bool {
+	if oldObj == nil || newObj == nil {
+		return false
+	}
+
+	oldMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(oldObj)
+	if err != nil {
+		return false
+	}
+
+	newMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newObj)
+	if err != nil {
+		return false
+	}
+
+	oldSyncTime, _, _ := unstructured.NestedFieldNoCopy(oldMap, "status", "lastSyncTime")
+	newSyncTime, _, _ := unstructured.NestedFieldNoCopy(newMap, "status", "lastSyncTime")
+	if reflect.DeepEqual(oldSyncTime, newSyncTime) {
+		return false
+	}
+
+	for _, obj := range []map[string]interface{}{oldMap, newMap} {
+		unstructured.RemoveNestedField(obj, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(obj, "metadata", "managedFields")
+		unstructured.RemoveNestedField(obj, "status", "lastSyncTime")
+	}
+
+	return reflect.DeepEqual(oldMap, newMap)
+}
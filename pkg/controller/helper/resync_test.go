@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+func TestGetResyncPeriod(t *testing.T) {
+	cases := []struct {
+		testName    string
+		envVar      string
+		annotations map[string]string
+		expected    time.Duration
+	}{
+		{"disabled by default", "", nil, 0},
+		{"operator wide", "30m", nil, 30 * time.Minute},
+		{"invalid operator wide", "often", nil, 0},
+		{"negative operator wide", "-1m", nil, 0},
+		{"annotation", "", map[string]string{ResyncPeriodAnnotation: "1h"}, time.Hour},
+		{"annotation overrides operator wide", "30m", map[string]string{ResyncPeriodAnnotation: "5m"}, 5 * time.Minute},
+		{"annotation disables", "30m", map[string]string{ResyncPeriodAnnotation: "0"}, 0},
+		{"invalid annotation", "30m", map[string]string{ResyncPeriodAnnotation: "often"}, 30 * time.Minute},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			subT.Setenv(ResyncPeriodEnvVar, tc.envVar)
+			equals(subT, tc.expected, GetResyncPeriod(tc.annotations))
+		})
+	}
+}
+
+func TestWithResync(t *testing.T) {
+	t.Setenv(ResyncPeriodEnvVar, "")
+	annotations := map[string]string{ResyncPeriodAnnotation: "10m"}
+	maxResync := time.Duration(float64(10*time.Minute) * (1 + resyncJitterFactor))
+
+	t.Run("disabled", func(subT *testing.T) {
+		equals(subT, ctrl.Result{}, WithResync(ctrl.Result{}, nil))
+	})
+
+	t.Run("resync scheduled with jitter", func(subT *testing.T) {
+		result := WithResync(ctrl.Result{}, annotations)
+		equals(subT, true, result.RequeueAfter >= 10*time.Minute && result.RequeueAfter <= maxResync)
+	})
+
+	t.Run("sooner requeue kept", func(subT *testing.T) {
+		equals(subT, ctrl.Result{RequeueAfter: time.Minute}, WithResync(ctrl.Result{RequeueAfter: time.Minute}, annotations))
+	})
+
+	t.Run("immediate requeue kept", func(subT *testing.T) {
+		equals(subT, ctrl.Result{Requeue: true}, WithResync(ctrl.Result{Requeue: true}, annotations))
+	})
+
+	t.Run("later requeue replaced", func(subT *testing.T) {
+		result := WithResync(ctrl.Result{RequeueAfter: time.Hour}, annotations)
+		equals(subT, true, result.RequeueAfter >= 10*time.Minute && result.RequeueAfter <= maxResync)
+	})
+}
+
+func TestLastSyncTime(t *testing.T) {
+	previous := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	annotations := map[string]string{ResyncPeriodAnnotation: "10m"}
+
+	equals(t, &previous, LastSyncTime(&previous, errors.New("sync failed"), annotations))
+
+	current := LastSyncTime(&previous, nil, annotations)
+	equals(t, true, current != nil && current.After(previous.Time))
+
+	first := LastSyncTime(nil, nil, annotations)
+	equals(t, true, first != nil)
+
+	recent := metav1.NewTime(time.Now().Add(-time.Minute))
+	equals(t, &recent, LastSyncTime(&recent, nil, annotations))
+
+	var disabled *metav1.Time
+	equals(t, disabled, LastSyncTime(&previous, nil, map[string]string{ResyncPeriodAnnotation: "0"}))
+}
+
+func TestOnlyLastSyncTimeChanged(t *testing.T) {
+	previous := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	current := metav1.NewTime(time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC))
+
+	newBackend := func(resourceVersion, name string, syncTime *metav1.Time) *capabilitiesv1beta1.Backend {
+		return &capabilitiesv1beta1.Backend{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "test", ResourceVersion: resourceVersion},
+			Spec:       capabilitiesv1beta1.BackendSpec{Name: name},
+			Status:     capabilitiesv1beta1.BackendStatus{LastSyncTime: syncTime},
+		}
+	}
+
+	cases := []struct {
+		testName string
+		oldObj   *capabilitiesv1beta1.Backend
+		newObj   *capabilitiesv1beta1.Backend
+		expected bool
+	}{
+		{"first sync", newBackend("1", "a", nil), newBackend("2", "a", &current), true},
+		{"sync time refreshed", newBackend("1", "a", &previous), newBackend("2", "a", &current), true},
+		{"nothing changed", newBackend("1", "a", &previous), newBackend("1", "a", &previous), false},
+		{"spec changed", newBackend("1", "a", &previous), newBackend("2", "b", &previous), false},
+		{"spec and sync time changed", newBackend("1", "a", &previous), newBackend("2", "b", &current), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			equals(subT, tc.expected, onlyLastSyncTimeChanged(tc.oldObj, tc.newObj))
+		})
+	}
+}
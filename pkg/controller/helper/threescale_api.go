@@ -40,6 +40,11 @@ func PortaClientFromURL(url *url.URL, token string, insecureSkipVerify bool) (*t
 		return nil, err
 	}
 
+	return threescaleapi.NewThreeScale(adminPortal, token, threescaleHTTPClient(insecureSkipVerify)), nil
+}
+
+// threescaleHTTPClient builds the http client used to reach the 3scale admin API
+func threescaleHTTPClient(insecureSkipVerify bool) *http.Client {
 	// Activated by some env var or Spec param
 	var transport http.RoundTripper = &http.Transport{
 		Proxy:           http.ProxyFromEnvironment,
@@ -50,7 +55,7 @@ func PortaClientFromURL(url *url.URL, token string, insecureSkipVerify bool) (*t
 		transport = &helper.Transport{Transport: transport}
 	}
 
-	return threescaleapi.NewThreeScale(adminPortal, token, &http.Client{Transport: transport}), nil
+	return &http.Client{Transport: transport}
 }
 
 // GetInsecureSkipVerifyAnnotation extracts the insecure_skip_verify annotation from an object
@@ -0,0 +1,141 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	productFeatureListResourceEndpoint = "/admin/api/services/%d/features.json"
+	productFeatureResourceEndpoint     = "/admin/api/services/%d/features/%d.json"
+	accountFeatureListResourceEndpoint = "/admin/api/features.json"
+	accountFeatureResourceEndpoint     = "/admin/api/features/%d.json"
+	applicationPlanFeatureListEndpoint = "/admin/api/application_plans/%d/features.json"
+	applicationPlanFeatureEndpoint     = "/admin/api/application_plans/%d/features/%d.json"
+	servicePlanFeatureListEndpoint     = "/admin/api/service_plans/%d/features.json"
+	servicePlanFeatureEndpoint         = "/admin/api/service_plans/%d/features/%d.json"
+	accountPlanFeatureListEndpoint     = "/admin/api/account_plans/%d/features.json"
+	accountPlanFeatureEndpoint         = "/admin/api/account_plans/%d/features/%d.json"
+	FeatureScopeApplicationPlan        = "ApplicationPlan"
+	FeatureScopeServicePlan            = "ServicePlan"
+	FeatureScopeAccountPlan            = "AccountPlan"
+)
+
+// FeatureItem holds a plan feature serialized/unserialized in json format
+type FeatureItem struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	SystemName  string `json:"system_name"`
+	Scope       string `json:"scope"`
+	Visible     bool   `json:"visible"`
+	Description string `json:"description"`
+}
+
+type Feature struct {
+	Element FeatureItem `json:"feature"`
+}
+
+type FeatureList struct {
+	Features []Feature `json:"features"`
+}
+
+// ListProductFeatures lists the features of a product, available to its application plans and service plans
+func (c *PlansAPIClient) ListProductFeatures(productID int64) (*FeatureList, error) {
+	return c.listFeatures(fmt.Sprintf(productFeatureListResourceEndpoint, productID))
+}
+
+// CreateProductFeature creates a feature of a product
+func (c *PlansAPIClient) CreateProductFeature(productID int64, params threescaleapi.Params) (*Feature, error) {
+	item := &Feature{}
+	err := c.do(http.MethodPost, fmt.Sprintf(productFeatureListResourceEndpoint, productID), params, http.StatusCreated, item)
+	return item, err
+}
+
+// UpdateProductFeature updates a feature of a product
+func (c *PlansAPIClient) UpdateProductFeature(productID, id int64, params threescaleapi.Params) (*Feature, error) {
+	item := &Feature{}
+	err := c.do(http.MethodPut, fmt.Sprintf(productFeatureResourceEndpoint, productID, id), params, http.StatusOK, item)
+	return item, err
+}
+
+// DeleteProductFeature deletes a feature of a product. 3scale disables it on every plan
+func (c *PlansAPIClient) DeleteProductFeature(productID, id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(productFeatureResourceEndpoint, productID, id), nil, http.StatusOK, nil)
+}
+
+// ListAccountFeatures lists the features of the tenant, available to its account plans
+func (c *PlansAPIClient) ListAccountFeatures() (*FeatureList, error) {
+	return c.listFeatures(accountFeatureListResourceEndpoint)
+}
+
+// CreateAccountFeature creates a feature of the tenant
+func (c *PlansAPIClient) CreateAccountFeature(params threescaleapi.Params) (*Feature, error) {
+	item := &Feature{}
+	err := c.do(http.MethodPost, accountFeatureListResourceEndpoint, params, http.StatusCreated, item)
+	return item, err
+}
+
+// UpdateAccountFeature updates a feature of the tenant
+func (c *PlansAPIClient) UpdateAccountFeature(id int64, params threescaleapi.Params) (*Feature, error) {
+	item := &Feature{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountFeatureResourceEndpoint, id), params, http.StatusOK, item)
+	return item, err
+}
+
+// ListApplicationPlanFeatures lists the features enabled on an application plan
+func (c *PlansAPIClient) ListApplicationPlanFeatures(planID int64) (*FeatureList, error) {
+	return c.listFeatures(fmt.Sprintf(applicationPlanFeatureListEndpoint, planID))
+}
+
+// EnableApplicationPlanFeature enables a product feature on an application plan
+func (c *PlansAPIClient) EnableApplicationPlanFeature(planID, featureID int64) error {
+	return c.enableFeature(fmt.Sprintf(applicationPlanFeatureListEndpoint, planID), featureID)
+}
+
+// DisableApplicationPlanFeature disables a product feature on an application plan
+func (c *PlansAPIClient) DisableApplicationPlanFeature(planID, featureID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(applicationPlanFeatureEndpoint, planID, featureID), nil, http.StatusOK, nil)
+}
+
+// ListServicePlanFeatures lists the features enabled on a service plan
+func (c *PlansAPIClient) ListServicePlanFeatures(planID int64) (*FeatureList, error) {
+	return c.listFeatures(fmt.Sprintf(servicePlanFeatureListEndpoint, planID))
+}
+
+// EnableServicePlanFeature enables a product feature on a service plan
+func (c *PlansAPIClient) EnableServicePlanFeature(planID, featureID int64) error {
+	return c.enableFeature(fmt.Sprintf(servicePlanFeatureListEndpoint, planID), featureID)
+}
+
+// DisableServicePlanFeature disables a product feature on a service plan
+func (c *PlansAPIClient) DisableServicePlanFeature(planID, featureID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(servicePlanFeatureEndpoint, planID, featureID), nil, http.StatusOK, nil)
+}
+
+// ListAccountPlanFeatures lists the features enabled on an account plan
+func (c *PlansAPIClient) ListAccountPlanFeatures(planID int64) (*FeatureList, error) {
+	return c.listFeatures(fmt.Sprintf(accountPlanFeatureListEndpoint, planID))
+}
+
+// EnableAccountPlanFeature enables a tenant feature on an account plan
+func (c *PlansAPIClient) EnableAccountPlanFeature(planID, featureID int64) error {
+	return c.enableFeature(fmt.Sprintf(accountPlanFeatureListEndpoint, planID), featureID)
+}
+
+// DisableAccountPlanFeature disables a tenant feature on an account plan
+func (c *PlansAPIClient) DisableAccountPlanFeature(planID, featureID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(accountPlanFeatureEndpoint, planID, featureID), nil, http.StatusOK, nil)
+}
+
+func (c *PlansAPIClient) listFeatures(endpoint string) (*FeatureList, error) {
+	list := &FeatureList{}
+	err := c.do(http.MethodGet, endpoint, nil, http.StatusOK, list)
+	return list, err
+}
+
+func (c *PlansAPIClient) enableFeature(endpoint string, featureID int64) error {
+	params := threescaleapi.Params{"feature_id": fmt.Sprint(featureID)}
+	return c.do(http.MethodPost, endpoint, params, http.StatusCreated, nil)
+}
@@ -0,0 +1,342 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	accountPlanListResourceEndpoint    = "/admin/api/account_plans.json"
+	accountPlanResourceEndpoint        = "/admin/api/account_plans/%d.json"
+	accountPlanDefaultResourceEndpoint = "/admin/api/account_plans/%d/default.json"
+	servicePlanListResourceEndpoint    = "/admin/api/services/%d/service_plans.json"
+	servicePlanResourceEndpoint        = "/admin/api/services/%d/service_plans/%d.json"
+	servicePlanDefaultResourceEndpoint = "/admin/api/services/%d/service_plans/%d/default.json"
+	accountPlanOfAccountEndpoint       = "/admin/api/accounts/%d/plan.json"
+	accountChangePlanEndpoint          = "/admin/api/accounts/%d/change_plan.json"
+	applicationListByPlanEndpoint      = "/admin/api/applications.json?plan_id=%d&page=%d&per_page=%d"
+	ApplicationListPerPage             = 500
+	accountListEndpoint                = "/admin/api/accounts.json?page=%d&per_page=%d"
+	AccountListPerPage                 = 500
+	serviceSubscriptionListEndpoint    = "/admin/api/accounts/%d/service_subscriptions.json"
+)
+
+// PlanItem holds an account plan or service plan serialized/unserialized in json format
+type PlanItem struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	SystemName       string  `json:"system_name"`
+	State            string  `json:"state"`
+	SetupFee         float64 `json:"setup_fee"`
+	CostPerMonth     float64 `json:"cost_per_month"`
+	TrialPeriodDays  int     `json:"trial_period_days"`
+	ApprovalRequired bool    `json:"approval_required"`
+	Default          bool    `json:"default"`
+}
+
+type AccountPlan struct {
+	Element PlanItem `json:"account_plan"`
+}
+
+type AccountPlanList struct {
+	Plans []AccountPlan `json:"plans"`
+}
+
+type ServicePlan struct {
+	Element PlanItem `json:"service_plan"`
+}
+
+type ServicePlanList struct {
+	Plans []ServicePlan `json:"plans"`
+}
+
+// AccountItem holds the account fields needed to look up its service subscriptions
+type AccountItem struct {
+	ID    int64  `json:"id"`
+	State string `json:"state"`
+}
+
+type Account struct {
+	Element AccountItem `json:"account"`
+}
+
+type AccountList struct {
+	Accounts []Account `json:"accounts"`
+}
+
+// ServiceSubscriptionItem holds a subscription of an account to a service plan
+type ServiceSubscriptionItem struct {
+	ID        int64  `json:"id"`
+	PlanID    int64  `json:"plan_id"`
+	ServiceID int64  `json:"service_id"`
+	State     string `json:"state"`
+}
+
+type ServiceSubscription struct {
+	Element ServiceSubscriptionItem `json:"service_subscription"`
+}
+
+type ServiceSubscriptionList struct {
+	Subscriptions []ServiceSubscription `json:"service_subscriptions"`
+}
+
+// PlansAPIError is returned when the 3scale admin API answers with an unexpected status code
+type PlansAPIError struct {
+	Code    int
+	Message string
+}
+
+func (e *PlansAPIError) Error() string {
+	return fmt.Sprintf("error calling 3scale system - reason: %s - code: %d", e.Message, e.Code)
+}
+
+// IsPlansAPINotFound returns true if the error is a not found answer from the 3scale admin API
+func IsPlansAPINotFound(err error) bool {
+	apiErr, ok := err.(*PlansAPIError)
+	return ok && apiErr.Code == http.StatusNotFound
+}
+
+// PlansAPIClient reaches the 3scale admin API endpoints of account plans and service plans,
+// not covered by porta_client.ThreeScaleClient
+type PlansAPIClient struct {
+	adminURL   *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// NewPlansAPIClient instantiates PlansAPIClient from ProviderAccount object
+func NewPlansAPIClient(providerAccount *ProviderAccount, insecureSkipVerify bool) (*PlansAPIClient, error) {
+	adminURL, err := url.Parse(providerAccount.AdminURLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if adminURL.Scheme == "" || adminURL.Host == "" {
+		return nil, fmt.Errorf("invalid admin url: %s", providerAccount.AdminURLStr)
+	}
+
+	return &PlansAPIClient{
+		adminURL:   adminURL,
+		token:      providerAccount.Token,
+		httpClient: threescaleHTTPClient(insecureSkipVerify),
+	}, nil
+}
+
+// ListAccountPlans lists the account plans of the tenant
+func (c *PlansAPIClient) ListAccountPlans() (*AccountPlanList, error) {
+	list := &AccountPlanList{}
+	err := c.do(http.MethodGet, accountPlanListResourceEndpoint, nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateAccountPlan creates an account plan
+func (c *PlansAPIClient) CreateAccountPlan(params threescaleapi.Params) (*AccountPlan, error) {
+	item := &AccountPlan{}
+	err := c.do(http.MethodPost, accountPlanListResourceEndpoint, params, http.StatusCreated, item)
+	return item, err
+}
+
+// UpdateAccountPlan updates an account plan
+func (c *PlansAPIClient) UpdateAccountPlan(id int64, params threescaleapi.Params) (*AccountPlan, error) {
+	item := &AccountPlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountPlanResourceEndpoint, id), params, http.StatusOK, item)
+	return item, err
+}
+
+// DeleteAccountPlan deletes an account plan
+func (c *PlansAPIClient) DeleteAccountPlan(id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(accountPlanResourceEndpoint, id), nil, http.StatusOK, nil)
+}
+
+// SetDefaultAccountPlan makes the account plan the default one of the tenant
+func (c *PlansAPIClient) SetDefaultAccountPlan(id int64) (*AccountPlan, error) {
+	item := &AccountPlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(accountPlanDefaultResourceEndpoint, id), nil, http.StatusOK, item)
+	return item, err
+}
+
+// AccountPlanOfAccount reads the account plan a developer account is subscribed to
+func (c *PlansAPIClient) AccountPlanOfAccount(accountID int64) (*AccountPlan, error) {
+	item := &AccountPlan{}
+	err := c.do(http.MethodGet, fmt.Sprintf(accountPlanOfAccountEndpoint, accountID), nil, http.StatusOK, item)
+	return item, err
+}
+
+// ChangeAccountPlan subscribes a developer account to the account plan
+func (c *PlansAPIClient) ChangeAccountPlan(accountID, planID int64) error {
+	params := threescaleapi.Params{"plan_id": fmt.Sprint(planID)}
+	return c.do(http.MethodPut, fmt.Sprintf(accountChangePlanEndpoint, accountID), params, http.StatusOK, nil)
+}
+
+// ListServicePlans lists the service plans of a product
+func (c *PlansAPIClient) ListServicePlans(productID int64) (*ServicePlanList, error) {
+	list := &ServicePlanList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(servicePlanListResourceEndpoint, productID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// CreateServicePlan creates a service plan of a product
+func (c *PlansAPIClient) CreateServicePlan(productID int64, params threescaleapi.Params) (*ServicePlan, error) {
+	item := &ServicePlan{}
+	err := c.do(http.MethodPost, fmt.Sprintf(servicePlanListResourceEndpoint, productID), params, http.StatusCreated, item)
+	return item, err
+}
+
+// UpdateServicePlan updates a service plan of a product
+func (c *PlansAPIClient) UpdateServicePlan(productID, id int64, params threescaleapi.Params) (*ServicePlan, error) {
+	item := &ServicePlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(servicePlanResourceEndpoint, productID, id), params, http.StatusOK, item)
+	return item, err
+}
+
+// DeleteServicePlan deletes a service plan of a product
+func (c *PlansAPIClient) DeleteServicePlan(productID, id int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(servicePlanResourceEndpoint, productID, id), nil, http.StatusOK, nil)
+}
+
+// SetDefaultServicePlan makes the service plan the default one of the product
+func (c *PlansAPIClient) SetDefaultServicePlan(productID, id int64) (*ServicePlan, error) {
+	item := &ServicePlan{}
+	err := c.do(http.MethodPut, fmt.Sprintf(servicePlanDefaultResourceEndpoint, productID, id), nil, http.StatusOK, item)
+	return item, err
+}
+
+// ListApplicationsByPlan lists a page of the applications subscribed to an application plan.
+// Porta client lists all the applications of the tenant and the filter cannot be applied
+func (c *PlansAPIClient) ListApplicationsByPlan(planID int64, page int) (*threescaleapi.ApplicationList, error) {
+	list := &threescaleapi.ApplicationList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationListByPlanEndpoint, planID, page, ApplicationListPerPage), nil, http.StatusOK, list)
+	return list, err
+}
+
+// ListAccounts lists a page of the developer accounts of the tenant
+func (c *PlansAPIClient) ListAccounts(page int) (*AccountList, error) {
+	list := &AccountList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(accountListEndpoint, page, AccountListPerPage), nil, http.StatusOK, list)
+	return list, err
+}
+
+// ListServiceSubscriptions lists the service subscriptions of a developer account
+func (c *PlansAPIClient) ListServiceSubscriptions(accountID int64) (*ServiceSubscriptionList, error) {
+	list := &ServiceSubscriptionList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(serviceSubscriptionListEndpoint, accountID), nil, http.StatusOK, list)
+	return list, err
+}
+
+// SubscribedServicePlans returns which of the service plans of the product have developer accounts subscribed.
+// The 3scale admin API lists service subscriptions per account only,
+// accounts are scanned until all the service plans are found subscribed
+func (c *PlansAPIClient) SubscribedServicePlans(productID int64, planIDs []int64) (map[int64]bool, error) {
+	subscribed := map[int64]bool{}
+	if len(planIDs) == 0 {
+		return subscribed, nil
+	}
+
+	lookedUp := map[int64]bool{}
+	for _, planID := range planIDs {
+		lookedUp[planID] = true
+	}
+
+	err := c.scanAccounts(func(account AccountItem) (bool, error) {
+		list, err := c.ListServiceSubscriptions(account.ID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, subscription := range list.Subscriptions {
+			if subscription.Element.ServiceID == productID && lookedUp[subscription.Element.PlanID] {
+				subscribed[subscription.Element.PlanID] = true
+			}
+		}
+
+		return len(subscribed) == len(lookedUp), nil
+	})
+
+	return subscribed, err
+}
+
+// AccountPlanSubscribed returns true when developer accounts are subscribed to the account plan.
+// Accounts are scanned until one subscribed to the account plan is found
+func (c *PlansAPIClient) AccountPlanSubscribed(planID int64) (bool, error) {
+	subscribed := false
+	err := c.scanAccounts(func(account AccountItem) (bool, error) {
+		plan, err := c.AccountPlanOfAccount(account.ID)
+		if err != nil {
+			return false, err
+		}
+
+		subscribed = plan.Element.ID == planID
+		return subscribed, nil
+	})
+
+	return subscribed, err
+}
+
+// scanAccounts visits the developer accounts of the tenant, page by page, until visit returns true
+func (c *PlansAPIClient) scanAccounts(visit func(AccountItem) (bool, error)) error {
+	for page := 1; ; page++ {
+		list, err := c.ListAccounts(page)
+		if err != nil {
+			return err
+		}
+
+		for _, account := range list.Accounts {
+			done, err := visit(account.Element)
+			if err != nil || done {
+				return err
+			}
+		}
+
+		if len(list.Accounts) < AccountListPerPage {
+			return nil
+		}
+	}
+}
+
+func (c *PlansAPIClient) do(method, endpoint string, params threescaleapi.Params, expectCode int, decodeInto interface{}) error {
+	values := url.Values{}
+	for k, v := range params {
+		values.Add(k, v)
+	}
+
+	var body io.Reader
+	if len(values) > 0 {
+		body = strings.NewReader(values.Encode())
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.adminURL.String(), "/")+endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+c.token)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectCode {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &PlansAPIError{Code: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if decodeInto == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(decodeInto); err != nil {
+		return &PlansAPIError{Code: resp.StatusCode, Message: fmt.Sprintf("decoding error - %s", err)}
+	}
+
+	return nil
+}
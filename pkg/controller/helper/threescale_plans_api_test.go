@@ -0,0 +1,226 @@
+package helper
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPlansAPITestServer(t *testing.T, handler http.HandlerFunc) *PlansAPIClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(":some token"))
+		if r.Header.Get("Authorization") != expectedAuth {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewPlansAPIClient(&ProviderAccount{AdminURLStr: server.URL, Token: "some token"}, false)
+	ok(t, err)
+	return client
+}
+
+func TestNewPlansAPIClientInvalidURL(t *testing.T) {
+	_, err := NewPlansAPIClient(&ProviderAccount{AdminURLStr: ":foo", Token: "some token"}, false)
+	assert(t, err != nil, "error should not be nil")
+
+	_, err = NewPlansAPIClient(&ProviderAccount{AdminURLStr: "somedomain.example.com", Token: "some token"}, false)
+	assert(t, err != nil, "error should not be nil")
+}
+
+func TestPlansAPIClientAccountPlans(t *testing.T) {
+	client := newPlansAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/account_plans.json":
+			fmt.Fprint(w, `{"plans":[{"account_plan":{"id":1,"name":"Default","system_name":"default","state":"published","default":true}}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/api/account_plans.json":
+			ok(t, r.ParseForm())
+			equals(t, "basic", r.PostForm.Get("system_name"))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"account_plan":{"id":2,"name":"%s","system_name":"basic","state":"hidden"}}`, r.PostForm.Get("name"))
+		case r.Method == http.MethodPut && r.URL.Path == "/admin/api/account_plans/2.json":
+			ok(t, r.ParseForm())
+			equals(t, "publish", r.PostForm.Get("state_event"))
+			fmt.Fprint(w, `{"account_plan":{"id":2,"name":"Basic","system_name":"basic","state":"published"}}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/admin/api/account_plans/2/default.json":
+			fmt.Fprint(w, `{"account_plan":{"id":2,"name":"Basic","system_name":"basic","state":"published","default":true}}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/admin/api/account_plans/2.json":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"Not found"}`)
+		}
+	})
+
+	list, err := client.ListAccountPlans()
+	ok(t, err)
+	equals(t, 1, len(list.Plans))
+	equals(t, PlanItem{ID: 1, Name: "Default", SystemName: "default", State: "published", Default: true}, list.Plans[0].Element)
+
+	created, err := client.CreateAccountPlan(map[string]string{"system_name": "basic", "name": "Basic"})
+	ok(t, err)
+	equals(t, int64(2), created.Element.ID)
+	equals(t, "Basic", created.Element.Name)
+
+	updated, err := client.UpdateAccountPlan(2, map[string]string{"state_event": "publish"})
+	ok(t, err)
+	equals(t, "published", updated.Element.State)
+
+	defaultPlan, err := client.SetDefaultAccountPlan(2)
+	ok(t, err)
+	assert(t, defaultPlan.Element.Default, "account plan should be the default one")
+
+	ok(t, client.DeleteAccountPlan(2))
+
+	err = client.DeleteAccountPlan(3)
+	assert(t, IsPlansAPINotFound(err), "expected not found error, got %v", err)
+}
+
+func TestPlansAPIClientServicePlans(t *testing.T) {
+	client := newPlansAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/services/10/service_plans.json":
+			fmt.Fprint(w, `{"plans":[{"service_plan":{"id":5,"name":"Default","system_name":"default","state":"published","default":true}}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/api/services/10/service_plans.json":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"service_plan":{"id":6,"name":"premium","system_name":"premium","state":"hidden"}}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/admin/api/services/10/service_plans/6.json":
+			ok(t, r.ParseForm())
+			fmt.Fprintf(w, `{"service_plan":{"id":6,"name":"%s","system_name":"premium","state":"hidden"}}`, r.PostForm.Get("name"))
+		case r.Method == http.MethodPut && r.URL.Path == "/admin/api/services/10/service_plans/6/default.json":
+			fmt.Fprint(w, `{"service_plan":{"id":6,"name":"Premium","system_name":"premium","state":"hidden","default":true}}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/admin/api/services/10/service_plans/5.json":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":{"base":["unexpected"]}}`)
+		}
+	})
+
+	list, err := client.ListServicePlans(10)
+	ok(t, err)
+	equals(t, 1, len(list.Plans))
+	equals(t, "default", list.Plans[0].Element.SystemName)
+
+	created, err := client.CreateServicePlan(10, map[string]string{"system_name": "premium", "name": "premium"})
+	ok(t, err)
+	equals(t, int64(6), created.Element.ID)
+
+	updated, err := client.UpdateServicePlan(10, 6, map[string]string{"name": "Premium"})
+	ok(t, err)
+	equals(t, "Premium", updated.Element.Name)
+
+	defaultPlan, err := client.SetDefaultServicePlan(10, 6)
+	ok(t, err)
+	assert(t, defaultPlan.Element.Default, "service plan should be the default one")
+
+	ok(t, client.DeleteServicePlan(10, 5))
+
+	err = client.DeleteServicePlan(10, 6)
+	apiErr, isAPIErr := err.(*PlansAPIError)
+	assert(t, isAPIErr, "expected plans API error, got %v", err)
+	equals(t, http.StatusUnprocessableEntity, apiErr.Code)
+	assert(t, !IsPlansAPINotFound(err), "unexpected not found error")
+}
+
+func TestPlansAPIClientChangeAccountPlan(t *testing.T) {
+	client := newPlansAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/accounts/3/plan.json":
+			fmt.Fprint(w, `{"account_plan":{"id":1,"name":"Default","system_name":"default","state":"published","default":true}}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/admin/api/accounts/3/change_plan.json":
+			ok(t, r.ParseForm())
+			equals(t, "2", r.PostForm.Get("plan_id"))
+			fmt.Fprint(w, `{"account":{"id":3}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	currentPlan, err := client.AccountPlanOfAccount(3)
+	ok(t, err)
+	equals(t, int64(1), currentPlan.Element.ID)
+
+	ok(t, client.ChangeAccountPlan(3, 2))
+}
+
+func TestPlansAPIClientAccountPlanSubscribed(t *testing.T) {
+	requests := []string{}
+	client := newPlansAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/accounts.json":
+			fmt.Fprint(w, `{"accounts":[{"account":{"id":3,"state":"approved"}},{"account":{"id":4,"state":"approved"}}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/accounts/3/plan.json":
+			fmt.Fprint(w, `{"account_plan":{"id":1,"name":"Default","system_name":"default","state":"published","default":true}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/accounts/4/plan.json":
+			fmt.Fprint(w, `{"account_plan":{"id":2,"name":"Basic","system_name":"basic","state":"published"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	subscribed, err := client.AccountPlanSubscribed(1)
+	ok(t, err)
+	assert(t, subscribed, "account plan should be subscribed")
+	// Accounts are not scanned any further once a subscriber is found
+	equals(t, []string{"/admin/api/accounts.json", "/admin/api/accounts/3/plan.json"}, requests)
+
+	subscribed, err = client.AccountPlanSubscribed(5)
+	ok(t, err)
+	assert(t, !subscribed, "account plan should not be subscribed")
+}
+
+func TestPlansAPIClientFeatures(t *testing.T) {
+	client := newPlansAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/services/10/features.json":
+			fmt.Fprint(w, `{"features":[{"feature":{"id":1,"name":"Support","system_name":"support","scope":"ApplicationPlan"}}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/api/services/10/features.json":
+			ok(t, r.ParseForm())
+			equals(t, "ServicePlan", r.PostForm.Get("scope"))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"feature":{"id":2,"name":"SLA","system_name":"sla","scope":"ServicePlan"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/features.json":
+			fmt.Fprint(w, `{"features":[{"feature":{"id":3,"name":"Billing","system_name":"billing","scope":"AccountPlan"}}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/api/application_plans/4/features.json":
+			ok(t, r.ParseForm())
+			equals(t, "1", r.PostForm.Get("feature_id"))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"feature":{"id":1,"name":"Support","system_name":"support","scope":"ApplicationPlan"}}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/admin/api/service_plans/5/features/2.json":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/api/account_plans/6/features.json":
+			fmt.Fprint(w, `{"features":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	list, err := client.ListProductFeatures(10)
+	ok(t, err)
+	equals(t, 1, len(list.Features))
+	equals(t, FeatureItem{ID: 1, Name: "Support", SystemName: "support", Scope: FeatureScopeApplicationPlan}, list.Features[0].Element)
+
+	created, err := client.CreateProductFeature(10, map[string]string{"system_name": "sla", "name": "SLA", "scope": FeatureScopeServicePlan})
+	ok(t, err)
+	equals(t, int64(2), created.Element.ID)
+
+	accountFeatures, err := client.ListAccountFeatures()
+	ok(t, err)
+	equals(t, FeatureScopeAccountPlan, accountFeatures.Features[0].Element.Scope)
+
+	ok(t, client.EnableApplicationPlanFeature(4, 1))
+	ok(t, client.DisableServicePlanFeature(5, 2))
+
+	accountPlanFeatures, err := client.ListAccountPlanFeatures(6)
+	ok(t, err)
+	equals(t, 0, len(accountPlanFeatures.Features))
+
+	err = client.DisableAccountPlanFeature(6, 3)
+	assert(t, IsPlansAPINotFound(err), "expected not found error, got %v", err)
+}
@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	proxyConfigLatestResourceEndpoint = "/admin/api/services/%d/proxy/configs/%s/latest.json"
+)
+
+// ProxyConfigItem holds a proxy configuration keeping its content unchanged.
+// Porta client drops the content fields it does not model
+type ProxyConfigItem struct {
+	ID          int64           `json:"id"`
+	Version     int             `json:"version"`
+	Environment string          `json:"environment"`
+	Content     json.RawMessage `json:"content"`
+}
+
+type ProxyConfig struct {
+	Element ProxyConfigItem `json:"proxy_config"`
+}
+
+// ReadLatestProxyConfig reads the latest proxy configuration of the product environment, sandbox or production
+func (c *PlansAPIClient) ReadLatestProxyConfig(productID int64, environment string) (*ProxyConfig, error) {
+	item := &ProxyConfig{}
+	err := c.do(http.MethodGet, fmt.Sprintf(proxyConfigLatestResourceEndpoint, productID, environment), nil, http.StatusOK, item)
+	return item, err
+}
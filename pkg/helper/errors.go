@@ -56,6 +56,19 @@ func (s *WaitError) Error() string {
 	return s.Err.Error()
 }
 
+// ConflictError represents that the 3scale object matching the resource spec
+// was not created by the resource and has not been adopted.
+// This is not a transient error, but
+// indicates a state that must be fixed before progress can be made.
+// Example: a product with the same system name has been created out of the operator
+type ConflictError struct {
+	Err error
+}
+
+func (s *ConflictError) Error() string {
+	return s.Err.Error()
+}
+
 func IsInvalidSpecError(err error) bool {
 	if specErrorObj, ok := err.(SpecError); ok && specErrorObj.FieldType() == InvalidError {
 		return true
@@ -74,3 +87,8 @@ func IsWaitError(err error) bool {
 	_, ok := err.(*WaitError)
 	return ok
 }
+
+func IsConflictError(err error) bool {
+	_, ok := err.(*ConflictError)
+	return ok
+}
@@ -0,0 +1,289 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"k8s.io/utils/lru"
+)
+
+// DefaultGitOpenAPIDocumentsSize is the maximum number of documents kept by the default loader
+const DefaultGitOpenAPIDocumentsSize = 256
+
+var gitCommitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// GitSourceError is an error of the git source itself, like an unknown ref,
+// a missing file or rejected credentials. Errors reaching the repository are
+// not GitSourceError and may be transient
+type GitSourceError struct {
+	Err error
+}
+
+func (e *GitSourceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *GitSourceError) Unwrap() error {
+	return e.Err
+}
+
+// IsGitSourceError returns whether the error is caused by the git source itself
+func IsGitSourceError(err error) bool {
+	var gitSourceError *GitSourceError
+	return errors.As(err, &gitSourceError)
+}
+
+// gitRemoteError returns GitSourceError when the remote rejects the source, the error otherwise
+func gitRemoteError(err error) error {
+	for _, sourceErr := range []error{
+		transport.ErrRepositoryNotFound,
+		transport.ErrEmptyRemoteRepository,
+		transport.ErrAuthenticationRequired,
+		transport.ErrAuthorizationFailed,
+		transport.ErrInvalidAuthMethod,
+	} {
+		if errors.Is(err, sourceErr) {
+			return &GitSourceError{Err: err}
+		}
+	}
+	return err
+}
+
+// GitOpenAPISource is a file of a git repository holding an OpenAPI document
+type GitOpenAPISource struct {
+	// URL of the git repository
+	URL string
+	// Ref is the branch, tag or commit SHA. Empty for the default branch
+	Ref string
+	// Path of the OpenAPI document within the repository
+	Path string
+	// Auth is the authentication method of the repository. Can be nil
+	Auth transport.AuthMethod
+}
+
+type gitOpenAPIDocument struct {
+	commit  string
+	data    []byte
+	version string
+}
+
+// GitOpenAPILoader loads OpenAPI documents from git repositories.
+// The last document loaded from the most recently used repository files is
+// kept, so the repository is only fetched when the commit the ref resolves to changes
+type GitOpenAPILoader struct {
+	documents *lru.Cache
+}
+
+// NewGitOpenAPILoader returns a loader keeping at most size documents
+func NewGitOpenAPILoader(size int) *GitOpenAPILoader {
+	return &GitOpenAPILoader{documents: lru.New(size)}
+}
+
+// DefaultGitOpenAPILoader is the loader shared by the controllers
+var DefaultGitOpenAPILoader = NewGitOpenAPILoader(DefaultGitOpenAPIDocumentsSize)
+
+// Load resolves the ref of the source to a commit and loads the OpenAPI
+// document from that commit. It returns the loaded document, the detected
+// version of the source document and the commit SHA.
+// Returns GitSourceError when the source cannot be loaded from the repository
+func (l *GitOpenAPILoader) Load(ctx context.Context, source GitOpenAPISource) (*openapi3.T, string, string, error) {
+	commit, refName, err := resolveGitRef(ctx, source)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	documentKey := fmt.Sprintf("%s#%s#%s", source.URL, source.Ref, source.Path)
+	if value, ok := l.documents.Get(documentKey); ok {
+		document := value.(*gitOpenAPIDocument)
+		if document.commit == commit {
+			// The cached document is self-contained
+			openapiObj, err := openapi3.NewLoader().LoadFromData(document.data)
+			if err != nil {
+				return nil, "", "", err
+			}
+			return openapiObj, document.version, document.commit, nil
+		}
+	}
+
+	tree, err := fetchGitTree(ctx, source, commit, refName)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	documentPath := cleanGitPath(source.Path)
+	file, err := tree.File(documentPath)
+	if err != nil {
+		return nil, "", "", &GitSourceError{Err: fmt.Errorf("file %s not found in commit %s: %w", documentPath, commit, err)}
+	}
+
+	data, err := file.Contents()
+	if err != nil {
+		return nil, "", "", &GitSourceError{Err: err}
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = ReadFromGitTree(tree)
+
+	// The tree is already fetched, loading errors are caused by the document
+	openapiObj, version, err := LoadOpenAPIFromData(loader, []byte(data), &url.URL{Path: documentPath})
+	if err != nil {
+		return nil, "", "", &GitSourceError{Err: err}
+	}
+
+	openapiJSON, err := openapiObj.MarshalJSON()
+	if err != nil {
+		return nil, "", "", err
+	}
+	l.documents.Add(documentKey, &gitOpenAPIDocument{commit: commit, data: openapiJSON, version: version})
+
+	return openapiObj, version, commit, nil
+}
+
+// ReadFromGitTree returns a ReadFromURIFunc that reads the relative external
+// references of an OpenAPI document from the files of a git tree, looked up
+// by path
+func ReadFromGitTree(tree *object.Tree) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme != "" || location.Host != "" {
+			return nil, openapi3.ErrURINotSupported
+		}
+
+		file, err := tree.File(cleanGitPath(location.Path))
+		if err != nil {
+			return nil, fmt.Errorf("referenced file %s not found: %w", location.Path, err)
+		}
+
+		data, err := file.Contents()
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(data), nil
+	}
+}
+
+// resolveGitRef lists the remote references to resolve the ref of the source.
+// It returns the commit SHA and the reference to fetch it from, which is empty
+// when the ref is a commit SHA not pointed to by any branch or tag
+func resolveGitRef(ctx context.Context, source GitOpenAPISource) (string, plumbing.ReferenceName, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{source.URL},
+	})
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: source.Auth, PeelingOption: git.AppendPeeled})
+	if err != nil {
+		return "", "", gitRemoteError(fmt.Errorf("listing references of %s: %w", source.URL, err))
+	}
+
+	hashes := map[plumbing.ReferenceName]plumbing.Hash{}
+	symbolic := map[plumbing.ReferenceName]plumbing.ReferenceName{}
+	for _, ref := range refs {
+		switch ref.Type() {
+		case plumbing.HashReference:
+			hashes[ref.Name()] = ref.Hash()
+		case plumbing.SymbolicReference:
+			symbolic[ref.Name()] = ref.Target()
+		}
+	}
+
+	// commit of a reference, peeled for annotated tags
+	commitOf := func(name plumbing.ReferenceName) (string, bool) {
+		if hash, ok := hashes[name+"^{}"]; ok {
+			return hash.String(), true
+		}
+		hash, ok := hashes[name]
+		return hash.String(), ok
+	}
+
+	if source.Ref == "" {
+		if target, ok := symbolic[plumbing.HEAD]; ok {
+			if commit, ok := commitOf(target); ok {
+				return commit, target, nil
+			}
+		}
+		commit, ok := commitOf(plumbing.HEAD)
+		if !ok {
+			return "", "", &GitSourceError{Err: fmt.Errorf("default branch of %s not found", source.URL)}
+		}
+		// HEAD advertised without its target branch
+		for name, hash := range hashes {
+			if name.IsBranch() && hash.String() == commit {
+				return commit, name, nil
+			}
+		}
+		return commit, "", nil
+	}
+
+	for _, name := range []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(source.Ref),
+		plumbing.NewTagReferenceName(source.Ref),
+		plumbing.ReferenceName(source.Ref),
+	} {
+		if commit, ok := commitOf(name); ok {
+			return commit, name, nil
+		}
+	}
+
+	if gitCommitSHARegexp.MatchString(source.Ref) {
+		return source.Ref, "", nil
+	}
+
+	return "", "", &GitSourceError{Err: fmt.Errorf("ref %s not found in %s", source.Ref, source.URL)}
+}
+
+// fetchGitTree fetches the commit from the reference into memory and returns
+// its tree. Only the commit is fetched when the reference is known
+func fetchGitTree(ctx context.Context, source GitOpenAPISource, commit string, refName plumbing.ReferenceName) (*object.Tree, error) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{source.URL},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fetchOptions := &git.FetchOptions{
+		RefSpecs: []config.RefSpec{"+refs/*:refs/*"},
+		Auth:     source.Auth,
+		Tags:     git.NoTags,
+	}
+	if refName != "" {
+		fetchOptions.RefSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))}
+		fetchOptions.Depth = 1
+	}
+
+	err = remote.FetchContext(ctx, fetchOptions)
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, gitRemoteError(fmt.Errorf("fetching %s: %w", source.URL, err))
+	}
+
+	commitObj, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return nil, &GitSourceError{Err: fmt.Errorf("commit %s not found in %s: %w", commit, source.URL, err)}
+	}
+
+	return commitObj.Tree()
+}
+
+func cleanGitPath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
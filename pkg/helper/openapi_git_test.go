@@ -0,0 +1,224 @@
+package helper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const gitPetstoreDocument = `
+openapi: 3.0.2
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+servers:
+  - url: https://petstore.swagger.io/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: A list of pets
+          content:
+            application/json:
+              schema:
+                $ref: "../schemas/pets.yaml"
+`
+
+const gitPetsSchema = `
+type: array
+items:
+  type: string
+`
+
+// gitTestRepo is a bare repository fed from a work tree
+type gitTestRepo struct {
+	t        *testing.T
+	workRepo *git.Repository
+	workDir  string
+	url      string
+}
+
+func newGitTestRepo(t *testing.T) *gitTestRepo {
+	workDir := t.TempDir()
+	workRepo, err := git.PlainInit(workDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bareDir := t.TempDir()
+	_, err = git.PlainInit(bareDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = workRepo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{bareDir}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &gitTestRepo{t: t, workRepo: workRepo, workDir: workDir, url: "file://" + bareDir}
+}
+
+// commit commits the files and pushes the branch to the bare repository
+func (r *gitTestRepo) commit(files map[string]string) string {
+	worktree, err := r.workRepo.Worktree()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	for name, content := range files {
+		filePath := filepath.Join(r.workDir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			r.t.Fatal(err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+
+	hash, err := worktree.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	err = r.workRepo.Push(&git.PushOptions{RefSpecs: []config.RefSpec{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"}})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		r.t.Fatal(err)
+	}
+
+	return hash.String()
+}
+
+func (r *gitTestRepo) tag(name, commit string) {
+	_, err := r.workRepo.CreateTag(name, plumbing.NewHash(commit), &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		Message: name,
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	err = r.workRepo.Push(&git.PushOptions{RefSpecs: []config.RefSpec{"+refs/tags/*:refs/tags/*"}})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		r.t.Fatal(err)
+	}
+}
+
+func TestGitOpenAPILoaderLoad(t *testing.T) {
+	repo := newGitTestRepo(t)
+	firstCommit := repo.commit(map[string]string{
+		"specs/petstore.yaml": gitPetstoreDocument,
+		"schemas/pets.yaml":   gitPetsSchema,
+	})
+	repo.tag("v1", firstCommit)
+
+	loader := NewGitOpenAPILoader(DefaultGitOpenAPIDocumentsSize)
+	source := GitOpenAPISource{URL: repo.url, Path: "specs/petstore.yaml"}
+
+	openapiObj, version, commit, err := loader.Load(context.TODO(), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != firstCommit {
+		t.Errorf("expected commit %s got %s", firstCommit, commit)
+	}
+	if version != "3.0.2" {
+		t.Errorf("expected version 3.0.2 got %s", version)
+	}
+	petsSchema := openapiObj.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema
+	if petsSchema.Value == nil || petsSchema.Value.Type != "array" {
+		t.Errorf("expected referenced schema resolved from the repository, got %v", petsSchema)
+	}
+
+	// New commit
+	secondCommit := repo.commit(map[string]string{
+		"schemas/pets.yaml": "type: object\n",
+	})
+
+	openapiObj, _, commit, err = loader.Load(context.TODO(), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != secondCommit {
+		t.Errorf("expected commit %s got %s", secondCommit, commit)
+	}
+	petsSchema = openapiObj.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema
+	if petsSchema.Value == nil || petsSchema.Value.Type != "object" {
+		t.Errorf("expected schema from the new commit, got %v", petsSchema)
+	}
+
+	// Annotated tag and commit SHA refs
+	for _, ref := range []string{"v1", firstCommit} {
+		source.Ref = ref
+		_, _, commit, err = loader.Load(context.TODO(), source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commit != firstCommit {
+			t.Errorf("ref %s: expected commit %s got %s", ref, firstCommit, commit)
+		}
+	}
+
+	source.Ref = "unknown"
+	_, _, _, err = loader.Load(context.TODO(), source)
+	if !IsGitSourceError(err) {
+		t.Errorf("expected unknown ref source error, got %v", err)
+	}
+
+	source.Ref = ""
+	source.Path = "specs/unknown.yaml"
+	_, _, _, err = loader.Load(context.TODO(), source)
+	if !IsGitSourceError(err) {
+		t.Errorf("expected missing file source error, got %v", err)
+	}
+}
+
+func TestGitOpenAPILoaderLoadUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	loader := NewGitOpenAPILoader(DefaultGitOpenAPIDocumentsSize)
+	_, _, _, err := loader.Load(context.TODO(), GitOpenAPISource{URL: server.URL + "/apis.git", Path: "petstore.yaml"})
+	if err == nil || IsGitSourceError(err) {
+		t.Errorf("expected transient error, got %v", err)
+	}
+}
+
+func TestGitOpenAPILoaderDocumentsBounded(t *testing.T) {
+	repo := newGitTestRepo(t)
+	repo.commit(map[string]string{
+		"specs/petstore.yaml": gitPetstoreDocument,
+		"specs/other.yaml":    gitPetstoreDocument,
+		"schemas/pets.yaml":   gitPetsSchema,
+	})
+
+	loader := NewGitOpenAPILoader(1)
+	for _, documentPath := range []string{"specs/petstore.yaml", "specs/other.yaml"} {
+		_, _, _, err := loader.Load(context.TODO(), GitOpenAPISource{URL: repo.url, Path: documentPath})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if loader.documents.Len() != 1 {
+		t.Errorf("expected 1 document kept, got %d", loader.documents.Len())
+	}
+}
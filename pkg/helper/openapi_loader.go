@@ -0,0 +1,256 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// OpenAPIVersionSwagger2 is the only supported Swagger version
+	OpenAPIVersionSwagger2 = "2.0"
+
+	// openAPIVersionDowngraded is the OpenAPI version OpenAPI 3.1 documents
+	// are downgraded to before being loaded
+	openAPIVersionDowngraded = "3.0.3"
+
+	// OpenAPIFileKeyPathSeparator stands for the directory separator in the
+	// keys of secrets and configmaps, which cannot hold directories
+	OpenAPIFileKeyPathSeparator = "__"
+
+	// OpenAPIHTTPTimeout bounds the requests reading OpenAPI documents from URLs
+	OpenAPIHTTPTimeout = 30 * time.Second
+)
+
+// LoadOpenAPIFromData loads an OpenAPI document in Swagger 2.0, OpenAPI 3.0
+// or OpenAPI 3.1 format, either json or yaml. Swagger 2.0 documents are
+// converted and OpenAPI 3.1 documents downgraded to OpenAPI 3.0. The location,
+// when not nil, is used to resolve the relative external references of the
+// document, which are then internalized so the loaded document is self-contained.
+// It returns the loaded document and the detected version of the source document
+func LoadOpenAPIFromData(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, string, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	versions := struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+	}{}
+	if err := json.Unmarshal(jsonData, &versions); err != nil {
+		return nil, "", err
+	}
+
+	switch {
+	case versions.Swagger != "":
+		if versions.Swagger != OpenAPIVersionSwagger2 {
+			return nil, "", fmt.Errorf("unsupported Swagger version: %s", versions.Swagger)
+		}
+
+		openapiObj, err := swagger2ToOpenAPI3(jsonData)
+		if err != nil {
+			return nil, "", err
+		}
+		return openapiObj, versions.Swagger, nil
+	case strings.HasPrefix(versions.OpenAPI, "3.1"):
+		jsonData, err = downgradeOpenAPI31(jsonData)
+		if err != nil {
+			return nil, "", err
+		}
+	case strings.HasPrefix(versions.OpenAPI, "3.0"):
+	default:
+		return nil, "", fmt.Errorf("unsupported OpenAPI version: %q", versions.OpenAPI)
+	}
+
+	if location == nil {
+		openapiObj, err := loader.LoadFromData(jsonData)
+		if err != nil {
+			return nil, "", err
+		}
+		return openapiObj, versions.OpenAPI, nil
+	}
+
+	openapiObj, err := loader.LoadFromDataWithPath(jsonData, location)
+	if err != nil {
+		return nil, "", err
+	}
+	openapiObj.InternalizeRefs(context.Background(), nil)
+
+	return openapiObj, versions.OpenAPI, nil
+}
+
+// OpenAPIFilePath returns the path, relative to the root of the virtual
+// filesystem, of the file stored in a secret or configmap key:
+// the schemas__pet.yaml key holds the schemas/pet.yaml file
+func OpenAPIFilePath(key string) string {
+	return strings.ReplaceAll(key, OpenAPIFileKeyPathSeparator, "/")
+}
+
+// ReadFromFiles returns a ReadFromURIFunc that reads the relative external
+// references of an OpenAPI document from the given files, looked up by their
+// path relative to the root of the virtual filesystem. The files are usually
+// the keys of a secret or a configmap, see OpenAPIFilePath
+func ReadFromFiles(files map[string][]byte) openapi3.ReadFromURIFunc {
+	filesByPath := make(map[string][]byte, len(files))
+	for key, data := range files {
+		filesByPath[path.Clean(OpenAPIFilePath(key))] = data
+	}
+
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme != "" || location.Host != "" {
+			return nil, openapi3.ErrURINotSupported
+		}
+
+		filePath := path.Clean(location.Path)
+		if path.IsAbs(filePath) || filePath == ".." || strings.HasPrefix(filePath, "../") {
+			return nil, fmt.Errorf("referenced file %s is out of the source files", location.Path)
+		}
+
+		data, ok := filesByPath[filePath]
+		if !ok {
+			return nil, fmt.Errorf("referenced file %s not found", location.Path)
+		}
+
+		return data, nil
+	}
+}
+
+// ReadFromHTTPHost returns a ReadFromURIFunc that reads the OpenAPI document
+// and its external references over http or https from the given host only.
+// Any other URI, like file references to the filesystem of the operator, is rejected
+func ReadFromHTTPHost(host string) openapi3.ReadFromURIFunc {
+	httpClient := &http.Client{
+		Timeout: OpenAPIHTTPTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host != host {
+				return fmt.Errorf("redirect to %s not allowed: only URLs of host %s are supported", req.URL, host)
+			}
+			return nil
+		},
+	}
+	readFromHTTP := openapi3.ReadFromHTTP(httpClient)
+
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme != "http" && location.Scheme != "https" {
+			return nil, fmt.Errorf("reference %s not allowed: only http and https URLs are supported", location)
+		}
+		if location.Host != host {
+			return nil, fmt.Errorf("reference %s not allowed: only URLs of host %s are supported", location, host)
+		}
+
+		return readFromHTTP(loader, location)
+	}
+}
+
+func swagger2ToOpenAPI3(jsonData []byte) (*openapi3.T, error) {
+	swaggerObj := &openapi2.T{}
+	if err := json.Unmarshal(jsonData, swaggerObj); err != nil {
+		return nil, err
+	}
+
+	// Vendor extensions, like x-3scale-product and x-3scale-operation, are
+	// kept by the conversion
+	return openapi2conv.ToV3(swaggerObj)
+}
+
+// downgradeOpenAPI31 rewrites the OpenAPI 3.1 constructs that have an
+// OpenAPI 3.0 equivalent and drops the ones that have not
+func downgradeOpenAPI31(jsonData []byte) ([]byte, error) {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, err
+	}
+
+	doc["openapi"] = openAPIVersionDowngraded
+	delete(doc, "webhooks")
+	delete(doc, "jsonSchemaDialect")
+	// paths object is optional since OpenAPI 3.1
+	if _, ok := doc["paths"]; !ok {
+		doc["paths"] = map[string]interface{}{}
+	}
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		delete(info, "summary")
+		if license, ok := info["license"].(map[string]interface{}); ok {
+			delete(license, "identifier")
+		}
+	}
+
+	downgradeOpenAPI31Node(doc)
+
+	return json.Marshal(doc)
+}
+
+func downgradeOpenAPI31Node(node interface{}) {
+	switch value := node.(type) {
+	case []interface{}:
+		for _, item := range value {
+			downgradeOpenAPI31Node(item)
+		}
+	case map[string]interface{}:
+		downgradeOpenAPI31Schema(value)
+		for key, item := range value {
+			// Extensions are not part of the specification
+			if strings.HasPrefix(key, "x-") {
+				continue
+			}
+			downgradeOpenAPI31Node(item)
+		}
+	}
+}
+
+// downgradeOpenAPI31Schema rewrites the JSON schema keywords of OpenAPI 3.1
+// schema objects. Object properties are never matched, as their values are
+// always objects
+func downgradeOpenAPI31Schema(schema map[string]interface{}) {
+	// type: [string, "null"] -> type: string, nullable: true
+	if types, ok := schema["type"].([]interface{}); ok {
+		delete(schema, "type")
+		for _, t := range types {
+			if t == "null" {
+				schema["nullable"] = true
+			} else if _, ok := schema["type"]; !ok {
+				schema["type"] = t
+			}
+		}
+	}
+	// type: "null" -> nullable: true
+	if schema["type"] == "null" {
+		delete(schema, "type")
+		schema["nullable"] = true
+	}
+
+	// exclusiveMinimum: 1 -> minimum: 1, exclusiveMinimum: true
+	for keyword, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		if limit, ok := schema[keyword].(float64); ok {
+			schema[bound] = limit
+			schema[keyword] = true
+		}
+	}
+
+	// const: a -> enum: [a]
+	if constValue, ok := schema["const"]; ok {
+		if _, isObject := constValue.(map[string]interface{}); !isObject {
+			delete(schema, "const")
+			schema["enum"] = []interface{}{constValue}
+		}
+	}
+
+	// examples: [a, b] -> example: a
+	if examples, ok := schema["examples"].([]interface{}); ok {
+		delete(schema, "examples")
+		if len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package helper
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const swagger2Document = `
+swagger: "2.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+host: petstore.swagger.io
+basePath: /v1
+schemes:
+  - https
+x-3scale-product:
+  metrics:
+    metric01:
+      friendlyName: My Metric 01
+      unit: hits
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-3scale-operation:
+        mappingRule:
+          metricMethodRef: metric01
+          increment: 2
+      responses:
+        "200":
+          description: A list of pets
+          schema:
+            type: array
+            items:
+              type: string
+`
+
+const openAPI31Document = `
+openapi: 3.1.0
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  summary: Pets
+  license:
+    name: MIT
+    identifier: MIT
+servers:
+  - url: https://petstore.swagger.io/v1
+x-3scale-product:
+  metrics:
+    metric01:
+      friendlyName: My Metric 01
+      unit: hits
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: ok
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-3scale-operation:
+        mappingRule:
+          metricMethodRef: metric01
+          increment: 2
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: [integer, "null"]
+            exclusiveMinimum: 0
+            examples: [10]
+      responses:
+        "200":
+          description: A list of pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  type:
+                    const: pet
+`
+
+func TestLoadOpenAPIFromData(t *testing.T) {
+	cases := []struct {
+		name            string
+		data            string
+		expectedVersion string
+	}{
+		{"swagger2", swagger2Document, "2.0"},
+		{"openapi31", openAPI31Document, "3.1.0"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			openapiObj, version, err := LoadOpenAPIFromData(openapi3.NewLoader(), []byte(tc.data), nil)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if version != tc.expectedVersion {
+				subT.Errorf("expected version %s got %s", tc.expectedVersion, version)
+			}
+			if err := openapiObj.Validate(context.TODO()); err != nil {
+				subT.Fatal(err)
+			}
+
+			baseURL, err := BaseURLFromOpenAPI(openapiObj)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if baseURL != "https://petstore.swagger.io" {
+				subT.Errorf("expected base URL https://petstore.swagger.io got %s", baseURL)
+			}
+
+			productExtension, err := NewOasRootProductExtension(openapiObj)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if productExtension == nil || productExtension.Metrics["metric01"].Unit != "hits" {
+				subT.Errorf("expected x-3scale-product extension, got %v", productExtension)
+			}
+
+			operationExtension, err := NewOasOperationExtension(openapiObj.Paths["/pets"].Get)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if operationExtension == nil || operationExtension.MappingRule.Increment != 2 {
+				subT.Errorf("expected x-3scale-operation extension, got %v", operationExtension)
+			}
+		})
+	}
+}
+
+func TestLoadOpenAPIFromDataOpenAPI31Schemas(t *testing.T) {
+	openapiObj, _, err := LoadOpenAPIFromData(openapi3.NewLoader(), []byte(openAPI31Document), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limit := openapiObj.Paths["/pets"].Get.Parameters[0].Value.Schema.Value
+	if limit.Type != "integer" || !limit.Nullable {
+		t.Errorf("expected nullable integer, got type %s nullable %t", limit.Type, limit.Nullable)
+	}
+	if limit.Min == nil || *limit.Min != 0 || !limit.ExclusiveMin {
+		t.Errorf("expected exclusive minimum 0, got %v exclusive %t", limit.Min, limit.ExclusiveMin)
+	}
+	if limit.Example != float64(10) {
+		t.Errorf("expected example 10, got %v", limit.Example)
+	}
+
+	petType := openapiObj.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Properties["type"].Value
+	if len(petType.Enum) != 1 || petType.Enum[0] != "pet" {
+		t.Errorf("expected const as enum, got %v", petType.Enum)
+	}
+}
+
+func TestLoadOpenAPIFromDataUnsupportedVersion(t *testing.T) {
+	for _, data := range []string{`swagger: "1.2"`, `openapi: 4.0.0`, `info: {}`} {
+		_, _, err := LoadOpenAPIFromData(openapi3.NewLoader(), []byte(data), nil)
+		if err == nil {
+			t.Errorf("expected unsupported version error for %s", data)
+		}
+	}
+}
+
+func TestReadFromFiles(t *testing.T) {
+	readFromFiles := ReadFromFiles(map[string][]byte{
+		"openapi.yaml":          []byte("root"),
+		"pet.yaml":              []byte("pet"),
+		"schemas__pet.yaml":     []byte("schemas pet"),
+		"v2__schemas__pet.yaml": []byte("v2 schemas pet"),
+	})
+
+	for filePath, expected := range map[string]string{
+		"pet.yaml":            "pet",
+		"./schemas/pet.yaml":  "schemas pet",
+		"v2/schemas/pet.yaml": "v2 schemas pet",
+		"schemas/../pet.yaml": "pet",
+	} {
+		data, err := readFromFiles(openapi3.NewLoader(), &url.URL{Path: filePath})
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", filePath, err)
+			continue
+		}
+		if string(data) != expected {
+			t.Errorf("%s: expected %q got %q", filePath, expected, data)
+		}
+	}
+
+	for _, filePath := range []string{"other/pet.yaml", "../pet.yaml", "/pet.yaml"} {
+		if _, err := readFromFiles(openapi3.NewLoader(), &url.URL{Path: filePath}); err == nil {
+			t.Errorf("%s: expected error", filePath)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	apps "github.com/3scale/3scale-operator/apis/apps"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -30,6 +31,7 @@ func NewAPIManagerRestore(options *APIManagerRestoreOptions) *APIManagerRestore
 const (
 	RestorePVCMountPath           = "/backup"
 	SystemFileStoragePVCMountPath = "/system-filestorage-pvc"
+	restoreDataVolumeName         = "restore-data"
 )
 
 var secretsToRestore map[string]string = map[string]string{
@@ -198,6 +200,11 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 }
 
 func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options != nil {
+		return b.s3RestoreJob("restore-apm-tosecret-s3", "job", []string{"apimanager"},
+			b.createAPIManagerSharedSecretContainerArgs(), nil, nil)
+	}
+
 	if b.options.APIManagerRestorePVCOptions == nil {
 		return nil
 	}
@@ -252,10 +259,6 @@ func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
 }
 
 func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
-		return nil
-	}
-
 	jobName, err := helper.UIDBasedJobName("resync-domains", b.options.APIManagerRestoreUID)
 	if err != nil {
 		panic(err)
@@ -298,6 +301,424 @@ func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
 	}
 }
 
+func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromS3Job() *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options == nil {
+		return nil
+	}
+
+	return b.s3RestoreJob("restore-cfgmaps-secrets-s3", "restore-cfgmaps-secrets", []string{"secrets", "configmaps"},
+		b.restoreSecretsAndConfigMapsContainerArgs(), nil, nil)
+}
+
+func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromS3Job() *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options == nil {
+		return nil
+	}
+
+	return b.s3RestoreJob("restore-system-fs-s3", "backup-system-filestorage-pvc", []string{"system-filestorage-pvc"},
+		b.restoreSystemFilestoragePVCContainerArgs(),
+		[]v1.Volume{b.systemFileStoragePVCPodVolume()},
+		[]v1.VolumeMount{b.systemFileStoragePVCContainerVolumeMount()},
+	)
+}
+
+// RestoreDatabaseFromPVCJob returns a Job that loads the dump of the given
+// database from the restore data PVC. Nil dumpOptions means the database is
+// not managed by the operator and there is nothing to restore
+func (b *APIManagerRestore) RestoreDatabaseFromPVCJob(dumpOptions *backup.DatabaseDumpOptions) *batchv1.Job {
+	if b.options.APIManagerRestorePVCOptions == nil || dumpOptions == nil {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName(fmt.Sprintf("restore-%s", dumpOptions.Name), b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	restoreContainer := b.databaseRestoreContainer(dumpOptions)
+	restoreContainer.VolumeMounts = []v1.VolumeMount{
+		b.restoreSourcePVCContainerVolumeMount(),
+	}
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreSourcePVCPodVolume(),
+					},
+					Containers: []v1.Container{
+						restoreContainer,
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+// RestoreDatabaseFromS3Job returns a Job that loads the dump of the given
+// database from the S3 restore data source. Nil dumpOptions means the
+// database is not managed by the operator and there is nothing to restore
+func (b *APIManagerRestore) RestoreDatabaseFromS3Job(dumpOptions *backup.DatabaseDumpOptions) *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options == nil || dumpOptions == nil {
+		return nil
+	}
+
+	return b.s3RestoreJobWithContainer(fmt.Sprintf("restore-%s-s3", dumpOptions.Name), []string{"databases"},
+		b.databaseRestoreContainer(dumpOptions), nil)
+}
+
+// databaseRestoreContainer returns a container that loads the dump of the
+// database from the restore data. It uses the database image so the client
+// tools match the server version
+func (b *APIManagerRestore) databaseRestoreContainer(dumpOptions *backup.DatabaseDumpOptions) v1.Container {
+	return v1.Container{
+		Name:  fmt.Sprintf("restore-%s", dumpOptions.Name),
+		Image: dumpOptions.ImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.databaseRestoreContainerArgs(dumpOptions),
+		},
+		Env: []v1.EnvVar{
+			dumpOptions.DatabaseURLEnvVar(),
+		},
+	}
+}
+
+func (b *APIManagerRestore) databaseRestoreContainerArgs(dumpOptions *backup.DatabaseDumpOptions) string {
+	restoreCommand := `psql -v ON_ERROR_STOP=1 -d "${DATABASE_URL}" -f "${DUMP_FILE}";`
+	if dumpOptions.Engine == backup.DatabaseEngineMySQL {
+		restoreCommand = `mysql -h "${DB_HOST}" -P "${DB_PORT}" -u "${DB_USER}" < "${DUMP_FILE}";`
+	}
+
+	return fmt.Sprintf(`
+DUMP_FILE='%s';
+if [ ! -f "${DUMP_FILE}" ]; then
+	echo "Database dump ${DUMP_FILE} not found in the backup data. Skipping restore of the database";
+	exit 0;
+fi;
+%s
+%s
+`,
+		dumpOptions.DumpFilePath(RestorePVCMountPath),
+		dumpOptions.ConnectionEnvScript(),
+		restoreCommand,
+	)
+}
+
+// RestoreRedisFromPVCJob returns a Job that loads the RDB snapshot of the
+// given Redis from the restore data PVC into the Redis PersistentVolumeClaim.
+// Nil snapshotOptions means the Redis is not managed by the operator and there
+// is nothing to restore
+func (b *APIManagerRestore) RestoreRedisFromPVCJob(snapshotOptions *backup.RedisSnapshotOptions) *batchv1.Job {
+	if b.options.APIManagerRestorePVCOptions == nil || snapshotOptions == nil {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName(fmt.Sprintf("restore-%s", snapshotOptions.Name), b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	restoreContainer := b.redisRestoreContainer(snapshotOptions)
+	restoreContainer.VolumeMounts = append([]v1.VolumeMount{b.restoreSourcePVCContainerVolumeMount()}, restoreContainer.VolumeMounts...)
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreSourcePVCPodVolume(),
+						b.redisPVCPodVolume(snapshotOptions),
+					},
+					Containers: []v1.Container{
+						restoreContainer,
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+// RestoreRedisFromS3Job returns a Job that loads the RDB snapshot of the
+// given Redis from the S3 restore data source into the Redis
+// PersistentVolumeClaim. Nil snapshotOptions means the Redis is not managed
+// by the operator and there is nothing to restore
+func (b *APIManagerRestore) RestoreRedisFromS3Job(snapshotOptions *backup.RedisSnapshotOptions) *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options == nil || snapshotOptions == nil {
+		return nil
+	}
+
+	return b.s3RestoreJobWithContainer(fmt.Sprintf("restore-%s-s3", snapshotOptions.Name), []string{"redis"},
+		b.redisRestoreContainer(snapshotOptions),
+		[]v1.Volume{b.redisPVCPodVolume(snapshotOptions)},
+	)
+}
+
+// RedisPVC returns the PersistentVolumeClaim of the given Redis. It is
+// created before the APIManager so the snapshot can be loaded into it
+func (b *APIManagerRestore) RedisPVC(snapshotOptions *backup.RedisSnapshotOptions) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshotOptions.PVCName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: snapshotOptions.PVCStorageClass,
+			AccessModes: []v1.PersistentVolumeAccessMode{
+				v1.ReadWriteOnce,
+			},
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{
+					// We hardcode the size due to in APIManager is hardcoded to 1Gi. If in
+					// the future this changes we should change it here too or update the
+					// logic here
+					v1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+}
+
+func (b *APIManagerRestore) redisPVCPodVolume(snapshotOptions *backup.RedisSnapshotOptions) v1.Volume {
+	return v1.Volume{
+		Name: snapshotOptions.PVCName,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: snapshotOptions.PVCName,
+			},
+		},
+	}
+}
+
+// redisRestoreContainer returns a container that loads the RDB snapshot into
+// the Redis data directory. The Redis configuration of the APIManager enables
+// AOF persistence, which takes precedence over the RDB file when Redis
+// starts, so the snapshot is loaded into a temporary local Redis server that
+// rewrites the AOF files from it
+func (b *APIManagerRestore) redisRestoreContainer(snapshotOptions *backup.RedisSnapshotOptions) v1.Container {
+	return v1.Container{
+		Name:  fmt.Sprintf("restore-%s", snapshotOptions.Name),
+		Image: snapshotOptions.ImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.redisRestoreContainerArgs(snapshotOptions),
+		},
+		VolumeMounts: []v1.VolumeMount{
+			v1.VolumeMount{
+				Name:      snapshotOptions.PVCName,
+				MountPath: component.RedisDataPath,
+			},
+		},
+	}
+}
+
+func (b *APIManagerRestore) redisRestoreContainerArgs(snapshotOptions *backup.RedisSnapshotOptions) string {
+	return fmt.Sprintf(`
+SNAPSHOT_FILE='%s';
+REDIS_DATA_DIR='%s';
+if [ ! -f "${SNAPSHOT_FILE}" ]; then
+	echo "Redis snapshot ${SNAPSHOT_FILE} not found in the backup data. Skipping restore of the Redis data";
+	exit 0;
+fi;
+rm -rf ${REDIS_DATA_DIR}/appendonlydir ${REDIS_DATA_DIR}/appendonly.aof;
+cp ${SNAPSHOT_FILE} ${REDIS_DATA_DIR}/dump.rdb;
+redis-server --bind 127.0.0.1 --port 6379 --dir ${REDIS_DATA_DIR} --dbfilename dump.rdb --appendonly no --daemonize yes;
+until redis-cli PING | grep -q PONG; do
+	sleep 1;
+done;
+redis-cli CONFIG SET appendonly yes;
+until redis-cli INFO persistence | grep -q 'aof_rewrite_in_progress:0' && redis-cli INFO persistence | grep -q 'aof_rewrite_scheduled:0'; do
+	sleep 1;
+done;
+redis-cli SHUTDOWN || true;
+`,
+		snapshotOptions.SnapshotFilePath(RestorePVCMountPath),
+		component.RedisDataPath,
+	)
+}
+
+// s3RestoreJob returns a Job that downloads the given subdirectories of the
+// backup data from the S3 restore data source in an init container, storing
+// them in a temporary volume mounted at the same path the restore data PVC
+// would be mounted, and then runs the given restore script
+func (b *APIManagerRestore) s3RestoreJob(jobNamePrefix, containerName string, subdirs []string, containerArgs string, volumes []v1.Volume, volumeMounts []v1.VolumeMount) *batchv1.Job {
+	return b.s3RestoreJobWithContainer(jobNamePrefix, subdirs,
+		v1.Container{
+			Name:  containerName,
+			Image: b.options.OCCLIImageURL,
+			Command: []string{
+				"/bin/bash",
+			},
+			Args: []string{
+				"-c",
+				"-e",
+				containerArgs,
+			},
+			VolumeMounts: volumeMounts,
+		},
+		volumes,
+	)
+}
+
+// s3RestoreJobWithContainer is like s3RestoreJob but runs the given restore
+// container after the download
+func (b *APIManagerRestore) s3RestoreJobWithContainer(jobNamePrefix string, subdirs []string, restoreContainer v1.Container, volumes []v1.Volume) *batchv1.Job {
+	jobName, err := helper.UIDBasedJobName(jobNamePrefix, b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	restoreContainer.VolumeMounts = append([]v1.VolumeMount{b.restoreDataContainerVolumeMount()}, restoreContainer.VolumeMounts...)
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: append([]v1.Volume{b.restoreDataPodVolume()}, volumes...),
+					InitContainers: []v1.Container{
+						v1.Container{
+							Name:  "download-from-s3",
+							Image: b.options.AWSCLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								b.downloadFromS3ContainerArgs(subdirs),
+							},
+							Env: b.s3ContainerEnv(),
+							VolumeMounts: []v1.VolumeMount{
+								b.restoreDataContainerVolumeMount(),
+							},
+						},
+					},
+					Containers: []v1.Container{
+						restoreContainer,
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+func (b *APIManagerRestore) restoreDataPodVolume() v1.Volume {
+	return v1.Volume{
+		Name: restoreDataVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (b *APIManagerRestore) restoreDataContainerVolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      restoreDataVolumeName,
+		MountPath: RestorePVCMountPath,
+	}
+}
+
+func (b *APIManagerRestore) s3ContainerEnv() []v1.EnvVar {
+	s3Options := b.options.APIManagerRestoreS3Options
+	res := []v1.EnvVar{
+		helper.EnvVarFromSecret(apps.AwsAccessKeyID, s3Options.CredentialsSecretName, apps.AwsAccessKeyID),
+		helper.EnvVarFromSecret(apps.AwsSecretAccessKey, s3Options.CredentialsSecretName, apps.AwsSecretAccessKey),
+	}
+	if s3Options.Region != nil {
+		res = append(res, helper.EnvVarFromValue("AWS_DEFAULT_REGION", *s3Options.Region))
+	}
+	return res
+}
+
+// downloadFromS3ContainerArgs returns the script that downloads the given
+// subdirectories of the backup data. When no subdirectories are given the
+// whole backup data is downloaded
+func (b *APIManagerRestore) downloadFromS3ContainerArgs(subdirs []string) string {
+	s3Options := b.options.APIManagerRestoreS3Options
+	endpointArgs := ""
+	if s3Options.Endpoint != nil {
+		endpointArgs = fmt.Sprintf("--endpoint-url %s", *s3Options.Endpoint)
+	}
+	if len(subdirs) == 0 {
+		return fmt.Sprintf(`
+BASEPATH='%s';
+S3_SOURCE='s3://%s/%s';
+aws %s s3 cp --recursive ${S3_SOURCE}/ ${BASEPATH}/;
+`,
+			RestorePVCMountPath,
+			s3Options.Bucket,
+			s3Options.Path,
+			endpointArgs,
+		)
+	}
+	return fmt.Sprintf(`
+BASEPATH='%s';
+S3_SOURCE='s3://%s/%s';
+SUBDIRS='%s';
+for i in $(echo -n $SUBDIRS); do
+	aws %s s3 cp --recursive ${S3_SOURCE}/${i} ${BASEPATH}/${i};
+done;
+`,
+		RestorePVCMountPath,
+		s3Options.Bucket,
+		s3Options.Path,
+		strings.Join(subdirs, " "),
+		endpointArgs,
+	)
+}
+
 func (b *APIManagerRestore) SystemStoragePVC(restoreInfo *RuntimeAPIManagerRestoreInfo) *v1.PersistentVolumeClaim {
 	return &v1.PersistentVolumeClaim{
 		TypeMeta: metav1.TypeMeta{
@@ -3,6 +3,8 @@ package restore
 import (
 	validator "github.com/go-playground/validator/v10"
 	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 )
 
 type APIManagerRestoreOptions struct {
@@ -10,8 +12,12 @@ type APIManagerRestoreOptions struct {
 	APIManagerRestoreName string    `validate:"required"` // Name of the APIManagerRestore CR. NOT the backup or APIManager name
 	APIManagerRestoreUID  types.UID `validate:"required"` // UID of the APIManagerRestore CR
 
-	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions `validate:"required"`
-	OCCLIImageURL               string                       `validate:"required"`
+	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions // Union type with APIManagerRestoreS3Options. Only one of them is set
+	APIManagerRestoreS3Options  *APIManagerRestoreS3Options
+	OCCLIImageURL               string `validate:"required"`
+	AWSCLIImageURL              string `validate:"required"`
+
+	RouteHostRemaps []appsv1alpha1.RouteHostRemap // Route host domains remapped in the restored system database
 }
 
 func NewAPIManagerRestoreOptions() *APIManagerRestoreOptions {
@@ -2,6 +2,7 @@ package restore
 
 import (
 	"fmt"
+	"strings"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
@@ -28,18 +29,29 @@ func (a *APIManagerRestoreOptionsProvider) Options() (*APIManagerRestoreOptions,
 	res.Namespace = a.APIManagerRestoreCR.Namespace
 
 	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.AWSCLIImageURL = a.awsCLIImageURL()
+	res.RouteHostRemaps = a.APIManagerRestoreCR.Spec.RouteHostRemaps
 
 	pvcOptions, err := a.pvcRestoreOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3RestoreOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerRestore struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one restore source has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one restore source can be specified")
+	}
 
 	res.APIManagerRestorePVCOptions = pvcOptions
+	res.APIManagerRestoreS3Options = s3Options
 
 	return res, res.Validate()
 }
@@ -55,6 +67,26 @@ func (a *APIManagerRestoreOptionsProvider) pvcRestoreOptions() (*APIManagerResto
 	return res, res.Validate()
 }
 
+func (a *APIManagerRestoreOptionsProvider) s3RestoreOptions() (*APIManagerRestoreS3Options, error) {
+	s3Spec := a.APIManagerRestoreCR.Spec.RestoreSource.S3
+	if s3Spec == nil {
+		return nil, nil
+	}
+
+	res := NewAPIManagerRestoreS3Options()
+	res.Bucket = s3Spec.Bucket
+	res.Path = strings.Trim(s3Spec.Path, "/")
+	res.Region = s3Spec.Region
+	res.Endpoint = s3Spec.Endpoint
+	res.CredentialsSecretName = s3Spec.CredentialsSecretRef.Name
+
+	return res, res.Validate()
+}
+
 func (a *APIManagerRestoreOptionsProvider) ocCLIImageURL() string {
 	return helper.GetEnvVar("RELATED_IMAGE_OC_CLI", component.OCCLIImageURL())
 }
+
+func (a *APIManagerRestoreOptionsProvider) awsCLIImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_AWS_CLI", component.AWSCLIImageURL())
+}
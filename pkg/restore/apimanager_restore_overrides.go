@@ -0,0 +1,143 @@
+package restore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// ApplyAPIManagerOverrides applies the given JSON merge patch to the spec of
+// the backed up APIManager. Nil or empty overrides leave it unchanged
+func ApplyAPIManagerOverrides(apimanager *appsv1alpha1.APIManager, overrides *runtime.RawExtension) error {
+	if overrides == nil || len(overrides.Raw) == 0 {
+		return nil
+	}
+
+	specJSON, err := json.Marshal(apimanager.Spec)
+	if err != nil {
+		return err
+	}
+
+	patchedSpecJSON, err := jsonpatch.MergePatch(specJSON, overrides.Raw)
+	if err != nil {
+		return fmt.Errorf("Error applying APIManager overrides: %w", err)
+	}
+
+	patchedSpec := appsv1alpha1.APIManagerSpec{}
+	err = json.Unmarshal(patchedSpecJSON, &patchedSpec)
+	if err != nil {
+		return fmt.Errorf("Error applying APIManager overrides: %w", err)
+	}
+
+	apimanager.Spec = patchedSpec
+	return nil
+}
+
+// RemapRouteHostsJob returns a Job that replaces the remapped route host
+// domains in the 3scale tenant domains and APIcast endpoints stored in the
+// restored system database. It has to run before the routes are
+// resynchronized by zync. It returns nil when there are no remappings
+func (b *APIManagerRestore) RemapRouteHostsJob() *batchv1.Job {
+	if len(b.options.RouteHostRemaps) == 0 {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("remap-route-hosts", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "job",
+							Image: b.options.OCCLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								b.remapRouteHostsContainerArgs(),
+							},
+						},
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+func (b *APIManagerRestore) remapRouteHostsContainerArgs() string {
+	return `
+	dname="system-sidekiq"
+	dpods=$(oc get pods --ignore-not-found=true -l deployment=${dname} --no-headers=true -o custom-columns=:metadata.name)
+	if [ -z "${dpods}" ]; then
+		echo "No pods found for Deployment ${dname}"
+		exit 1
+	fi
+	podname=$(echo -n $dpods | awk '{print $1}')
+	oc exec -i ${podname} -- bash -c "bundle exec rails runner -" <<'RUBY_SCRIPT'
+` + b.rubyRemapRouteHostsScript() + `RUBY_SCRIPT
+`
+}
+
+// rubyRemapRouteHostsScript returns a script that replaces each remapped
+// domain when it is the suffix of a host of the tenant domains or of the
+// APIcast endpoints. The columns are updated without callbacks, as zync is
+// notified afterwards by the resynchronization of the domains
+func (b *APIManagerRestore) rubyRemapRouteHostsScript() string {
+	remaps := &strings.Builder{}
+	for _, remap := range b.options.RouteHostRemaps {
+		fmt.Fprintf(remaps, "  '%s' => '%s',\n", remap.From, remap.To)
+	}
+
+	return `remaps = {
+` + remaps.String() + `}
+columns = {
+  Account => %w[domain self_domain],
+  Proxy => %w[endpoint sandbox_endpoint staging_endpoint],
+}
+remaps.each do |from, to|
+  pattern = /(?<![a-z0-9-])#{Regexp.escape(from)}(?=[:\/]|\z)/
+  columns.each do |model, attrs|
+    attrs = attrs.select { |attr| model.column_names.include?(attr) }
+    model.find_each do |record|
+      changes = {}
+      attrs.each do |attr|
+        value = record.read_attribute(attr)
+        changes[attr] = value.sub(pattern, to) if value&.match?(pattern)
+      end
+      next if changes.empty?
+      puts "#{model.name} #{record.id}: #{changes}"
+      record.update_columns(changes)
+    end
+  end
+end
+`
+}
@@ -0,0 +1,71 @@
+package restore
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestApplyAPIManagerOverrides(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{}
+	apimanager.Name = "example-apimanager"
+	apimanager.Spec.WildcardDomain = "prod.example.com"
+	apimanager.Spec.ResourceRequirementsEnabled = ptr.To(true)
+
+	overrides := &runtime.RawExtension{Raw: []byte(`{"wildcardDomain": "staging.example.com", "resourceRequirementsEnabled": null}`)}
+	err := ApplyAPIManagerOverrides(apimanager, overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apimanager.Spec.WildcardDomain != "staging.example.com" {
+		t.Errorf("expected wildcardDomain staging.example.com got %s", apimanager.Spec.WildcardDomain)
+	}
+	if apimanager.Spec.ResourceRequirementsEnabled != nil {
+		t.Errorf("expected resourceRequirementsEnabled removed got %v", *apimanager.Spec.ResourceRequirementsEnabled)
+	}
+	if apimanager.Name != "example-apimanager" {
+		t.Errorf("expected name to be kept, got %s", apimanager.Name)
+	}
+
+	err = ApplyAPIManagerOverrides(apimanager, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apimanager.Spec.WildcardDomain != "staging.example.com" {
+		t.Errorf("expected nil overrides to keep the spec, got wildcardDomain %s", apimanager.Spec.WildcardDomain)
+	}
+
+	err = ApplyAPIManagerOverrides(apimanager, &runtime.RawExtension{Raw: []byte(`{"wildcardDomain": 1}`)})
+	if err == nil {
+		t.Error("expected error for overrides not matching the APIManager spec")
+	}
+}
+
+func TestRemapRouteHostsJob(t *testing.T) {
+	options := &APIManagerRestoreOptions{
+		Namespace:             "staging",
+		APIManagerRestoreName: "example-restore",
+		APIManagerRestoreUID:  "4b85d0e1-0c3b-4e0b-9d4f-000000000000",
+		OCCLIImageURL:         "oc-cli",
+	}
+
+	if job := NewAPIManagerRestore(options).RemapRouteHostsJob(); job != nil {
+		t.Errorf("expected no Job without remappings, got %s", job.Name)
+	}
+
+	options.RouteHostRemaps = []appsv1alpha1.RouteHostRemap{
+		{From: "prod.example.com", To: "staging.example.com"},
+	}
+	job := NewAPIManagerRestore(options).RemapRouteHostsJob()
+	if job == nil {
+		t.Fatal("expected remap route hosts Job")
+	}
+	args := job.Spec.Template.Spec.Containers[0].Args[2]
+	if !strings.Contains(args, "'prod.example.com' => 'staging.example.com',") {
+		t.Errorf("expected remapping in the Job script, got %s", args)
+	}
+}
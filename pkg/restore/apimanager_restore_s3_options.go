@@ -0,0 +1,22 @@
+package restore
+
+import (
+	validator "github.com/go-playground/validator/v10"
+)
+
+type APIManagerRestoreS3Options struct {
+	Bucket                string  `validate:"required"`
+	Path                  string  `validate:"required"` // Path inside the bucket where the backup data is stored
+	Region                *string // TODO should we validate the region in case we define it?
+	Endpoint              *string `validate:"omitempty,url"`
+	CredentialsSecretName string  `validate:"required"`
+}
+
+func NewAPIManagerRestoreS3Options() *APIManagerRestoreS3Options {
+	return &APIManagerRestoreS3Options{}
+}
+
+func (a *APIManagerRestoreS3Options) Validate() error {
+	validate := validator.New()
+	return validate.Struct(a)
+}
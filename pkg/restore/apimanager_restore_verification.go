@@ -0,0 +1,162 @@
+package restore
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	// Keys of the ConfigMap where the backup verification Job writes its result
+	BackupVerificationResultKey             = "result"
+	BackupVerificationOperatorVersionKey    = "operatorVersion"
+	BackupVerificationAPIManagerSpecHashKey = "apiManagerSpecHash"
+	BackupVerificationFailuresKey           = "failures"
+
+	// Results of the backup verification Job
+	BackupVerificationResultVerified         = "Verified"
+	BackupVerificationResultManifestNotFound = "ManifestNotFound"
+	BackupVerificationResultChecksumMismatch = "ChecksumMismatch"
+)
+
+// VerifyBackupFromPVCJob returns a Job that verifies the backup data stored
+// in the restore data PVC against its backup integrity manifest
+func (b *APIManagerRestore) VerifyBackupFromPVCJob() *batchv1.Job {
+	if b.options.APIManagerRestorePVCOptions == nil {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("restore-verify-backup", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreSourcePVCPodVolume(),
+					},
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "verify-backup",
+							Image: b.options.OCCLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								b.verifyBackupContainerArgs(),
+							},
+							VolumeMounts: []v1.VolumeMount{
+								b.restoreSourcePVCContainerVolumeMount(),
+							},
+						},
+					},
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+					ServiceAccountName: ServiceAccountName,
+				},
+			},
+		},
+	}
+}
+
+// VerifyBackupFromS3Job returns a Job that downloads the whole backup data
+// from the S3 restore data source and verifies it against its backup
+// integrity manifest
+func (b *APIManagerRestore) VerifyBackupFromS3Job() *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options == nil {
+		return nil
+	}
+
+	return b.s3RestoreJob("restore-verify-backup-s3", "verify-backup", nil,
+		b.verifyBackupContainerArgs(), nil, nil)
+}
+
+// BackupVerificationConfigMapName returns the name of the ConfigMap where the
+// backup verification Job writes its result
+func (b *APIManagerRestore) BackupVerificationConfigMapName() string {
+	return fmt.Sprintf("%s-backup-verification", b.options.APIManagerRestoreName)
+}
+
+// verifyBackupContainerArgs returns the script that checks the backup data
+// against the checksums of the backup integrity manifest. The outcome is
+// written into a ConfigMap instead of failing the Job so the operator can
+// tell a corrupted backup apart from a backup without manifest
+func (b *APIManagerRestore) verifyBackupContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+MANIFEST_FILE='%s';
+CONFIGMAP='%s';
+PYTHON_MANIFEST_SUBSCRIPT="%s"
+cd ${BASEPATH};
+OPERATOR_VERSION='';
+SPEC_HASH='';
+FAILURES='';
+if [ ! -f ${MANIFEST_FILE} ]; then
+	RESULT='%s';
+else
+	python -c "${PYTHON_MANIFEST_SUBSCRIPT}" checksums < ${MANIFEST_FILE} > /tmp/checksums;
+	OPERATOR_VERSION=$(python -c "${PYTHON_MANIFEST_SUBSCRIPT}" operatorVersion < ${MANIFEST_FILE});
+	SPEC_HASH=$(python -c "${PYTHON_MANIFEST_SUBSCRIPT}" apiManagerSpecHash < ${MANIFEST_FILE});
+	if sha256sum -c --quiet /tmp/checksums > /tmp/failures 2>&1; then
+		RESULT='%s';
+	else
+		RESULT='%s';
+		FAILURES=$(head -n 10 /tmp/failures);
+	fi
+fi
+oc create configmap ${CONFIGMAP} \
+	--from-literal=%s="${RESULT}" \
+	--from-literal=%s="${OPERATOR_VERSION}" \
+	--from-literal=%s="${SPEC_HASH}" \
+	--from-literal=%s="${FAILURES}" \
+	--dry-run=client -o yaml | oc apply -f -;
+`,
+		RestorePVCMountPath,
+		backup.ManifestFileName,
+		b.BackupVerificationConfigMapName(),
+		b.pythonManifestScript(),
+		BackupVerificationResultManifestNotFound,
+		BackupVerificationResultVerified,
+		BackupVerificationResultChecksumMismatch,
+		BackupVerificationResultKey,
+		BackupVerificationOperatorVersionKey,
+		BackupVerificationAPIManagerSpecHashKey,
+		BackupVerificationFailuresKey,
+	)
+}
+
+// pythonManifestScript returns a script that prints either the file checksums
+// of the backup integrity manifest read from stdin, in the format expected by
+// sha256sum, or the value of the given manifest attribute
+func (b *APIManagerRestore) pythonManifestScript() string {
+	return `
+import sys, json
+
+manifest=json.load(sys.stdin)
+if sys.argv[1] == 'checksums':
+  for f in manifest['files']:
+    print(f['sha256'] + '  ' + f['path'])
+else:
+  print(manifest.get(sys.argv[1], ''))
+`
+}
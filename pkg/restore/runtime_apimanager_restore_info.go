@@ -1,5 +1,13 @@
 package restore
 
+import (
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
 type RuntimeAPIManagerRestoreInfo struct {
-	PVCStorageClass *string
+	PVCStorageClass             *string
+	SystemDatabaseDumpOptions   *backup.DatabaseDumpOptions  // Only set when the system database is managed by the operator
+	ZyncDatabaseDumpOptions     *backup.DatabaseDumpOptions  // Only set when the zync database is managed by the operator
+	BackendRedisSnapshotOptions *backup.RedisSnapshotOptions // Only set when the backend Redis is managed by the operator
+	SystemRedisSnapshotOptions  *backup.RedisSnapshotOptions // Only set when the system Redis is managed by the operator
 }
@@ -23,6 +23,12 @@ const (
 	startTimePath                                    = "/status/startTime"
 	completionTimePath                               = "/status/completionTime"
 	lastTransitionTimePath                           = "/status/conditions/lastTransitionTime"
+	backupTemplatePVCResourceRequestsPath            = "/spec/backupTemplate/backupDestination/persistentVolumeClaim/resources/requests"
+	lastScheduleTimePath                             = "/status/lastScheduleTime"
+	nextScheduleTimePath                             = "/status/nextScheduleTime"
+	lastSuccessfulTimePath                           = "/status/lastSuccessfulTime"
+	lastFailureTimePath                              = "/status/lastFailureTime"
+	lastSyncTimePath                                 = "/status/lastSyncTime"
 	systemSharedPVCResourceRequestsPath              = "/spec/system/fileStorage/persistentVolumeClaim/resources/requests"
 	systemMySQLPVCResourceRequestsPath               = "/spec/system/database/mysql/persistentVolumeClaim/resources/requests"
 	systemPostgreSQLPVCResourceRequestsPath          = "/spec/system/database/postgresql/persistentVolumeClaim/resources/requests"
@@ -30,6 +36,7 @@ const (
 	systemSearchdPVCResourceRequestsPath             = "/spec/system/searchdSpec/persistentVolumeClaim/resources/requests"
 	productPoliciesConfigurationPath                 = "/spec/policies/configuration"
 	policyConfigurationPath                          = "/spec/schema/configuration"
+	apiManagerOverridesPath                          = "/spec/apiManagerOverrides"
 	resourceClaimsRegex                              = "^/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/claims.*"
 	podAffinityMatchLabelKeysRegex                   = "^/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?/.*DuringSchedulingIgnoredDuringExecution/(?:podAffinityTerm/)?(mis)?matchLabelKeys"
 	topologySpreadConstraintsMatchLabelKeysRegex     = "^/([a-zA-Z]+)/([a-zA-Z]+)(?:/([a-zA-Z]+))?(?:/([a-zA-Z]+))?/.*[tT]opologySpreadConstraints/matchLabelKeys$"
@@ -58,6 +65,10 @@ func TestSampleCustomResources(t *testing.T) {
 			crPrefix:   "apps_v1alpha1_apimanagerbackup.yaml",
 			apiVersion: apps.GroupVersion.Version,
 		},
+		"apps.3scale.net_apimanagerbackupschedules.yaml": {
+			crPrefix:   "apps_v1alpha1_apimanagerbackupschedule",
+			apiVersion: apps.GroupVersion.Version,
+		},
 		"apps.3scale.net_apimanagerrestores.yaml": {
 			crPrefix:   "apps_v1alpha1_apimanagerrestore.yaml",
 			apiVersion: apps.GroupVersion.Version,
@@ -90,6 +101,10 @@ func TestSampleCustomResources(t *testing.T) {
 			crPrefix:   "capabilities_v1beta1_developeraccount",
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_accountplans.yaml": {
+			crPrefix:   "capabilities_v1beta1_accountplan",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 		"capabilities.3scale.net_developerusers.yaml": {
 			crPrefix:   "capabilities_v1beta1_developeruser",
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
@@ -139,6 +154,10 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &apps.APIManagerBackup{},
 			apiVersion: apps.GroupVersion.Version,
 		},
+		"apps.3scale.net_apimanagerbackupschedules.yaml": {
+			obj:        &apps.APIManagerBackupSchedule{},
+			apiVersion: apps.GroupVersion.Version,
+		},
 		"apps.3scale.net_apimanagerrestores.yaml": {
 			obj:        &apps.APIManagerRestore{},
 			apiVersion: apps.GroupVersion.Version,
@@ -171,6 +190,10 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &capabilitiesv1beta1.DeveloperAccount{},
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_accountplans.yaml": {
+			obj:        &capabilitiesv1beta1.AccountPlan{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 		"capabilities.3scale.net_developerusers.yaml": {
 			obj:        &capabilitiesv1beta1.DeveloperUser{},
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
@@ -182,11 +205,18 @@ func TestCompleteCRD(t *testing.T) {
 		startTimePath,
 		completionTimePath,
 		lastTransitionTimePath,
+		backupTemplatePVCResourceRequestsPath,
+		lastScheduleTimePath,
+		nextScheduleTimePath,
+		lastSuccessfulTimePath,
+		lastFailureTimePath,
+		lastSyncTimePath,
 		systemSharedPVCResourceRequestsPath,
 		systemMySQLPVCResourceRequestsPath,
 		systemPostgreSQLPVCResourceRequestsPath,
 		productPoliciesConfigurationPath,
 		policyConfigurationPath,
+		apiManagerOverridesPath,
 		systemSearchdResourceRequestsPath,
 		systemSearchdPVCResourceRequestsPath,
 	}